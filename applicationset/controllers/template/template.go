@@ -2,6 +2,8 @@ package template
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -63,6 +65,17 @@ func GenerateApplications(logCtx *log.Entry, applicationSetInfo argov1alpha1.App
 					app = patchedApplication
 				}
 
+				if err := validateSyncPolicy(app); err != nil {
+					logCtx.WithError(err).WithField("params", a.Params).WithField("generator", requestedGenerator).
+						Error("error generating application from params")
+
+					if firstError == nil {
+						firstError = err
+						applicationSetReason = argov1alpha1.ApplicationSetReasonRenderTemplateParamsError
+					}
+					continue
+				}
+
 				// The app's namespace must be the same as the AppSet's namespace to preserve the appsets-in-any-namespace
 				// security boundary.
 				app.Namespace = applicationSetInfo.Namespace
@@ -88,6 +101,31 @@ func renderTemplatePatch(r utils.Renderer, app *argov1alpha1.Application, applic
 	return applyTemplatePatch(app, replacedTemplate)
 }
 
+// validateSyncPolicy verifies that the sync policy of a rendered Application is well-formed. The sync policy, including
+// its boolean fields (through templatePatch), may be templated from generator parameters, so a bad parameter value must
+// fail the generation of that Application instead of producing one the controller is unable to sync.
+func validateSyncPolicy(app *argov1alpha1.Application) error {
+	syncPolicy := app.Spec.SyncPolicy
+	if syncPolicy == nil {
+		return nil
+	}
+	for _, option := range syncPolicy.SyncOptions {
+		if strings.Contains(option, "{{") {
+			return fmt.Errorf("application %q has unrendered sync option %q", app.Name, option)
+		}
+		key, value, ok := strings.Cut(option, "=")
+		if !ok || strings.TrimSpace(key) == "" || strings.TrimSpace(value) == "" {
+			return fmt.Errorf("application %q has invalid sync option %q: expected format <key>=<value>", app.Name, option)
+		}
+	}
+	if syncPolicy.Retry != nil {
+		if _, err := syncPolicy.Retry.NextRetryAt(time.Now(), 0); err != nil {
+			return fmt.Errorf("application %q has invalid retry backoff: %w", app.Name, err)
+		}
+	}
+	return nil
+}
+
 func GetTempApplication(applicationSetTemplate argov1alpha1.ApplicationSetTemplate) *argov1alpha1.Application {
 	var tmplApplication argov1alpha1.Application
 	tmplApplication.Annotations = applicationSetTemplate.Annotations
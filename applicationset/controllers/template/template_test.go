@@ -348,3 +348,128 @@ func TestGenerateAppsUsingPullRequestGenerator(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateAppsWithTemplatedSyncPolicy(t *testing.T) {
+	params := []map[string]any{
+		{"name": "dev", "selfHeal": true, "createNamespace": "true"},
+		{"name": "prod", "selfHeal": false, "createNamespace": "false"},
+		{"name": "broken", "selfHeal": false, "createNamespace": ""},
+	}
+	template := v1alpha1.ApplicationSetTemplate{
+		ApplicationSetTemplateMeta: v1alpha1.ApplicationSetTemplateMeta{
+			Name: "guestbook-{{.name}}",
+		},
+		Spec: v1alpha1.ApplicationSpec{
+			Source: &v1alpha1.ApplicationSource{
+				RepoURL: "https://testurl/testRepo",
+			},
+			Destination: v1alpha1.ApplicationDestination{
+				Server:    "https://kubernetes.default.svc",
+				Namespace: "{{.name}}",
+			},
+			SyncPolicy: &v1alpha1.SyncPolicy{
+				SyncOptions: v1alpha1.SyncOptions{"CreateNamespace={{.createNamespace}}"},
+			},
+		},
+	}
+	templatePatch := `
+spec:
+  syncPolicy:
+    automated:
+      selfHeal: {{ .selfHeal }}
+`
+
+	generatorMock := &genmock.Generator{}
+	generator := v1alpha1.ApplicationSetGenerator{
+		List: &v1alpha1.ListGenerator{},
+	}
+	generatorMock.EXPECT().GenerateParams(&generator, mock.AnythingOfType("*v1alpha1.ApplicationSet"), mock.Anything).
+		Return(params, nil)
+	generatorMock.EXPECT().GetTemplate(&generator).
+		Return(&template)
+
+	got, reason, err := GenerateApplications(log.NewEntry(log.StandardLogger()), v1alpha1.ApplicationSet{
+		Spec: v1alpha1.ApplicationSetSpec{
+			GoTemplate:    true,
+			Generators:    []v1alpha1.ApplicationSetGenerator{generator},
+			Template:      template,
+			TemplatePatch: &templatePatch,
+		},
+	},
+		map[string]generators.Generator{"List": generatorMock},
+		&utils.Render{},
+		nil,
+	)
+
+	require.ErrorContains(t, err, `invalid sync option "CreateNamespace="`)
+	assert.EqualValues(t, v1alpha1.ApplicationSetReasonRenderTemplateParamsError, reason)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "guestbook-dev", got[0].Name)
+	require.NotNil(t, got[0].Spec.SyncPolicy.Automated)
+	assert.True(t, got[0].Spec.SyncPolicy.Automated.SelfHeal)
+	assert.Equal(t, v1alpha1.SyncOptions{"CreateNamespace=true"}, got[0].Spec.SyncPolicy.SyncOptions)
+
+	assert.Equal(t, "guestbook-prod", got[1].Name)
+	require.NotNil(t, got[1].Spec.SyncPolicy.Automated)
+	assert.False(t, got[1].Spec.SyncPolicy.Automated.SelfHeal)
+	assert.Equal(t, v1alpha1.SyncOptions{"CreateNamespace=false"}, got[1].Spec.SyncPolicy.SyncOptions)
+}
+
+func TestValidateSyncPolicy(t *testing.T) {
+	for _, c := range []struct {
+		name        string
+		syncPolicy  *v1alpha1.SyncPolicy
+		expectedErr string
+	}{
+		{
+			name: "no sync policy",
+		},
+		{
+			name: "valid sync policy",
+			syncPolicy: &v1alpha1.SyncPolicy{
+				Automated:   &v1alpha1.SyncPolicyAutomated{Prune: true, SelfHeal: true},
+				SyncOptions: v1alpha1.SyncOptions{"CreateNamespace=true", "PrunePropagationPolicy=foreground"},
+				Retry: &v1alpha1.RetryStrategy{
+					Limit:   -1,
+					Backoff: &v1alpha1.Backoff{Duration: "5s", MaxDuration: "3m"},
+				},
+			},
+		},
+		{
+			name:        "sync option without value",
+			syncPolicy:  &v1alpha1.SyncPolicy{SyncOptions: v1alpha1.SyncOptions{"CreateNamespace="}},
+			expectedErr: `invalid sync option "CreateNamespace="`,
+		},
+		{
+			name:        "sync option without separator",
+			syncPolicy:  &v1alpha1.SyncPolicy{SyncOptions: v1alpha1.SyncOptions{"CreateNamespace"}},
+			expectedErr: `invalid sync option "CreateNamespace"`,
+		},
+		{
+			name:        "unrendered sync option",
+			syncPolicy:  &v1alpha1.SyncPolicy{SyncOptions: v1alpha1.SyncOptions{"Prune={{.prune}}"}},
+			expectedErr: `unrendered sync option "Prune={{.prune}}"`,
+		},
+		{
+			name: "invalid retry backoff",
+			syncPolicy: &v1alpha1.SyncPolicy{Retry: &v1alpha1.RetryStrategy{
+				Backoff: &v1alpha1.Backoff{Duration: "soon"},
+			}},
+			expectedErr: "invalid retry backoff",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			app := &v1alpha1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec:       v1alpha1.ApplicationSpec{SyncPolicy: c.syncPolicy},
+			}
+			err := validateSyncPolicy(app)
+			if c.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, c.expectedErr)
+			}
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	stderrors "errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"sort"
+	"text/tabwriter"
 	"time"
 
 	"github.com/argoproj/gitops-engine/pkg/health"
@@ -59,6 +61,9 @@ argocd admin app generate-spec APPNAME
 
 # Reconcile all applications and store reconciliation summary in the specified file
 argocd admin app get-reconcile-results APPNAME
+
+# List the ten largest applications by serialized object size
+argocd admin app object-size --top 10
 `,
 		Run: func(c *cobra.Command, args []string) {
 			c.HelpFunc()(c, args)
@@ -68,9 +73,101 @@ argocd admin app get-reconcile-results APPNAME
 	command.AddCommand(NewGenAppSpecCommand())
 	command.AddCommand(NewReconcileCommand(clientOpts))
 	command.AddCommand(NewDiffReconcileResults())
+	command.AddCommand(NewAppObjectSizeCommand())
 	return command
 }
 
+// appObjectSize is the serialized size of an application, as printed by the object-size command
+type appObjectSize struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	argo.ApplicationObjectSize
+}
+
+// NewAppObjectSizeCommand lists the applications sorted by the size of their serialized object, to find the ones which
+// strain etcd
+func NewAppObjectSizeCommand() *cobra.Command {
+	var (
+		clientConfig  clientcmd.ClientConfig
+		allNamespaces bool
+		top           int
+		output        string
+	)
+	command := &cobra.Command{
+		Use:   "object-size",
+		Short: "List applications sorted by the size of their serialized object, broken down by spec, status and history",
+		Run: func(c *cobra.Command, _ []string) {
+			ctx := c.Context()
+
+			cfg, err := clientConfig.ClientConfig()
+			errors.CheckError(err)
+			namespace, _, err := clientConfig.Namespace()
+			errors.CheckError(err)
+			if allNamespaces {
+				namespace = metav1.NamespaceAll
+			}
+			appClientset := appclientset.NewForConfigOrDie(cfg)
+			apps, err := appClientset.ArgoprojV1alpha1().Applications(namespace).List(ctx, metav1.ListOptions{})
+			errors.CheckError(err)
+			sizes, err := getAppObjectSizes(apps.Items, top)
+			errors.CheckError(err)
+			errors.CheckError(printAppObjectSizes(os.Stdout, output, sizes))
+		},
+	}
+	clientConfig = cli.AddKubectlFlagsToCmd(command)
+	command.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List the applications of all namespaces")
+	command.Flags().IntVar(&top, "top", 0, "Only list the given number of largest applications, 0 for all of them")
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: wide|json|yaml")
+	return command
+}
+
+// getAppObjectSizes returns the sizes of the applications, largest first, limited to top applications if positive
+func getAppObjectSizes(apps []v1alpha1.Application, top int) ([]appObjectSize, error) {
+	sizes := make([]appObjectSize, 0, len(apps))
+	for i := range apps {
+		size, err := argo.GetApplicationObjectSize(&apps[i])
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, appObjectSize{Namespace: apps[i].Namespace, Name: apps[i].Name, ApplicationObjectSize: size})
+	}
+	sort.SliceStable(sizes, func(i, j int) bool {
+		if sizes[i].Total != sizes[j].Total {
+			return sizes[i].Total > sizes[j].Total
+		}
+		return sizes[i].Namespace+"/"+sizes[i].Name < sizes[j].Namespace+"/"+sizes[j].Name
+	})
+	if top > 0 && len(sizes) > top {
+		sizes = sizes[:top]
+	}
+	return sizes, nil
+}
+
+func printAppObjectSizes(out io.Writer, output string, sizes []appObjectSize) error {
+	switch output {
+	case "json", "yaml":
+		data, err := json.MarshalIndent(sizes, "", "  ")
+		if err != nil {
+			return err
+		}
+		if output == "yaml" {
+			if data, err = yaml.JSONToYAML(data); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case "wide":
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(w, "NAMESPACE\tNAME\tTOTAL\tSPEC\tSTATUS\tHISTORY\n")
+		for _, size := range sizes {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\n", size.Namespace, size.Name, size.Total, size.Spec, size.Status, size.History)
+		}
+		return w.Flush()
+	}
+	return fmt.Errorf("unknown output format: %s", output)
+}
+
 // NewGenAppSpecCommand generates declarative configuration file for given application
 func NewGenAppSpecCommand() *cobra.Command {
 	var (
@@ -407,6 +504,7 @@ func reconcileApplications(
 	appStateManager := controller.NewAppStateManager(
 		argoDB,
 		appClientset,
+		kubeClientset,
 		repoServerClient,
 		namespace,
 		kubeutil.NewKubectl(),
@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	clustermocks "github.com/argoproj/gitops-engine/pkg/cache/mocks"
@@ -198,3 +200,40 @@ app3
 >   status: OutOfSync
 `, logs)
 }
+
+func TestGetAppObjectSizes(t *testing.T) {
+	newApp := func(name string, history int) v1alpha1.Application {
+		app := v1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argocd"}}
+		for i := 0; i < history; i++ {
+			app.Status.History = append(app.Status.History, v1alpha1.RevisionHistory{ID: int64(i), Revision: strings.Repeat("a", 40)})
+		}
+		return app
+	}
+	apps := []v1alpha1.Application{newApp("small", 0), newApp("large", 10), newApp("medium", 2)}
+
+	sizes, err := getAppObjectSizes(apps, 0)
+	require.NoError(t, err)
+	require.Len(t, sizes, 3)
+	assert.Equal(t, "large", sizes[0].Name)
+	assert.Equal(t, "medium", sizes[1].Name)
+	assert.Equal(t, "small", sizes[2].Name)
+	assert.Zero(t, sizes[2].History)
+	assert.Greater(t, sizes[0].History, sizes[1].History)
+
+	sizes, err = getAppObjectSizes(apps, 1)
+	require.NoError(t, err)
+	require.Len(t, sizes, 1)
+	assert.Equal(t, "large", sizes[0].Name)
+
+	var out bytes.Buffer
+	require.NoError(t, printAppObjectSizes(&out, "wide", sizes))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"NAMESPACE", "NAME", "TOTAL", "SPEC", "STATUS", "HISTORY"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"argocd", "large"}, strings.Fields(lines[1])[:2])
+
+	out.Reset()
+	require.NoError(t, printAppObjectSizes(&out, "json", sizes))
+	assert.Contains(t, out.String(), `"history": `)
+	require.ErrorContains(t, printAppObjectSizes(&out, "tree", sizes), "unknown output format")
+}
@@ -3,10 +3,12 @@ package admin
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
 
@@ -16,6 +18,8 @@ import (
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
@@ -63,6 +67,7 @@ argocd admin cluster namespaces my-cluster `,
 	command.AddCommand(NewGenClusterConfigCommand(pathOpts))
 	command.AddCommand(NewClusterStatsCommand(clientOpts))
 	command.AddCommand(NewClusterShardsCommand(clientOpts))
+	command.AddCommand(NewClusterHealthcheckCommand())
 	namespacesCommand := NewClusterNamespacesCommand()
 	namespacesCommand.AddCommand(NewClusterEnableNamespacedMode())
 	namespacesCommand.AddCommand(NewClusterDisableNamespacedMode())
@@ -520,6 +525,132 @@ argocd admin cluster stats target-cluster`,
 	return &command
 }
 
+// clusterHealth is the result of connecting to a cluster with its stored credentials
+type clusterHealth struct {
+	Server  string
+	Name    string
+	Version string
+	Latency time.Duration
+	Err     error
+}
+
+// checkClustersHealth connects to each cluster with its stored credentials and returns whether it is reachable, its
+// version and the latency of the version request
+func checkClustersHealth(clusters []v1alpha1.Cluster, timeout time.Duration, parallelism int) []clusterHealth {
+	results := make([]clusterHealth, len(clusters))
+	sem := make(chan struct{}, max(parallelism, 1))
+	var wg sync.WaitGroup
+	for i := range clusters {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			cluster := &clusters[i]
+			results[i] = clusterHealth{Server: cluster.Server, Name: cluster.Name}
+			config, err := cluster.RESTConfig()
+			if err != nil {
+				results[i].Err = fmt.Errorf("error getting REST config: %w", err)
+				return
+			}
+			config.Timeout = timeout
+			discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+			if err != nil {
+				results[i].Err = fmt.Errorf("error creating discovery client: %w", err)
+				return
+			}
+			start := time.Now()
+			version, err := discoveryClient.ServerVersion()
+			results[i].Latency = time.Since(start)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Version = version.GitVersion
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+func printClustersHealth(w io.Writer, results []clusterHealth) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "SERVER\tNAME\tSTATUS\tVERSION\tLATENCY\tMESSAGE\n")
+	for _, result := range results {
+		status := "Successful"
+		message := ""
+		if result.Err != nil {
+			status = "Failed"
+			message = result.Err.Error()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", result.Server, result.Name, status, result.Version, result.Latency.Round(time.Millisecond), message)
+	}
+	_ = tw.Flush()
+}
+
+// NewClusterHealthcheckCommand returns a new instance of an `argocd admin cluster healthcheck` command
+func NewClusterHealthcheckCommand() *cobra.Command {
+	var (
+		clientConfig clientcmd.ClientConfig
+		selector     string
+		timeout      time.Duration
+		parallelism  int
+		failOnError  bool
+	)
+	command := cobra.Command{
+		Use:   "healthcheck",
+		Short: "Connects to every registered cluster with its stored credentials and reports reachability, version and latency",
+		Example: `
+#Check all the registered clusters
+argocd admin cluster healthcheck
+
+#Check the clusters labeled with env=prod, without failing if some are unreachable
+argocd admin cluster healthcheck --selector env=prod --fail-on-error=false`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			ctx := cmd.Context()
+
+			log.SetLevel(log.WarnLevel)
+
+			clientCfg, err := clientConfig.ClientConfig()
+			errors.CheckError(err)
+			namespace, _, err := clientConfig.Namespace()
+			errors.CheckError(err)
+			labelSelector, err := labels.Parse(selector)
+			errors.CheckError(err)
+
+			kubeClient := kubernetes.NewForConfigOrDie(clientCfg)
+			settingsMgr := settings.NewSettingsManager(ctx, kubeClient, namespace)
+			clustersList, err := db.NewDB(namespace, settingsMgr, kubeClient).ListClusters(ctx)
+			errors.CheckError(err)
+
+			var clusters []v1alpha1.Cluster
+			for _, cluster := range clustersList.Items {
+				if labelSelector.Matches(labels.Set(cluster.Labels)) {
+					clusters = append(clusters, cluster)
+				}
+			}
+			results := checkClustersHealth(clusters, timeout, parallelism)
+			printClustersHealth(os.Stdout, results)
+			if !failOnError {
+				return
+			}
+			for _, result := range results {
+				if result.Err != nil {
+					os.Exit(1)
+				}
+			}
+		},
+	}
+	clientConfig = cli.AddKubectlFlagsToCmd(&command)
+	command.Flags().StringVarP(&selector, "selector", "l", "", "Only check the clusters matching the label selector")
+	command.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "Timeout of the connection to each cluster")
+	command.Flags().IntVar(&parallelism, "parallelism", 10, "Number of clusters checked in parallel")
+	command.Flags().BoolVar(&failOnError, "fail-on-error", true, "Exit with a non-zero code if any cluster is unreachable")
+	return &command
+}
+
 // NewClusterConfig returns a new instance of `argocd admin kubeconfig` command
 func NewClusterConfig() *cobra.Command {
 	var clientConfig clientcmd.ClientConfig
@@ -1,6 +1,10 @@
 package admin
 
 import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -80,3 +84,34 @@ func Test_loadClusters(t *testing.T) {
 	}}
 	assert.Equal(t, expected, clusters)
 }
+
+func Test_checkClustersHealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"major": "1", "minor": "33", "gitVersion": "v1.33.1"}`))
+	}))
+	defer server.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	results := checkClustersHealth([]v1alpha1.Cluster{
+		{Server: server.URL, Name: "reachable"},
+		{Server: unreachable.URL, Name: "unreachable"},
+	}, time.Second, 2)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "v1.33.1", results[0].Version)
+	require.Error(t, results[1].Err)
+	assert.Empty(t, results[1].Version)
+
+	out := &bytes.Buffer{}
+	printClustersHealth(out, results)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^SERVER\s+NAME\s+STATUS\s+VERSION\s+LATENCY\s+MESSAGE$`, lines[0])
+	assert.Regexp(t, `reachable\s+Successful\s+v1.33.1`, lines[1])
+	assert.Regexp(t, `unreachable\s+Failed`, lines[2])
+}
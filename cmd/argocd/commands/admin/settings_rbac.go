@@ -118,6 +118,7 @@ func NewRBACCommand() *cobra.Command {
 	}
 	command.AddCommand(NewRBACCanCommand())
 	command.AddCommand(NewRBACValidateCommand())
+	command.AddCommand(NewRBACMatrixCommand())
 	return command
 }
 
@@ -0,0 +1,271 @@
+package admin
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-cd/v3/util/assets"
+	"github.com/argoproj/argo-cd/v3/util/cli"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+// defaultRoleSubject is the pseudo subject of the matrix standing for any subject without a role, which is only granted
+// the default role. It cannot collide with a real subject since policies cannot contain parentheses in subjects.
+const defaultRoleSubject = "(default)"
+
+// rbacPolicyLine is a p line of a policy CSV
+type rbacPolicyLine struct {
+	subject  string
+	resource string
+	action   string
+	object   string
+}
+
+// rbacMatrixRow is the effective permission of a subject to perform an action on the objects of a resource
+type rbacMatrixRow struct {
+	subject  string
+	resource string
+	action   string
+	object   string
+	allowed  bool
+	// approximate is set if rules target individual objects matched by the object of the row, the permission of the
+	// subject on these objects may differ from the row
+	approximate bool
+}
+
+// parseRBACPolicy returns the p lines of the given policies, along with the subjects and roles they reference and the
+// projects the objects of project scoped resources explicitly belong to
+func parseRBACPolicy(policies ...string) (lines []rbacPolicyLine, subjects []string, projects []string, err error) {
+	for _, policy := range policies {
+		for _, line := range strings.Split(policy, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			reader := csv.NewReader(strings.NewReader(line))
+			reader.TrimLeadingSpace = true
+			tokens, err := reader.Read()
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid RBAC policy %q: %w", line, err)
+			}
+			switch {
+			case tokens[0] == "g" && len(tokens) == 3:
+				subjects = append(subjects, tokens[1], tokens[2])
+			case tokens[0] == "p" && len(tokens) == 6:
+				subjects = append(subjects, tokens[1])
+				lines = append(lines, rbacPolicyLine{subject: tokens[1], resource: tokens[2], action: tokens[3], object: tokens[4]})
+				if project, _, ok := strings.Cut(tokens[4], "/"); ok && rbac.ProjectScoped[tokens[2]] && !strings.ContainsAny(project, "*?[") {
+					projects = append(projects, project)
+				}
+			default:
+				return nil, nil, nil, fmt.Errorf("invalid RBAC policy: %s", line)
+			}
+		}
+	}
+	slices.Sort(subjects)
+	slices.Sort(projects)
+	return lines, slices.Compact(subjects), slices.Compact(projects), nil
+}
+
+// matrixObjects returns the objects of the given resource the permissions are computed for, all the objects of each
+// project for project scoped resources and all the objects otherwise
+func matrixObjects(resource string, projects []string) []string {
+	if !rbac.ProjectScoped[resource] {
+		return []string{"*"}
+	}
+	objects := []string{"*/*"}
+	for _, project := range projects {
+		objects = append(objects, project+"/*")
+	}
+	return objects
+}
+
+// isApproximate returns whether some of the given policy lines target individual objects matched by the given object,
+// so that the permission on the object does not apply to all of them
+func isApproximate(lines []rbacPolicyLine, resource, action, object string) bool {
+	project, _, _ := strings.Cut(object, "/")
+	for _, line := range lines {
+		if line.resource != resource && line.resource != "*" {
+			continue
+		}
+		if line.action != action && line.action != "*" {
+			continue
+		}
+		if line.object == "*" || line.object == "*/*" || line.object == object {
+			continue
+		}
+		if !rbac.ProjectScoped[resource] || project == "*" {
+			return true
+		}
+		if lineProject, _, _ := strings.Cut(line.object, "/"); lineProject == project || strings.ContainsAny(lineProject, "*?[") {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRBACMatrix computes the effective permissions of every subject of the policies, and of the default role, on
+// every resource and action. Actions are checked without their path, e.g. action rather than action/apps/Deployment/*.
+func buildRBACMatrix(builtinPolicy, userPolicy, defaultRole, matchMode string, projects []string) ([]rbacMatrixRow, error) {
+	enf := rbac.NewEnforcer(nil, "argocd", "argocd-rbac-cm", nil)
+	enf.SetDefaultRole(defaultRole)
+	enf.SetMatchMode(matchMode)
+	if builtinPolicy != "" {
+		if err := enf.SetBuiltinPolicy(builtinPolicy); err != nil {
+			return nil, fmt.Errorf("could not set built-in policy: %w", err)
+		}
+	}
+	if userPolicy != "" {
+		if err := rbac.ValidatePolicy(userPolicy); err != nil {
+			return nil, fmt.Errorf("invalid user policy: %w", err)
+		}
+		if err := enf.SetUserPolicy(userPolicy); err != nil {
+			return nil, fmt.Errorf("could not set user policy: %w", err)
+		}
+	}
+	lines, subjects, policyProjects, err := parseRBACPolicy(builtinPolicy, userPolicy)
+	if err != nil {
+		return nil, err
+	}
+	projects = append(slices.Clone(projects), policyProjects...)
+	slices.Sort(projects)
+	projects = slices.Compact(projects)
+	if defaultRole != "" {
+		subjects = append(subjects, defaultRoleSubject)
+	}
+
+	var rows []rbacMatrixRow
+	for _, subject := range subjects {
+		for _, resource := range slices.Sorted(maps.Keys(validRBACResourcesActions)) {
+			for _, action := range slices.Sorted(maps.Keys(validRBACResourcesActions[resource])) {
+				for _, object := range matrixObjects(resource, projects) {
+					rows = append(rows, rbacMatrixRow{
+						subject:     subject,
+						resource:    resource,
+						action:      action,
+						object:      object,
+						allowed:     enf.Enforce(subject, resource, action, object),
+						approximate: isApproximate(lines, resource, action, object),
+					})
+				}
+			}
+		}
+	}
+	return rows, nil
+}
+
+func printRBACMatrix(out io.Writer, rows []rbacMatrixRow) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"subject", "resource", "action", "object", "effect", "approximate"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		effect := "deny"
+		if row.allowed {
+			effect = "allow"
+		}
+		if err := w.Write([]string{row.subject, row.resource, row.action, row.object, effect, strconv.FormatBool(row.approximate)}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// NewRBACMatrixCommand is the command for 'rbac matrix'
+func NewRBACMatrixCommand() *cobra.Command {
+	var (
+		policyFile   string
+		defaultRole  string
+		useBuiltin   bool
+		projects     []string
+		clientConfig clientcmd.ClientConfig
+	)
+	command := &cobra.Command{
+		Use:   "matrix",
+		Short: "Export the effective permissions of all subjects as CSV",
+		Long: `
+Export the effective permissions of every subject, group and role referenced by
+the RBAC policy, and of the default role, on every resource and action as CSV.
+
+The permissions of project scoped resources are computed for all projects and
+for each project referenced by the policy or given with --project. Rules
+targeting individual objects are not expanded, the rows whose objects they
+match are marked as approximate.
+`,
+		Example: `
+# Export the permissions of the policy of the Argo CD in the argocd namespace
+argocd admin settings rbac matrix --namespace argocd > matrix.csv
+
+# Export the permissions of a local policy, including the projects team-a and team-b
+argocd admin settings rbac matrix --policy-file argocd-rbac-cm.yaml --project team-a --project team-b
+`,
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+
+			if len(args) > 0 {
+				c.HelpFunc()(c, args)
+				log.Fatalf("too many arguments")
+			}
+
+			namespace, nsOverride, err := clientConfig.Namespace()
+			if err != nil {
+				log.Fatalf("could not create k8s client: %v", err)
+			}
+
+			// Exactly one of --namespace or --policy-file must be given.
+			if (!nsOverride && policyFile == "") || (nsOverride && policyFile != "") {
+				c.HelpFunc()(c, args)
+				log.Fatalf("please provide exactly one of --policy-file or --namespace")
+			}
+
+			var realClientset kubernetes.Interface
+			if policyFile == "" {
+				restConfig, err := clientConfig.ClientConfig()
+				if err != nil {
+					log.Fatalf("could not create k8s client: %v", err)
+				}
+				realClientset, err = kubernetes.NewForConfig(restConfig)
+				if err != nil {
+					log.Fatalf("could not create k8s client: %v", err)
+				}
+			}
+
+			userPolicy, newDefaultRole, matchMode := getPolicy(ctx, policyFile, realClientset, namespace)
+
+			builtinPolicy := ""
+			if useBuiltin {
+				builtinPolicy = assets.BuiltinPolicyCSV
+			}
+
+			if newDefaultRole != "" && defaultRole == "" {
+				defaultRole = newDefaultRole
+			}
+
+			rows, err := buildRBACMatrix(builtinPolicy, userPolicy, defaultRole, matchMode, projects)
+			if err != nil {
+				log.Fatalf("could not compute RBAC matrix: %v", err)
+			}
+			if err := printRBACMatrix(os.Stdout, rows); err != nil {
+				log.Fatalf("could not print RBAC matrix: %v", err)
+			}
+		},
+	}
+	clientConfig = cli.AddKubectlFlagsToCmd(command)
+	command.Flags().StringVar(&policyFile, "policy-file", "", "path to the policy file to use")
+	command.Flags().StringVar(&defaultRole, "default-role", "", "name of the default role to use")
+	command.Flags().BoolVar(&useBuiltin, "use-builtin-policy", true, "whether to also use builtin-policy")
+	command.Flags().StringArrayVar(&projects, "project", nil, "project to compute the permissions of project scoped resources for, in addition to the projects referenced by the policy")
+	return command
+}
@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/util/assets"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+func findMatrixRow(rows []rbacMatrixRow, subject, resource, action, object string) *rbacMatrixRow {
+	for i := range rows {
+		if rows[i].subject == subject && rows[i].resource == resource && rows[i].action == action && rows[i].object == object {
+			return &rows[i]
+		}
+	}
+	return nil
+}
+
+func Test_buildRBACMatrix(t *testing.T) {
+	policy := `
+p, role:team-a, applications, sync, team-a/*, allow
+p, role:team-a, applications, get, team-a/guestbook, allow
+g, my-org:team-a, role:team-a
+`
+	rows, err := buildRBACMatrix(assets.BuiltinPolicyCSV, policy, "role:readonly", "glob", []string{"team-b"})
+	require.NoError(t, err)
+
+	t.Run("Group inherits its role", func(t *testing.T) {
+		row := findMatrixRow(rows, "my-org:team-a", rbac.ResourceApplications, rbac.ActionSync, "team-a/*")
+		require.NotNil(t, row)
+		assert.True(t, row.allowed)
+		assert.False(t, row.approximate)
+
+		row = findMatrixRow(rows, "my-org:team-a", rbac.ResourceApplications, rbac.ActionSync, "team-b/*")
+		require.NotNil(t, row)
+		assert.False(t, row.allowed)
+	})
+	t.Run("Rules on individual objects are approximate", func(t *testing.T) {
+		row := findMatrixRow(rows, "role:team-a", rbac.ResourceApplications, rbac.ActionGet, "team-a/*")
+		require.NotNil(t, row)
+		assert.True(t, row.approximate)
+		row = findMatrixRow(rows, "role:team-a", rbac.ResourceApplications, rbac.ActionGet, "team-b/*")
+		require.NotNil(t, row)
+		assert.False(t, row.approximate)
+	})
+	t.Run("Built-in subjects and the default role", func(t *testing.T) {
+		row := findMatrixRow(rows, "admin", rbac.ResourceClusters, rbac.ActionDelete, "*/*")
+		require.NotNil(t, row)
+		assert.True(t, row.allowed)
+
+		row = findMatrixRow(rows, defaultRoleSubject, rbac.ResourceProjects, rbac.ActionGet, "*")
+		require.NotNil(t, row)
+		assert.True(t, row.allowed)
+		row = findMatrixRow(rows, defaultRoleSubject, rbac.ResourceProjects, rbac.ActionDelete, "*")
+		require.NotNil(t, row)
+		assert.False(t, row.allowed)
+	})
+}
+
+func Test_printRBACMatrix(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, printRBACMatrix(buf, []rbacMatrixRow{
+		{subject: "role:a", resource: "applications", action: "get", object: "default/*", allowed: true, approximate: true},
+		{subject: "role:a", resource: "clusters", action: "delete", object: "*/*"},
+	}))
+	assert.Equal(t, []string{
+		"subject,resource,action,object,effect,approximate",
+		"role:a,applications,get,default/*,allow,true",
+		"role:a,clusters,delete,*/*,deny,false",
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+}
@@ -93,6 +93,7 @@ func NewApplicationCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comman
 	command.AddCommand(NewApplicationUnsetCommand(clientOpts))
 	command.AddCommand(NewApplicationSyncCommand(clientOpts))
 	command.AddCommand(NewApplicationHistoryCommand(clientOpts))
+	command.AddCommand(NewApplicationGraphCommand(clientOpts))
 	command.AddCommand(NewApplicationRollbackCommand(clientOpts))
 	command.AddCommand(NewApplicationListCommand(clientOpts))
 	command.AddCommand(NewApplicationDeleteCommand(clientOpts))
@@ -121,6 +122,8 @@ type watchOpts struct {
 	degraded  bool
 	delete    bool
 	hydrated  bool
+	// failOnDegraded ends the wait with an error as soon as the application is degraded
+	failOnDegraded bool
 }
 
 // NewApplicationCreateCommand returns a new instance of an `argocd app create` command
@@ -2013,11 +2016,12 @@ func parseSelectedResources(resources []string) ([]*argoappv1.SyncOperationResou
 
 func getWatchOpts(watch watchOpts) watchOpts {
 	// if no opts are defined should wait for sync,health,operation
-	if (watch == watchOpts{}) {
+	if (watch == watchOpts{failOnDegraded: watch.failOnDegraded}) {
 		return watchOpts{
-			sync:      true,
-			health:    true,
-			operation: true,
+			sync:           true,
+			health:         true,
+			operation:      true,
+			failOnDegraded: watch.failOnDegraded,
 		}
 	}
 	return watch
@@ -2036,6 +2040,15 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command := &cobra.Command{
 		Use:   "wait [APPNAME.. | -l selector]",
 		Short: "Wait for an application to reach a synced and healthy state",
+		Long: `Wait for an application to reach a synced and healthy state
+
+The command exits with one of the following codes, so that scripts can react to the outcome of the wait:
+
+  0   the conditions were met
+  20  an unexpected error occurred
+  21  the conditions were not met before the timeout
+  22  the application became degraded (see --health and --fail-on-degraded)
+  23  the operation of the application failed (when waiting for operations)`,
 		Example: `  # Wait for an app
   argocd app wait my-app
 
@@ -2057,7 +2070,10 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
   argocd app wait -l app.kubernetes.io/instance!=my-app
   argocd app wait -l app.kubernetes.io/instance
   argocd app wait -l '!app.kubernetes.io/instance'
-  argocd app wait -l 'app.kubernetes.io/instance notin (my-app,other-app)'`,
+  argocd app wait -l 'app.kubernetes.io/instance notin (my-app,other-app)'
+
+  # Wait for an app to become healthy, but fail as soon as it is degraded
+  argocd app wait my-app --health --fail-on-degraded`,
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -2066,6 +2082,8 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				os.Exit(1)
 			}
 			watch = getWatchOpts(watch)
+			// the timestamps of the operations are serialized with a precision of a second
+			waitStarted := time.Now().Truncate(time.Second)
 			selectedResources, err := parseSelectedResources(resources)
 			errors.CheckError(err)
 			appNames := args
@@ -2084,8 +2102,11 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				if appNamespace != "" && !strings.Contains(appName, "/") {
 					appName = appNamespace + "/" + appName
 				}
-				_, _, err := waitOnApplicationStatus(ctx, acdClient, appName, timeout, watch, selectedResources, output)
-				errors.CheckError(err)
+				_, opState, err := waitOnApplicationStatus(ctx, acdClient, appName, timeout, watch, selectedResources, output)
+				checkWaitError(err)
+				if watch.operation && operationFailedSince(opState, waitStarted) {
+					errors.Fatalf(errors.ErrorWaitOperationFailed, "operation of application '%s' has %s: %s", appName, strings.ToLower(string(opState.Phase)), opState.Message)
+				}
 			}
 		},
 	}
@@ -2095,6 +2116,7 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().BoolVar(&watch.degraded, "degraded", false, "Wait for degraded")
 	command.Flags().BoolVar(&watch.delete, "delete", false, "Wait for delete")
 	command.Flags().BoolVar(&watch.hydrated, "hydrated", false, "Wait for hydration operations")
+	command.Flags().BoolVar(&watch.failOnDegraded, "fail-on-degraded", false, "Stop waiting and fail as soon as the application or the selected resources are degraded")
 	command.Flags().StringVarP(&selector, "selector", "l", "", "Wait for apps by label. Supports '=', '==', '!=', in, notin, exists & not exists. Matching apps must satisfy all of the specified label constraints.")
 	command.Flags().StringArrayVar(&resources, "resource", []string{}, fmt.Sprintf("Sync only specific resources as GROUP%[1]sKIND%[1]sNAME or %[2]sGROUP%[1]sKIND%[1]sNAME. Fields may be blank and '*' can be used. This option may be specified repeatedly", resourceFieldDelimiter, resourceExcludeIndicator))
 	command.Flags().BoolVar(&watch.operation, "operation", false, "Wait for pending operations")
@@ -2104,6 +2126,18 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	return command
 }
 
+// operationFailedSince returns whether the operation failed at or after the given time, so that a failed operation left
+// by an earlier sync does not fail the wait
+func operationFailedSince(opState *argoappv1.OperationState, since time.Time) bool {
+	if opState == nil || !opState.Phase.Completed() || opState.Phase.Successful() {
+		return false
+	}
+	if opState.FinishedAt != nil {
+		return !opState.FinishedAt.Time.Before(since)
+	}
+	return !opState.StartedAt.Time.Before(since)
+}
+
 // printAppResources prints the resources of an application in a tabwriter table
 func printAppResources(w io.Writer, app *argoappv1.Application) {
 	_, _ = fmt.Fprintf(w, "GROUP\tKIND\tNAMESPACE\tNAME\tSTATUS\tHEALTH\tHOOK\tMESSAGE\n")
@@ -2714,6 +2748,43 @@ func resourceParentChild(ctx context.Context, acdClient argocdclient.Client, app
 
 const waitFormatString = "%s\t%5s\t%10s\t%10s\t%20s\t%8s\t%7s\t%10s\t%s\n"
 
+// waitError is returned when waiting on an application ended without the conditions being met. It carries the exit
+// code of the outcome, so that scripts can tell a timeout from a degraded application.
+type waitError struct {
+	exitCode int
+	err      error
+}
+
+func (e *waitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *waitError) Unwrap() error {
+	return e.err
+}
+
+// checkWaitError exits with the exit code of the outcome if err is a waitError, or with the generic exit code otherwise
+func checkWaitError(err error) {
+	var waitErr *waitError
+	if stderrors.As(err, &waitErr) {
+		errors.Fatal(waitErr.exitCode, err)
+	}
+	errors.CheckError(err)
+}
+
+// isDegraded returns whether the application, or any of the selected resources, is degraded
+func isDegraded(app *argoappv1.Application, selectedResources []*argoappv1.SyncOperationResource) bool {
+	if len(selectedResources) == 0 {
+		return app.Status.Health.Status == health.HealthStatusDegraded
+	}
+	for _, state := range getResourceStates(app, selectedResources) {
+		if state.Health == string(health.HealthStatusDegraded) {
+			return true
+		}
+	}
+	return false
+}
+
 // AppWithLock encapsulates the application and its lock
 type AppWithLock struct {
 	mu  sync.Mutex
@@ -2920,6 +2991,11 @@ func waitOnApplicationStatus(ctx context.Context, acdClient argocdclient.Client,
 			return app, finalOperationState, nil
 		}
 
+		if watch.failOnDegraded && !watch.degraded && !operationInProgress && isDegraded(app, selectedResources) {
+			_ = printFinalStatus(app)
+			return nil, finalOperationState, &waitError{exitCode: errors.ErrorWaitDegraded, err: fmt.Errorf("application '%s' is degraded", appName)}
+		}
+
 		newStates := groupResourceStates(app, selectedResources)
 		for _, newState := range newStates {
 			var doPrint bool
@@ -2927,7 +3003,7 @@ func waitOnApplicationStatus(ctx context.Context, acdClient argocdclient.Client,
 			if prevState, found := prevStates[stateKey]; found {
 				if watch.health && prevState.Health != string(health.HealthStatusUnknown) && prevState.Health != string(health.HealthStatusDegraded) && newState.Health == string(health.HealthStatusDegraded) {
 					_ = printFinalStatus(app)
-					return nil, finalOperationState, fmt.Errorf("application '%s' health state has transitioned from %s to %s", appName, prevState.Health, newState.Health)
+					return nil, finalOperationState, &waitError{exitCode: errors.ErrorWaitDegraded, err: fmt.Errorf("application '%s' health state has transitioned from %s to %s", appName, prevState.Health, newState.Health)}
 				}
 				doPrint = prevState.Merge(newState)
 			} else {
@@ -2941,7 +3017,7 @@ func waitOnApplicationStatus(ctx context.Context, acdClient argocdclient.Client,
 		_ = w.Flush()
 	}
 	_ = printFinalStatus(appWithLock.GetApp())
-	return nil, finalOperationState, fmt.Errorf("timed out (%ds) waiting for app %q match desired state", timeout, appName)
+	return nil, finalOperationState, &waitError{exitCode: errors.ErrorWaitTimeout, err: fmt.Errorf("timed out (%ds) waiting for app %q match desired state", timeout, appName)}
 }
 
 // setParameterOverrides updates an existing or appends a new parameter override in the application
@@ -3045,8 +3121,9 @@ func printApplicationHistoryTable(revHistory []argoappv1.RevisionHistory) {
 // NewApplicationHistoryCommand returns a new instance of an `argocd app history` command
 func NewApplicationHistoryCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
-		output       string
-		appNamespace string
+		output          string
+		appNamespace    string
+		includeArchived bool
 	)
 	command := &cobra.Command{
 		Use:   "history APPNAME",
@@ -3061,21 +3138,35 @@ func NewApplicationHistoryCommand(clientOpts *argocdclient.ClientOptions) *cobra
 			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
 			defer utilio.Close(conn)
 			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
-			app, err := appIf.Get(ctx, &application.ApplicationQuery{
-				Name:         &appName,
-				AppNamespace: &appNs,
-			})
-			errors.CheckError(err)
+			var revHistory []argoappv1.RevisionHistory
+			if includeArchived {
+				res, err := appIf.History(ctx, &application.ApplicationHistoryQuery{
+					Name:         &appName,
+					AppNamespace: &appNs,
+				})
+				errors.CheckError(err)
+				for _, entry := range res.Items {
+					revHistory = append(revHistory, *entry)
+				}
+			} else {
+				app, err := appIf.Get(ctx, &application.ApplicationQuery{
+					Name:         &appName,
+					AppNamespace: &appNs,
+				})
+				errors.CheckError(err)
+				revHistory = app.Status.History
+			}
 
 			if output == "id" {
-				printApplicationHistoryIDs(app.Status.History)
+				printApplicationHistoryIDs(revHistory)
 			} else {
-				printApplicationHistoryTable(app.Status.History)
+				printApplicationHistoryTable(revHistory)
 			}
 		},
 	}
 	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Only show application deployment history in namespace")
 	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: wide|id")
+	command.Flags().BoolVar(&includeArchived, "include-archived", false, "Include the entries archived outside of the application by the revision history archive sink")
 	return command
 }
 
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/cmd/argocd/commands/headless"
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+)
+
+func printAppGraphTree(out io.Writer, root *application.ApplicationGraphNode) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "NAME\tPROJECT\tSYNC\tHEALTH\tMESSAGE\n")
+	printAppGraphNode(w, "", root)
+	_ = w.Flush()
+}
+
+func printAppGraphNode(w io.Writer, prefix string, node *application.ApplicationGraphNode) {
+	message := node.GetError()
+	if node.GetCycle() {
+		message = "cycle detected, not walked again"
+	}
+	_, _ = fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\t%s\n", printPrefix(prefix), node.GetNamespace()+"/"+node.GetName(), node.GetProject(), node.GetSync(), node.GetHealth(), message)
+	for i, child := range node.Children {
+		var p string
+		switch i {
+		case len(node.Children) - 1:
+			p = prefix + lastElemPrefix
+		default:
+			p = prefix + firstElemPrefix
+		}
+		printAppGraphNode(w, p, child)
+	}
+}
+
+// NewApplicationGraphCommand returns a new instance of an `argocd app graph` command
+func NewApplicationGraphCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		output       string
+		appNamespace string
+		maxDepth     int64
+	)
+	command := &cobra.Command{
+		Use:   "graph APPNAME",
+		Short: "Show the graph of Applications managed by an app-of-apps",
+		Example: `  # Show the full graph of Applications managed by my-app
+  argocd app graph my-app
+
+  # Show only the first two levels of the graph, as JSON
+  argocd app graph my-app --max-depth 2 -o json`,
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
+			// the graph is walked by the API server, the Applications the user is not permitted to get are reported
+			// with their error
+			root, err := appIf.Graph(ctx, &application.ApplicationGraphQuery{
+				Name:         &appName,
+				AppNamespace: &appNs,
+				MaxDepth:     ptr.To(maxDepth),
+			})
+			errors.CheckError(err)
+			switch output {
+			case "json", "yaml":
+				errors.CheckError(PrintResource(root, output))
+			case "tree", "":
+				printAppGraphTree(os.Stdout, root)
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Only show the graph of the application in namespace")
+	command.Flags().StringVarP(&output, "output", "o", "tree", "Output format. One of: tree|json|yaml")
+	command.Flags().Int64Var(&maxDepth, "max-depth", 0, "Maximum number of levels of the graph to walk, 0 for no limit")
+	return command
+}
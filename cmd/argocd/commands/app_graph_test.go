@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+)
+
+func newGraphNode(name string, children ...*application.ApplicationGraphNode) *application.ApplicationGraphNode {
+	return &application.ApplicationGraphNode{
+		Name:      ptr.To(name),
+		Namespace: ptr.To("argocd"),
+		Project:   ptr.To("default"),
+		Sync:      ptr.To("Synced"),
+		Health:    ptr.To("Healthy"),
+		Children:  children,
+	}
+}
+
+func TestPrintAppGraphTree(t *testing.T) {
+	root := newGraphNode("root",
+		newGraphNode("a",
+			newGraphNode("c"),
+			&application.ApplicationGraphNode{Name: ptr.To("root"), Namespace: ptr.To("argocd"), Cycle: ptr.To(true)},
+		),
+		&application.ApplicationGraphNode{Name: ptr.To("b"), Namespace: ptr.To("argocd"), Error: ptr.To("rpc error: code = PermissionDenied desc = permission denied")},
+	)
+	buf := &bytes.Buffer{}
+	printAppGraphTree(buf, root)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 6)
+	assert.Regexp(t, `^NAME\s+PROJECT\s+SYNC\s+HEALTH\s+MESSAGE$`, lines[0])
+	assert.Regexp(t, `^argocd/root\s+default\s+Synced\s+Healthy`, lines[1])
+	assert.Regexp(t, `^├─argocd/a\s+default\s+Synced\s+Healthy`, lines[2])
+	assert.Regexp(t, `^│ ├─argocd/c\s+default\s+Synced\s+Healthy`, lines[3])
+	assert.Regexp(t, `^│ └─argocd/root\s+cycle detected, not walked again$`, lines[4])
+	assert.Regexp(t, `^└─argocd/b\s+rpc error: code = PermissionDenied desc = permission denied$`, lines[5])
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/argoproj/gitops-engine/pkg/sync/common"
 	"github.com/argoproj/gitops-engine/pkg/utils/kube"
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/google/go-cmp/cmp"
@@ -46,6 +47,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/v3/util/errors"
 )
 
 func Test_getInfos(t *testing.T) {
@@ -271,6 +273,47 @@ func TestOverrideWaitOptions(t *testing.T) {
 	assert.False(t, opts.suspended)
 }
 
+func TestDefaultWaitOptionsWithFailOnDegraded(t *testing.T) {
+	opts := getWatchOpts(watchOpts{failOnDegraded: true})
+	assert.True(t, opts.sync)
+	assert.True(t, opts.health)
+	assert.True(t, opts.operation)
+	assert.True(t, opts.failOnDegraded)
+}
+
+func TestOperationFailedSince(t *testing.T) {
+	waitStarted := time.Now().Truncate(time.Second)
+	before := metav1.NewTime(waitStarted.Add(-48 * time.Hour))
+	after := metav1.NewTime(waitStarted.Add(time.Second))
+
+	// a failure left by an earlier sync does not fail the wait
+	assert.False(t, operationFailedSince(&v1alpha1.OperationState{Phase: common.OperationFailed, StartedAt: before, FinishedAt: &before}, waitStarted))
+	// an operation in progress when the wait started
+	assert.True(t, operationFailedSince(&v1alpha1.OperationState{Phase: common.OperationFailed, StartedAt: before, FinishedAt: &after}, waitStarted))
+	// an operation started during the wait
+	assert.True(t, operationFailedSince(&v1alpha1.OperationState{Phase: common.OperationError, StartedAt: after}, waitStarted))
+	assert.False(t, operationFailedSince(&v1alpha1.OperationState{Phase: common.OperationSucceeded, StartedAt: after, FinishedAt: &after}, waitStarted))
+	assert.False(t, operationFailedSince(nil, waitStarted))
+}
+
+func TestIsDegraded(t *testing.T) {
+	app := &v1alpha1.Application{
+		Status: v1alpha1.ApplicationStatus{
+			Health: v1alpha1.AppHealthStatus{Status: health.HealthStatusDegraded},
+			Resources: []v1alpha1.ResourceStatus{
+				{Group: "apps", Kind: "Deployment", Name: "healthy", Health: &v1alpha1.HealthStatus{Status: health.HealthStatusHealthy}},
+				{Group: "apps", Kind: "Deployment", Name: "degraded", Health: &v1alpha1.HealthStatus{Status: health.HealthStatusDegraded}},
+			},
+		},
+	}
+	assert.True(t, isDegraded(app, nil))
+	assert.False(t, isDegraded(app, []*v1alpha1.SyncOperationResource{{Group: "apps", Kind: "Deployment", Name: "healthy"}}))
+	assert.True(t, isDegraded(app, []*v1alpha1.SyncOperationResource{{Group: "apps", Kind: "Deployment", Name: "degraded"}}))
+
+	app.Status.Health.Status = health.HealthStatusProgressing
+	assert.False(t, isDegraded(app, nil))
+}
+
 func TestFindRevisionHistoryWithoutPassedIdAndEmptyHistoryList(t *testing.T) {
 	histories := v1alpha1.RevisionHistories{}
 
@@ -1960,7 +2003,10 @@ func TestWaitOnApplicationStatus_JSON_YAML_WideOutput_With_Timeout(t *testing.T)
 	watch = getWatchOpts(watch)
 
 	output, _ := captureOutput(func() error {
-		_, _, _ = waitOnApplicationStatus(ctx, acdClient, "app-name", 5, watch, selectResource, "")
+		_, _, err := waitOnApplicationStatus(ctx, acdClient, "app-name", 5, watch, selectResource, "")
+		var waitErr *waitError
+		require.ErrorAs(t, err, &waitErr)
+		assert.Equal(t, errors.ErrorWaitTimeout, waitErr.exitCode)
 		return nil
 	})
 	timeStr := time.Now().Format("2006-01-02T15:04:05-07:00")
@@ -2253,6 +2299,14 @@ func (c *fakeAppServiceClient) ListResourceLinks(_ context.Context, _ *applicati
 	return nil, nil
 }
 
+func (c *fakeAppServiceClient) History(_ context.Context, _ *applicationpkg.ApplicationHistoryQuery, _ ...grpc.CallOption) (*applicationpkg.ApplicationHistoryResponse, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) Graph(_ context.Context, _ *applicationpkg.ApplicationGraphQuery, _ ...grpc.CallOption) (*applicationpkg.ApplicationGraphNode, error) {
+	return nil, nil
+}
+
 func (c *fakeAppServiceClient) ServerSideDiff(_ context.Context, _ *applicationpkg.ApplicationServerSideDiffQuery, _ ...grpc.CallOption) (*applicationpkg.ApplicationServerSideDiffResponse, error) {
 	return nil, nil
 }
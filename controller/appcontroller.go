@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	stderrors "errors"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"math/rand"
 	"net/http"
@@ -126,6 +127,7 @@ type ApplicationController struct {
 	statusRefreshTimeout          time.Duration
 	statusHardRefreshTimeout      time.Duration
 	statusRefreshJitter           time.Duration
+	statusRefreshJitterFraction   float64
 	selfHealTimeout               time.Duration
 	selfHealBackoff               *wait.Backoff
 	syncTimeout                   time.Duration
@@ -138,8 +140,12 @@ type ApplicationController struct {
 	kubectlSemaphore              *semaphore.Weighted
 	clusterSharding               sharding.ClusterShardingCache
 	projByNameCache               sync.Map
-	applicationNamespaces         []string
-	ignoreNormalizerOpts          normalizers.IgnoreNormalizerOpts
+	// deletedProjects contains the names of the projects the project informer saw being deleted, the applications
+	// referencing them are the ones orphaned by the deletion
+	deletedProjects       sync.Map
+	autoActions           *autoActionTracker
+	applicationNamespaces []string
+	ignoreNormalizerOpts  normalizers.IgnoreNormalizerOpts
 
 	// dynamicClusterDistributionEnabled if disabled deploymentInformer is never initialized
 	dynamicClusterDistributionEnabled bool
@@ -161,6 +167,7 @@ func NewApplicationController(
 	appResyncPeriod time.Duration,
 	appHardResyncPeriod time.Duration,
 	appResyncJitter time.Duration,
+	appResyncJitterFraction float64,
 	selfHealTimeout time.Duration,
 	selfHealBackoff *wait.Backoff,
 	syncTimeout time.Duration,
@@ -181,7 +188,7 @@ func NewApplicationController(
 	enableK8sEvent []string,
 	hydratorEnabled bool,
 ) (*ApplicationController, error) {
-	log.Infof("appResyncPeriod=%v, appHardResyncPeriod=%v, appResyncJitter=%v", appResyncPeriod, appHardResyncPeriod, appResyncJitter)
+	log.Infof("appResyncPeriod=%v, appHardResyncPeriod=%v, appResyncJitter=%v, appResyncJitterFraction=%v", appResyncPeriod, appHardResyncPeriod, appResyncJitter, appResyncJitterFraction)
 	db := db.NewDB(namespace, settingsMgr, kubeClientset)
 	if rateLimiterConfig == nil {
 		rateLimiterConfig = ratelimiter.GetDefaultAppRateLimiterConfig()
@@ -203,6 +210,7 @@ func NewApplicationController(
 		statusRefreshTimeout:              appResyncPeriod,
 		statusHardRefreshTimeout:          appHardResyncPeriod,
 		statusRefreshJitter:               appResyncJitter,
+		statusRefreshJitterFraction:       appResyncJitterFraction,
 		refreshRequestedApps:              make(map[string]CompareWith),
 		refreshRequestedAppsMutex:         &sync.Mutex{},
 		auditLogger:                       argo.NewAuditLogger(kubeClientset, common.ApplicationController, enableK8sEvent),
@@ -212,6 +220,7 @@ func NewApplicationController(
 		syncTimeout:                       syncTimeout,
 		clusterSharding:                   clusterSharding,
 		projByNameCache:                   sync.Map{},
+		autoActions:                       newAutoActionTracker(),
 		applicationNamespaces:             applicationNamespaces,
 		dynamicClusterDistributionEnabled: dynamicClusterDistributionEnabled,
 		ignoreNormalizerOpts:              ignoreNormalizerOpts,
@@ -233,6 +242,7 @@ func NewApplicationController(
 			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
 				ctrl.projectRefreshQueue.AddRateLimited(key)
 				if projMeta, ok := obj.(metav1.Object); ok {
+					ctrl.deletedProjects.Delete(projMeta.GetName())
 					ctrl.InvalidateProjectsCache(projMeta.GetName())
 				}
 			}
@@ -250,6 +260,7 @@ func NewApplicationController(
 				// immediately push to queue for deletes
 				ctrl.projectRefreshQueue.Add(key)
 				if projMeta, ok := obj.(metav1.Object); ok {
+					ctrl.deletedProjects.Store(projMeta.GetName(), true)
 					ctrl.InvalidateProjectsCache(projMeta.GetName())
 				}
 			}
@@ -327,7 +338,7 @@ func NewApplicationController(
 		}
 	}
 	stateCache := statecache.NewLiveStateCache(db, appInformer, ctrl.settingsMgr, ctrl.metricsServer, ctrl.handleObjectUpdated, clusterSharding, argo.NewResourceTracking())
-	appStateManager := NewAppStateManager(db, applicationClientset, repoClientset, namespace, kubectl, ctrl.onKubectlRun, ctrl.settingsMgr, stateCache, ctrl.metricsServer, argoCache, ctrl.statusRefreshTimeout, argo.NewResourceTracking(), persistResourceHealth, repoErrorGracePeriod, serverSideDiff, ignoreNormalizerOpts)
+	appStateManager := NewAppStateManager(db, applicationClientset, kubeClientset, repoClientset, namespace, kubectl, ctrl.onKubectlRun, ctrl.settingsMgr, stateCache, ctrl.metricsServer, argoCache, ctrl.statusRefreshTimeout, argo.NewResourceTracking(), persistResourceHealth, repoErrorGracePeriod, serverSideDiff, ignoreNormalizerOpts)
 	ctrl.appInformer = appInformer
 	ctrl.appLister = appLister
 	ctrl.projInformer = projInformer
@@ -1132,18 +1143,29 @@ func (ctrl *ApplicationController) processProjectQueueItem() (processNext bool)
 		if err := ctrl.finalizeProjectDeletion(origProj.DeepCopy()); err != nil {
 			log.WithError(err).Warn("Failed to finalize project deletion")
 		}
+	} else if origProj.DeletionTimestamp == nil && !origProj.HasFinalizer() {
+		policy, _, err := ctrl.settingsMgr.GetProjectDeletionPolicy()
+		if err != nil {
+			log.WithError(err).Warn("Failed to get project deletion policy")
+		} else if policy == settings_util.ProjectDeletionPolicyBlock {
+			// the finalizer blocks the deletion of the project until it is not referenced by any application
+			if err := ctrl.addProjectFinalizer(origProj.DeepCopy()); err != nil {
+				log.WithField("project", origProj.Name).WithError(err).Warn("Failed to add project finalizer")
+			}
+		}
 	}
 	return processNext
 }
 
 func (ctrl *ApplicationController) finalizeProjectDeletion(proj *appv1.AppProject) error {
-	apps, err := ctrl.appLister.Applications(ctrl.namespace).List(labels.Everything())
+	// applications in any of the allowed namespaces may reference the project
+	apps, err := ctrl.appLister.List(labels.Everything())
 	if err != nil {
 		return fmt.Errorf("error listing applications: %w", err)
 	}
 	appsCount := 0
 	for i := range apps {
-		if apps[i].Spec.GetProject() == proj.Name {
+		if apps[i].Spec.GetProject() == proj.Name && ctrl.isAppNamespaceAllowed(apps[i]) {
 			appsCount++
 		}
 	}
@@ -1154,6 +1176,59 @@ func (ctrl *ApplicationController) finalizeProjectDeletion(proj *appv1.AppProjec
 	return nil
 }
 
+func (ctrl *ApplicationController) addProjectFinalizer(proj *appv1.AppProject) error {
+	proj.AddFinalizer()
+	var patch []byte
+	patch, _ = json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"finalizers": proj.Finalizers,
+		},
+	})
+	_, err := ctrl.applicationClientset.ArgoprojV1alpha1().AppProjects(ctrl.namespace).Patch(context.Background(), proj.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// reassignOrphanedApp moves an application which references a deleted project to the fallback project, if the
+// project deletion policy is ProjectDeletionPolicyReassign. Otherwise, the given not found error is returned. Only the
+// applications of the projects the controller saw being deleted are reassigned, not the ones referencing a project
+// which never existed or is not synced yet.
+func (ctrl *ApplicationController) reassignOrphanedApp(app *appv1.Application, notFoundErr error) (*appv1.AppProject, error) {
+	logCtx := log.WithFields(applog.GetAppLogFields(app))
+	policy, fallback, err := ctrl.settingsMgr.GetProjectDeletionPolicy()
+	if err != nil {
+		logCtx.WithError(err).Warn("Failed to get project deletion policy")
+		return nil, notFoundErr
+	}
+	orphanedProject := app.Spec.GetProject()
+	if policy != settings_util.ProjectDeletionPolicyReassign || fallback == orphanedProject {
+		return nil, notFoundErr
+	}
+	if _, deleted := ctrl.deletedProjects.Load(orphanedProject); !deleted {
+		return nil, notFoundErr
+	}
+
+	app.Spec.Project = fallback
+	proj, err := ctrl.getAppProj(app)
+	if err != nil {
+		app.Spec.Project = orphanedProject
+		return nil, fmt.Errorf("application referencing project %s which does not exist, cannot be reassigned to fallback project %s: %v", orphanedProject, fallback, err)
+	}
+	patch, _ := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"project": fallback,
+		},
+	})
+	_, err = ctrl.PatchAppWithWriteBack(context.Background(), app.Name, app.Namespace, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		app.Spec.Project = orphanedProject
+		return nil, fmt.Errorf("error reassigning application to fallback project %s: %w", fallback, err)
+	}
+	message := fmt.Sprintf("Reassigned application from deleted project %s to fallback project %s", orphanedProject, fallback)
+	logCtx.Info(message)
+	ctrl.logAppEvent(context.TODO(), app, argo.EventInfo{Reason: argo.EventReasonResourceUpdated, Type: corev1.EventTypeNormal}, message)
+	return proj, nil
+}
+
 func (ctrl *ApplicationController) removeProjectFinalizer(proj *appv1.AppProject) error {
 	proj.RemoveFinalizer()
 	var patch []byte
@@ -1485,9 +1560,12 @@ func (ctrl *ApplicationController) processRequestedAppOperation(app *appv1.Appli
 
 			// Get rid of sync results and null out previous operation completion time
 			// This will start the retry attempt
+			state.SyncResult = syncResultForRetry(state)
+			if state.SyncResult != nil {
+				extraMsg += " of failed resources only"
+			}
 			state.Message = fmt.Sprintf("Retrying operation%s. Attempt #%d", extraMsg, state.RetryCount)
 			state.FinishedAt = nil
-			state.SyncResult = nil
 			ctrl.setOperationState(app, state)
 			logCtx.Infof("Retrying operation%s. Attempt #%d", extraMsg, state.RetryCount)
 		default:
@@ -1847,8 +1925,21 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 		app.Status.Summary = tree.GetSummary(app)
 	}
 
-	canSync, _ := project.Spec.SyncWindows.Matches(app).CanSync(false)
-	if canSync {
+	ctrl.runAutoResourceActions(app, project, destCluster, compareResult)
+	ts.AddCheckpoint("auto_resource_actions_ms")
+
+	syncBlocked, err := syncWindowsPreventSync(app, project, false, ctrl.settingsMgr, func(name string) (*appv1.AppProject, error) {
+		return applisters.NewAppProjectLister(ctrl.projInformer.GetIndexer()).AppProjects(ctrl.namespace).Get(name)
+	})
+	if stderrors.Is(err, errSyncWindowExclusivityGroups) {
+		logCtx.WithError(err).Warn("Failed to evaluate the sync window exclusivity groups")
+		// the unknown errors are reset by refreshAppConditions on each reconciliation
+		app.Status.SetConditions(
+			[]appv1.ApplicationCondition{{Type: appv1.ApplicationConditionUnknownError, Message: err.Error()}},
+			map[appv1.ApplicationConditionType]bool{},
+		)
+	}
+	if !syncBlocked {
 		syncErrCond, opDuration := ctrl.autoSync(app, compareResult.syncStatus, compareResult.resources, compareResult.revisionsMayHaveChanges)
 		setOpDuration = opDuration
 		if syncErrCond != nil {
@@ -2054,6 +2145,9 @@ func (ctrl *ApplicationController) needRefreshAppStatus(app *appv1.Application,
 func (ctrl *ApplicationController) refreshAppConditions(app *appv1.Application) (*appv1.AppProject, bool) {
 	errorConditions := make([]appv1.ApplicationCondition, 0)
 	proj, err := ctrl.getAppProj(app)
+	if apierrors.IsNotFound(err) {
+		proj, err = ctrl.reassignOrphanedApp(app, err)
+	}
 	if err != nil {
 		errorConditions = append(errorConditions, ctrl.projectErrorToCondition(err, app))
 	} else {
@@ -2068,8 +2162,9 @@ func (ctrl *ApplicationController) refreshAppConditions(app *appv1.Application)
 		}
 	}
 	app.Status.SetConditions(errorConditions, map[appv1.ApplicationConditionType]bool{
-		appv1.ApplicationConditionInvalidSpecError: true,
-		appv1.ApplicationConditionUnknownError:     true,
+		appv1.ApplicationConditionInvalidSpecError:     true,
+		appv1.ApplicationConditionOrphanedProjectError: true,
+		appv1.ApplicationConditionUnknownError:         true,
 	})
 	return proj, len(errorConditions) > 0
 }
@@ -2536,10 +2631,11 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 						log.WithFields(applog.GetAppLogFields(newApp)).Info("Enabled automated sync")
 						compareWith = CompareWithLatest.Pointer()
 					}
-					if ctrl.statusRefreshJitter != 0 && oldApp.ResourceVersion == newApp.ResourceVersion {
-						// Handler is refreshing the apps, add a random jitter to spread the load and avoid spikes
-						jitter := time.Duration(float64(ctrl.statusRefreshJitter) * rand.Float64())
-						delay = &jitter
+					if oldApp.ResourceVersion == newApp.ResourceVersion {
+						// Handler is refreshing the apps, add a jitter to spread the load and avoid spikes
+						if jitter := ctrl.resyncJitter(key); jitter != 0 {
+							delay = &jitter
+						}
 					}
 				}
 
@@ -2566,6 +2662,7 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 				delApp, delOK := obj.(*appv1.Application)
 				if err == nil && delOK {
 					ctrl.clusterSharding.DeleteApp(delApp)
+					ctrl.autoActions.forget(delApp.QualifiedName())
 				}
 			},
 		},
@@ -2576,6 +2673,23 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 	return informer, lister
 }
 
+// resyncJitter returns the delay applied to a periodic refresh of the application with the given key. The delay
+// consists of a stable per-app offset, which spreads applications across a configured fraction of the resync period,
+// and a random jitter bounded by statusRefreshJitter. Event-triggered refreshes never go through this function.
+func (ctrl *ApplicationController) resyncJitter(appKey string) time.Duration {
+	var delay time.Duration
+	if ctrl.statusRefreshJitterFraction > 0 && ctrl.statusRefreshTimeout > 0 {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(appKey))
+		offset := float64(h.Sum32()) / float64(math.MaxUint32)
+		delay = time.Duration(float64(ctrl.statusRefreshTimeout) * ctrl.statusRefreshJitterFraction * offset)
+	}
+	if ctrl.statusRefreshJitter != 0 {
+		delay += time.Duration(float64(ctrl.statusRefreshJitter) * rand.Float64())
+	}
+	return delay
+}
+
 func (ctrl *ApplicationController) projectErrorToCondition(err error, app *appv1.Application) appv1.ApplicationCondition {
 	var condition appv1.ApplicationCondition
 	if apierrors.IsNotFound(err) {
@@ -2583,6 +2697,12 @@ func (ctrl *ApplicationController) projectErrorToCondition(err error, app *appv1
 			Type:    appv1.ApplicationConditionInvalidSpecError,
 			Message: fmt.Sprintf("Application referencing project %s which does not exist", app.Spec.Project),
 		}
+		if policy, _, _ := ctrl.settingsMgr.GetProjectDeletionPolicy(); policy == settings_util.ProjectDeletionPolicyCondition {
+			condition = appv1.ApplicationCondition{
+				Type:    appv1.ApplicationConditionOrphanedProjectError,
+				Message: fmt.Sprintf("Application referencing project %s which does not exist or was deleted; move the application to an existing project or recreate the project", app.Spec.Project),
+			}
+		}
 	} else {
 		condition = appv1.ApplicationCondition{Type: appv1.ApplicationConditionUnknownError, Message: err.Error()}
 	}
@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	kubetesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
@@ -84,6 +85,12 @@ type MockKubectl struct {
 
 	DeletedResources []kube.ResourceKey
 	CreatedResources []*unstructured.Unstructured
+	PatchedResources []kube.ResourceKey
+}
+
+func (m *MockKubectl) PatchResource(ctx context.Context, config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, patchType types.PatchType, patchBytes []byte, subresources ...string) (*unstructured.Unstructured, error) {
+	m.PatchedResources = append(m.PatchedResources, kube.NewResourceKey(gvk.Group, gvk.Kind, namespace, name))
+	return m.Kubectl.PatchResource(ctx, config, gvk, name, namespace, patchType, patchBytes, subresources...)
 }
 
 func (m *MockKubectl) CreateResource(ctx context.Context, config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, obj *unstructured.Unstructured, createOptions metav1.CreateOptions, subresources ...string) (*unstructured.Unstructured, error) {
@@ -188,6 +195,7 @@ func newFakeControllerWithResync(ctx context.Context, data *fakeData, appResyncP
 		appResyncPeriod,
 		time.Hour,
 		time.Second,
+		0,
 		time.Minute,
 		nil,
 		0,
@@ -1677,6 +1685,32 @@ func TestSetOperationStateLogRetries(t *testing.T) {
 	assert.Contains(t, errorVal.Error(), "fake error")
 }
 
+func TestResyncJitter(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		ctrl := &ApplicationController{statusRefreshTimeout: time.Minute}
+		assert.Zero(t, ctrl.resyncJitter("argocd/my-app"))
+	})
+	t.Run("random jitter is bounded", func(t *testing.T) {
+		ctrl := &ApplicationController{statusRefreshTimeout: time.Minute, statusRefreshJitter: 10 * time.Second}
+		for i := 0; i < 100; i++ {
+			assert.Less(t, ctrl.resyncJitter("argocd/my-app"), 10*time.Second)
+		}
+	})
+	t.Run("per-app offset is stable and bounded by fraction", func(t *testing.T) {
+		ctrl := &ApplicationController{statusRefreshTimeout: time.Minute, statusRefreshJitterFraction: 0.5}
+		offsets := map[time.Duration]bool{}
+		for i := 0; i < 20; i++ {
+			key := fmt.Sprintf("argocd/app-%d", i)
+			offset := ctrl.resyncJitter(key)
+			assert.Equal(t, offset, ctrl.resyncJitter(key))
+			assert.GreaterOrEqual(t, offset, time.Duration(0))
+			assert.LessOrEqual(t, offset, 30*time.Second)
+			offsets[offset] = true
+		}
+		assert.Greater(t, len(offsets), 1, "apps should be spread across the interval")
+	})
+}
+
 func TestNeedRefreshAppStatus(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -1980,6 +2014,94 @@ func TestRefreshAppConditions(t *testing.T) {
 		assert.Equal(t, v1alpha1.ApplicationConditionInvalidSpecError, app.Status.Conditions[0].Type)
 		assert.Equal(t, "Application referencing project wrong project which does not exist", app.Status.Conditions[0].Message)
 	})
+
+	t.Run("OrphanedProjectCondition", func(t *testing.T) {
+		app := newFakeApp()
+		app.Spec.Project = "deleted"
+		app.Status.SetConditions([]v1alpha1.ApplicationCondition{{Type: v1alpha1.ApplicationConditionOrphanedProjectError, Message: "old message"}}, nil)
+
+		ctrl := newFakeController(t.Context(), &fakeData{
+			apps:          []runtime.Object{app, &defaultProj},
+			configMapData: map[string]string{"application.projectDeletionPolicy": "condition"},
+		}, nil)
+
+		_, hasErrors := ctrl.refreshAppConditions(app)
+		assert.True(t, hasErrors)
+		require.Len(t, app.Status.Conditions, 1)
+		assert.Equal(t, v1alpha1.ApplicationConditionOrphanedProjectError, app.Status.Conditions[0].Type)
+		assert.Contains(t, app.Status.Conditions[0].Message, "project deleted which does not exist or was deleted")
+	})
+
+	t.Run("ReassignsToFallbackProject", func(t *testing.T) {
+		app := newFakeApp()
+		app.Spec.Project = "deleted"
+		app.Status.SetConditions([]v1alpha1.ApplicationCondition{{Type: v1alpha1.ApplicationConditionInvalidSpecError, Message: "old message"}}, nil)
+
+		ctrl := newFakeController(t.Context(), &fakeData{
+			apps: []runtime.Object{app, &defaultProj},
+			configMapData: map[string]string{
+				"application.projectDeletionPolicy":                 "reassign",
+				"application.projectDeletionPolicy.fallbackProject": "default",
+			},
+		}, nil)
+		ctrl.deletedProjects.Store("deleted", true)
+
+		proj, hasErrors := ctrl.refreshAppConditions(app)
+		assert.False(t, hasErrors)
+		assert.Empty(t, app.Status.Conditions)
+		require.NotNil(t, proj)
+		assert.Equal(t, "default", proj.Name)
+		assert.Equal(t, "default", app.Spec.Project)
+
+		updatedApp, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(app.Namespace).Get(t.Context(), app.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "default", updatedApp.Spec.Project)
+	})
+
+	t.Run("ReassignWithMissingFallbackProject", func(t *testing.T) {
+		app := newFakeApp()
+		app.Spec.Project = "deleted"
+
+		ctrl := newFakeController(t.Context(), &fakeData{
+			apps: []runtime.Object{app, &defaultProj},
+			configMapData: map[string]string{
+				"application.projectDeletionPolicy":                 "reassign",
+				"application.projectDeletionPolicy.fallbackProject": "missing",
+			},
+		}, nil)
+		ctrl.deletedProjects.Store("deleted", true)
+
+		_, hasErrors := ctrl.refreshAppConditions(app)
+		assert.True(t, hasErrors)
+		require.Len(t, app.Status.Conditions, 1)
+		assert.Equal(t, v1alpha1.ApplicationConditionUnknownError, app.Status.Conditions[0].Type)
+		assert.Contains(t, app.Status.Conditions[0].Message, "cannot be reassigned to fallback project missing")
+		assert.Equal(t, "deleted", app.Spec.Project)
+	})
+
+	t.Run("KeepsAppOfNeverExistingProject", func(t *testing.T) {
+		app := newFakeApp()
+		app.Spec.Project = "typo"
+
+		ctrl := newFakeController(t.Context(), &fakeData{
+			apps: []runtime.Object{app, &defaultProj},
+			configMapData: map[string]string{
+				"application.projectDeletionPolicy":                 "reassign",
+				"application.projectDeletionPolicy.fallbackProject": "default",
+			},
+		}, nil)
+
+		_, hasErrors := ctrl.refreshAppConditions(app)
+		assert.True(t, hasErrors)
+		require.Len(t, app.Status.Conditions, 1)
+		assert.Equal(t, v1alpha1.ApplicationConditionInvalidSpecError, app.Status.Conditions[0].Type)
+		assert.Contains(t, app.Status.Conditions[0].Message, "project typo which does not exist")
+		assert.Equal(t, "typo", app.Spec.Project)
+
+		updatedApp, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(app.Namespace).Get(t.Context(), app.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "typo", updatedApp.Spec.Project)
+	})
 }
 
 func TestUpdateReconciledAt(t *testing.T) {
@@ -2313,6 +2435,50 @@ func TestFinalizeProjectDeletion_DoesNotHaveApplications(t *testing.T) {
 	}, receivedPatch)
 }
 
+func TestFinalizeProjectDeletion_HasApplicationsInOtherNamespace(t *testing.T) {
+	app := newFakeApp()
+	app.Namespace = "team-a"
+	proj := &v1alpha1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: test.FakeArgoCDNamespace}}
+	ctrl := newFakeController(t.Context(), &fakeData{apps: []runtime.Object{app, proj}, applicationNamespaces: []string{"team-a"}}, nil)
+
+	fakeAppCs := ctrl.applicationClientset.(*appclientset.Clientset)
+	patched := false
+	fakeAppCs.PrependReactor("patch", "*", func(_ kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+		patched = true
+		return true, &v1alpha1.AppProject{}, nil
+	})
+
+	err := ctrl.finalizeProjectDeletion(proj)
+	require.NoError(t, err)
+	assert.False(t, patched)
+}
+
+func TestProcessProjectQueueItem_ProjectDeletionPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy        string
+		wantFinalizer bool
+	}{
+		{policy: "", wantFinalizer: false},
+		{policy: "condition", wantFinalizer: false},
+		{policy: "block", wantFinalizer: true},
+	} {
+		t.Run("Policy="+tc.policy, func(t *testing.T) {
+			proj := defaultProj.DeepCopy()
+			ctrl := newFakeController(t.Context(), &fakeData{
+				apps:          []runtime.Object{proj},
+				configMapData: map[string]string{"application.projectDeletionPolicy": tc.policy},
+			}, nil)
+
+			ctrl.projectRefreshQueue.Add(proj.Namespace + "/" + proj.Name)
+			ctrl.processProjectQueueItem()
+
+			updatedProj, err := ctrl.applicationClientset.ArgoprojV1alpha1().AppProjects(proj.Namespace).Get(t.Context(), proj.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantFinalizer, updatedProj.HasFinalizer())
+		})
+	}
+}
+
 func TestProcessRequestedAppOperation_FailedNoRetries(t *testing.T) {
 	app := newFakeApp()
 	app.Spec.Project = "default"
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/controller/metrics"
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	applog "github.com/argoproj/argo-cd/v3/util/app/log"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/lua"
+	settings_util "github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// autoActionState tracks a resource matching an auto resource action binding
+type autoActionState struct {
+	// since is when the resource was first seen with the health status of the binding, zero if it currently has another status
+	since time.Time
+	// lastRun is when the action last ran on the resource
+	lastRun time.Time
+}
+
+// autoActionTracker keeps track, per application, of how long resources have reported the health status of an auto
+// resource action binding and of when the action last ran on them. The state is kept in memory, so the durations
+// restart when the controller restarts.
+type autoActionTracker struct {
+	lock   sync.Mutex
+	states map[string]map[string]*autoActionState
+}
+
+func newAutoActionTracker() *autoActionTracker {
+	return &autoActionTracker{states: map[string]map[string]*autoActionState{}}
+}
+
+// autoActionObservation is the health status of a resource matching an auto resource action binding
+type autoActionObservation struct {
+	key     string
+	matches bool
+	binding settings_util.AutoResourceAction
+}
+
+// observe records the given observations for the application and returns the keys of the resources on which the
+// action of their binding is due. The states of resources which are not observed anymore are dropped once they are
+// out of their minimum interval.
+func (t *autoActionTracker) observe(appKey string, observations []autoActionObservation, now time.Time) []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	prev := t.states[appKey]
+	next := make(map[string]*autoActionState, len(observations))
+	var due []string
+	for _, o := range observations {
+		state, ok := prev[o.key]
+		if !ok {
+			state = &autoActionState{}
+		}
+		next[o.key] = state
+		if !o.matches {
+			state.since = time.Time{}
+			continue
+		}
+		if state.since.IsZero() {
+			state.since = now
+		}
+		if now.Sub(state.since) < o.binding.After.Duration {
+			continue
+		}
+		if !state.lastRun.IsZero() && now.Sub(state.lastRun) < o.binding.GetMinInterval() {
+			continue
+		}
+		state.lastRun = now
+		due = append(due, o.key)
+	}
+	for key, state := range prev {
+		if _, ok := next[key]; !ok && !state.lastRun.IsZero() && now.Sub(state.lastRun) < maxAutoActionMinInterval {
+			state.since = time.Time{}
+			next[key] = state
+		}
+	}
+	if len(next) == 0 {
+		delete(t.states, appKey)
+	} else {
+		t.states[appKey] = next
+	}
+	return due
+}
+
+// forget drops the state of the application
+func (t *autoActionTracker) forget(appKey string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.states, appKey)
+}
+
+// maxAutoActionMinInterval bounds how long the last run of an action is remembered for a resource which is not part of
+// the application anymore
+const maxAutoActionMinInterval = 24 * time.Hour
+
+func autoActionKey(res appv1.ResourceStatus, action string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", res.Group, res.Kind, res.Namespace, res.Name, action)
+}
+
+// runAutoResourceActions runs the resource actions bound in argocd-cm to the health status of the managed resources of
+// the application, once the resources have reported the health status for long enough.
+func (ctrl *ApplicationController) runAutoResourceActions(app *appv1.Application, proj *appv1.AppProject, destCluster *appv1.Cluster, compareResult *comparisonResult) {
+	logCtx := log.WithFields(applog.GetAppLogFields(app))
+	appKey := app.QualifiedName()
+	bindings, err := ctrl.settingsMgr.GetAutoResourceActions()
+	if err != nil {
+		logCtx.WithError(err).Warn("Failed to get auto resource actions")
+		return
+	}
+	if len(bindings) == 0 || app.DeletionTimestamp != nil {
+		ctrl.autoActions.forget(appKey)
+		return
+	}
+	if app.Operation != nil || (app.Status.OperationState != nil && !app.Status.OperationState.Phase.Completed()) {
+		// do not interfere with a running sync, the resources are observed again on the next refresh
+		return
+	}
+
+	liveObjs := make(map[kube.ResourceKey]*unstructured.Unstructured, len(compareResult.managedResources))
+	for _, res := range compareResult.managedResources {
+		if res.Live != nil {
+			liveObjs[kube.NewResourceKey(res.Group, res.Kind, res.Namespace, res.Name)] = res.Live
+		}
+	}
+
+	observations := make([]autoActionObservation, 0)
+	targets := make(map[string]*unstructured.Unstructured)
+	bindingsByKey := make(map[string]settings_util.AutoResourceAction)
+	for _, res := range compareResult.resources {
+		live, ok := liveObjs[kube.NewResourceKey(res.Group, res.Kind, res.Namespace, res.Name)]
+		if !ok || res.Health == nil {
+			continue
+		}
+		for _, binding := range bindings {
+			if !binding.Match(res.Group, res.Kind) {
+				continue
+			}
+			key := autoActionKey(res, binding.Action)
+			observations = append(observations, autoActionObservation{key: key, matches: res.Health.Status == binding.Health, binding: binding})
+			targets[key] = live
+			bindingsByKey[key] = binding
+		}
+	}
+
+	for _, key := range ctrl.autoActions.observe(appKey, observations, time.Now()) {
+		live := targets[key]
+		binding := bindingsByKey[key]
+		resource := fmt.Sprintf("%s/%s/%s", live.GroupVersionKind().Group, live.GetKind(), live.GetName())
+		err := ctrl.executeAutoResourceAction(app, proj, destCluster, live, binding.Action)
+		if err != nil {
+			logCtx.WithError(err).Warnf("Failed to run auto action %s on resource %s", binding.Action, resource)
+			ctrl.logAppEvent(context.TODO(), app, argo.EventInfo{Reason: argo.EventReasonResourceActionRan, Type: corev1.EventTypeWarning},
+				fmt.Sprintf("failed to run auto action %s on resource %s: %v", binding.Action, resource, err))
+			continue
+		}
+		logCtx.Infof("Ran auto action %s on resource %s", binding.Action, resource)
+		ctrl.logAppEvent(context.TODO(), app, argo.EventInfo{Reason: argo.EventReasonResourceActionRan, Type: corev1.EventTypeNormal},
+			fmt.Sprintf("ran auto action %s on resource %s after being %s for %s", binding.Action, resource, binding.Health, binding.After.Duration))
+	}
+}
+
+// executeAutoResourceAction runs the named Lua resource action of the live object and applies its result
+func (ctrl *ApplicationController) executeAutoResourceAction(app *appv1.Application, proj *appv1.AppProject, destCluster *appv1.Cluster, liveObj *unstructured.Unstructured, actionName string) error {
+	resourceOverrides, err := ctrl.settingsMgr.GetResourceOverrides()
+	if err != nil {
+		return fmt.Errorf("error getting resource overrides: %w", err)
+	}
+	luaVM := lua.VM{
+		ResourceOverrides: resourceOverrides,
+	}
+	action, err := luaVM.GetResourceAction(liveObj, actionName)
+	if err != nil {
+		return fmt.Errorf("error getting Lua resource action: %w", err)
+	}
+	newObjects, err := luaVM.ExecuteResourceAction(liveObj, action.ActionLua, nil)
+	if err != nil {
+		return fmt.Errorf("error executing Lua resource action: %w", err)
+	}
+	if len(newObjects) == 0 {
+		return nil
+	}
+
+	clusterRESTConfig, err := destCluster.RESTConfig()
+	if err != nil {
+		return fmt.Errorf("error getting cluster REST config: %w", err)
+	}
+	config := metrics.AddMetricsTransportWrapper(ctrl.metricsServer, app, clusterRESTConfig)
+	if err := ctrl.applyImpersonationConfig(config, proj, app, destCluster); err != nil {
+		return fmt.Errorf("cannot apply impersonation: %w", err)
+	}
+
+	return argo.ApplyResourceActionResult(context.TODO(), ctrl.kubectl, config, ctrl.db, destCluster, proj, liveObj, newObjects)
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/test"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+func TestAutoActionTracker(t *testing.T) {
+	binding := settings.AutoResourceAction{
+		Kind:        "Deployment",
+		Health:      health.HealthStatusDegraded,
+		Action:      "restart",
+		After:       metav1.Duration{Duration: 10 * time.Minute},
+		MinInterval: &metav1.Duration{Duration: time.Hour},
+	}
+	observation := func(matches bool) []autoActionObservation {
+		return []autoActionObservation{{key: "my-deploy", matches: matches, binding: binding}}
+	}
+	start := time.Now()
+
+	t.Run("RunsAfterDuration", func(t *testing.T) {
+		tracker := newAutoActionTracker()
+		assert.Empty(t, tracker.observe("app", observation(true), start))
+		assert.Empty(t, tracker.observe("app", observation(true), start.Add(5*time.Minute)))
+		assert.Equal(t, []string{"my-deploy"}, tracker.observe("app", observation(true), start.Add(10*time.Minute)))
+		// bounded by the minimum interval
+		assert.Empty(t, tracker.observe("app", observation(true), start.Add(30*time.Minute)))
+		assert.Equal(t, []string{"my-deploy"}, tracker.observe("app", observation(true), start.Add(70*time.Minute)))
+	})
+
+	t.Run("DurationRestartsOnTransition", func(t *testing.T) {
+		tracker := newAutoActionTracker()
+		assert.Empty(t, tracker.observe("app", observation(true), start))
+		assert.Empty(t, tracker.observe("app", observation(false), start.Add(9*time.Minute)))
+		assert.Empty(t, tracker.observe("app", observation(true), start.Add(10*time.Minute)))
+		assert.Equal(t, []string{"my-deploy"}, tracker.observe("app", observation(true), start.Add(20*time.Minute)))
+	})
+
+	t.Run("MinIntervalSurvivesRemoval", func(t *testing.T) {
+		tracker := newAutoActionTracker()
+		binding.After = metav1.Duration{}
+		defer func() { binding.After = metav1.Duration{Duration: 10 * time.Minute} }()
+		assert.Equal(t, []string{"my-deploy"}, tracker.observe("app", observation(true), start))
+		assert.Empty(t, tracker.observe("app", nil, start.Add(time.Minute)))
+		assert.Empty(t, tracker.observe("app", observation(true), start.Add(2*time.Minute)))
+	})
+
+	t.Run("Forget", func(t *testing.T) {
+		tracker := newAutoActionTracker()
+		tracker.observe("app", observation(true), start)
+		tracker.forget("app")
+		assert.Empty(t, tracker.states)
+	})
+}
+
+func TestRunAutoResourceActions(t *testing.T) {
+	deployment := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":      "my-deploy",
+			"namespace": test.FakeDestNamespace,
+		},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{},
+			},
+		},
+	}}
+	compareResult := &comparisonResult{
+		managedResources: []managedResource{{
+			Live:      deployment,
+			Group:     "apps",
+			Kind:      "Deployment",
+			Namespace: test.FakeDestNamespace,
+			Name:      "my-deploy",
+		}},
+		resources: []v1alpha1.ResourceStatus{{
+			Group:     "apps",
+			Kind:      "Deployment",
+			Namespace: test.FakeDestNamespace,
+			Name:      "my-deploy",
+			Health:    &v1alpha1.HealthStatus{Status: health.HealthStatusDegraded},
+		}},
+	}
+	destCluster := &v1alpha1.Cluster{
+		Server: test.FakeClusterURL,
+		Config: v1alpha1.ClusterConfig{TLSClientConfig: v1alpha1.TLSClientConfig{Insecure: true}},
+	}
+
+	newController := func(t *testing.T, autoActions string) (*ApplicationController, *v1alpha1.Application) {
+		t.Helper()
+		app := newFakeApp()
+		ctrl := newFakeController(t.Context(), &fakeData{
+			apps:            []runtime.Object{app, &defaultProj},
+			managedLiveObjs: map[kube.ResourceKey]*unstructured.Unstructured{},
+			configMapData: map[string]string{
+				"resource.autoActions": autoActions,
+			},
+		}, nil)
+		return ctrl, app
+	}
+
+	t.Run("RunsBoundAction", func(t *testing.T) {
+		ctrl, app := newController(t, `[{group: apps, kind: Deployment, health: Degraded, action: restart}]`)
+		ctrl.runAutoResourceActions(app, &defaultProj, destCluster, compareResult)
+		patched := ctrl.kubectl.(*MockKubectl).PatchedResources
+		require.Len(t, patched, 1)
+		assert.Equal(t, kube.NewResourceKey("apps", "Deployment", test.FakeDestNamespace, "my-deploy"), patched[0])
+
+		// the second run is bounded by the minimum interval
+		ctrl.runAutoResourceActions(app, &defaultProj, destCluster, compareResult)
+		assert.Len(t, ctrl.kubectl.(*MockKubectl).PatchedResources, 1)
+	})
+
+	t.Run("IgnoresOtherHealthStatus", func(t *testing.T) {
+		ctrl, app := newController(t, `[{group: apps, kind: Deployment, health: Missing, action: restart}]`)
+		ctrl.runAutoResourceActions(app, &defaultProj, destCluster, compareResult)
+		assert.Empty(t, ctrl.kubectl.(*MockKubectl).PatchedResources)
+	})
+
+	t.Run("WaitsForDuration", func(t *testing.T) {
+		ctrl, app := newController(t, `[{group: apps, kind: Deployment, health: Degraded, after: 1h, action: restart}]`)
+		ctrl.runAutoResourceActions(app, &defaultProj, destCluster, compareResult)
+		assert.Empty(t, ctrl.kubectl.(*MockKubectl).PatchedResources)
+	})
+
+	t.Run("SkipsRunningOperation", func(t *testing.T) {
+		ctrl, app := newController(t, `[{group: apps, kind: Deployment, health: Degraded, action: restart}]`)
+		app.Operation = &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}}
+		ctrl.runAutoResourceActions(app, &defaultProj, destCluster, compareResult)
+		assert.Empty(t, ctrl.kubectl.(*MockKubectl).PatchedResources)
+	})
+
+	t.Run("UnknownAction", func(t *testing.T) {
+		ctrl, app := newController(t, `[{group: apps, kind: Deployment, health: Degraded, action: does-not-exist}]`)
+		ctrl.runAutoResourceActions(app, &defaultProj, destCluster, compareResult)
+		assert.Empty(t, ctrl.kubectl.(*MockKubectl).PatchedResources)
+	})
+}
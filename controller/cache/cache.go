@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"net"
 	"net/url"
 	"os/exec"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -37,6 +39,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/argo/normalizers"
 	"github.com/argoproj/argo-cd/v3/util/db"
 	"github.com/argoproj/argo-cd/v3/util/env"
+	"github.com/argoproj/argo-cd/v3/util/glob"
 	logutils "github.com/argoproj/argo-cd/v3/util/log"
 	"github.com/argoproj/argo-cd/v3/util/lua"
 	"github.com/argoproj/argo-cd/v3/util/settings"
@@ -203,6 +206,7 @@ func NewLiveStateCache(
 		metricsServer:    metricsServer,
 		clusterSharding:  clusterSharding,
 		resourceTracking: resourceTracking,
+		healthScripts:    lua.NewHealthScriptGuard(),
 	}
 }
 
@@ -216,6 +220,10 @@ type cacheSettings struct {
 
 	// ignoreResourceUpdates is a flag to enable resource-ignore rules.
 	ignoreResourceUpdatesEnabled bool
+
+	// healthOverrides are the resource overrides the health of the resources is evaluated with, whose health scripts
+	// compile
+	healthOverrides map[string]appv1.ResourceOverride
 }
 
 type liveStateCache struct {
@@ -227,6 +235,8 @@ type liveStateCache struct {
 	clusterSharding      sharding.ClusterShardingCache
 	resourceTracking     argo.ResourceTracking
 	ignoreNormalizerOpts normalizers.IgnoreNormalizerOpts
+	// healthScripts keeps the last custom health scripts which compiled, so that a broken script is not applied
+	healthScripts *lua.HealthScriptGuard
 
 	clusters      map[string]clustercache.ClusterCache
 	cacheSettings cacheSettings
@@ -262,12 +272,23 @@ func (c *liveStateCache) loadCacheSettings() (*cacheSettings, error) {
 	if err != nil {
 		return nil, err
 	}
+	healthAgeThresholds, err := c.settingsMgr.GetResourceHealthAgeThresholds()
+	if err != nil {
+		return nil, err
+	}
+	resourceOverrides, scriptErrs := c.healthScripts.Apply(resourceOverrides)
+	for _, key := range slices.Sorted(maps.Keys(scriptErrs)) {
+		log.Warnf("Keeping the previous health script of %s: %v", key, scriptErrs[key])
+		if c.metricsServer != nil {
+			c.metricsServer.IncHealthScriptReload(key, false)
+		}
+	}
 	clusterSettings := clustercache.Settings{
-		ResourceHealthOverride: lua.ResourceHealthOverrides(resourceOverrides),
+		ResourceHealthOverride: lua.NewResourceHealthOverride(resourceOverrides, healthAgeThresholds),
 		ResourcesFilter:        resourcesFilter,
 	}
 
-	return &cacheSettings{clusterSettings, appInstanceLabelKey, appv1.TrackingMethod(trackingMethod), installationID, resourceUpdatesOverrides, ignoreResourceUpdatesEnabled}, nil
+	return &cacheSettings{clusterSettings, appInstanceLabelKey, appv1.TrackingMethod(trackingMethod), installationID, resourceUpdatesOverrides, ignoreResourceUpdatesEnabled, resourceOverrides}, nil
 }
 
 func asResourceNode(r *clustercache.Resource, namespaceResources map[kube.ResourceKey]*clustercache.Resource) appv1.ResourceNode {
@@ -779,7 +800,9 @@ func (c *liveStateCache) watchSettings(ctx context.Context) {
 
 			c.lock.Lock()
 			needInvalidate := false
+			var reloadedHealthScripts []string
 			if !reflect.DeepEqual(c.cacheSettings, *nextCacheSettings) {
+				reloadedHealthScripts = changedHealthScripts(c.cacheSettings.healthOverrides, nextCacheSettings.healthOverrides)
 				c.cacheSettings = *nextCacheSettings
 				needInvalidate = true
 			}
@@ -787,6 +810,9 @@ func (c *liveStateCache) watchSettings(ctx context.Context) {
 			if needInvalidate {
 				c.invalidate(*nextCacheSettings)
 			}
+			if len(reloadedHealthScripts) > 0 {
+				c.reloadHealthScripts(reloadedHealthScripts)
+			}
 		case <-ctx.Done():
 			done = true
 		}
@@ -796,6 +822,57 @@ func (c *liveStateCache) watchSettings(ctx context.Context) {
 	close(updateCh)
 }
 
+// changedHealthScripts returns the keys of the resource overrides whose health scripts differ between the given
+// overrides
+func changedHealthScripts(previous, next map[string]appv1.ResourceOverride) []string {
+	var keys []string
+	for key, override := range next {
+		if previous[key].HealthLua != override.HealthLua {
+			keys = append(keys, key)
+		}
+	}
+	for key, override := range previous {
+		if _, ok := next[key]; !ok && override.HealthLua != "" {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// reloadHealthScripts records the reload of the health scripts of the given resource overrides, and requests the
+// refresh of the applications managing resources they match so that their health is evaluated again
+func (c *liveStateCache) reloadHealthScripts(keys []string) {
+	for _, key := range keys {
+		log.Infof("Reloaded the health script of %s", key)
+		if c.metricsServer != nil {
+			c.metricsServer.IncHealthScriptReload(key, true)
+		}
+	}
+	if c.appInformer == nil || c.onObjectUpdated == nil {
+		return
+	}
+	for _, obj := range c.appInformer.GetIndexer().List() {
+		app, ok := obj.(*appv1.Application)
+		if !ok {
+			continue
+		}
+		for _, res := range app.Status.Resources {
+			resourceKey := lua.GetConfigMapKey(schema.GroupVersionKind{Group: res.Group, Kind: res.Kind})
+			if !slices.ContainsFunc(keys, func(key string) bool { return glob.Match(key, resourceKey) }) {
+				continue
+			}
+			c.onObjectUpdated(map[string]bool{app.InstanceName(c.settingsMgr.GetNamespace()): true}, corev1.ObjectReference{
+				APIVersion: schema.GroupVersion{Group: res.Group, Version: res.Version}.String(),
+				Kind:       res.Kind,
+				Namespace:  res.Namespace,
+				Name:       res.Name,
+			})
+			break
+		}
+	}
+}
+
 func (c *liveStateCache) Init() error {
 	cacheSettings, err := c.loadCacheSettings()
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/argoproj/gitops-engine/pkg/utils/kube"
 	"github.com/stretchr/testify/mock"
 	"k8s.io/client-go/kubernetes/fake"
+	clientcache "k8s.io/client-go/tools/cache"
 
 	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/controller/metrics"
@@ -952,3 +953,49 @@ func Test_asResourceNode_same_namespace_parent(t *testing.T) {
 	assert.Equal(t, "my-deployment", resNode.ParentRefs[0].Name)
 	assert.Equal(t, "my-namespace", resNode.ParentRefs[0].Namespace, "Deployment parent should have same namespace")
 }
+
+func TestChangedHealthScripts(t *testing.T) {
+	previous := map[string]appv1.ResourceOverride{
+		"apps/Deployment": {HealthLua: "a"},
+		"Service":         {HealthLua: "b"},
+		"ConfigMap":       {HealthLua: "c"},
+		"Secret":          {IgnoreDifferences: appv1.OverrideIgnoreDiff{JSONPointers: []string{"/data"}}},
+	}
+	next := map[string]appv1.ResourceOverride{
+		"apps/Deployment": {HealthLua: "a"},
+		"Service":         {HealthLua: "b2"},
+		"Pod":             {HealthLua: "d"},
+		"Secret":          {IgnoreDifferences: appv1.OverrideIgnoreDiff{JSONPointers: []string{"/stringData"}}},
+	}
+	assert.Equal(t, []string{"ConfigMap", "Pod", "Service"}, changedHealthScripts(previous, next))
+	assert.Empty(t, changedHealthScripts(next, next))
+}
+
+func TestReloadHealthScripts(t *testing.T) {
+	_, settingsManager := fixtures(t.Context(), map[string]string{})
+	appInformer := clientcache.NewSharedIndexInformer(&clientcache.ListWatch{}, &appv1.Application{}, 0, clientcache.Indexers{})
+	newApp := func(name string, resources ...appv1.ResourceStatus) *appv1.Application {
+		return &appv1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     appv1.ApplicationStatus{Resources: resources},
+		}
+	}
+	require.NoError(t, appInformer.GetIndexer().Add(newApp("deployments", appv1.ResourceStatus{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "guestbook"})))
+	require.NoError(t, appInformer.GetIndexer().Add(newApp("services", appv1.ResourceStatus{Version: "v1", Kind: "Service", Namespace: "default", Name: "guestbook"})))
+
+	var updated []string
+	var refs []corev1.ObjectReference
+	c := &liveStateCache{
+		appInformer: appInformer,
+		settingsMgr: settingsManager,
+		onObjectUpdated: func(managedByApp map[string]bool, ref corev1.ObjectReference) {
+			for app := range managedByApp {
+				updated = append(updated, app)
+			}
+			refs = append(refs, ref)
+		},
+	}
+	c.reloadHealthScripts([]string{"apps/*"})
+	assert.Equal(t, []string{"deployments"}, updated)
+	assert.Equal(t, []corev1.ObjectReference{{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "guestbook"}}, refs)
+}
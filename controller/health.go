@@ -13,11 +13,10 @@ import (
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application"
 	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	applog "github.com/argoproj/argo-cd/v3/util/app/log"
-	"github.com/argoproj/argo-cd/v3/util/lua"
 )
 
 // setApplicationHealth updates the health statuses of all resources performed in the comparison
-func setApplicationHealth(resources []managedResource, statuses []appv1.ResourceStatus, resourceOverrides map[string]appv1.ResourceOverride, app *appv1.Application, persistResourceHealth bool) (health.HealthStatusCode, error) {
+func setApplicationHealth(resources []managedResource, statuses []appv1.ResourceStatus, healthOverride health.HealthOverride, app *appv1.Application, persistResourceHealth bool) (health.HealthStatusCode, error) {
 	var savedErr error
 	var errCount uint
 	var containsResources, containsLiveResources bool
@@ -44,7 +43,6 @@ func setApplicationHealth(resources []managedResource, statuses []appv1.Resource
 
 		var healthStatus *health.HealthStatus
 		var err error
-		healthOverrides := lua.ResourceHealthOverrides(resourceOverrides)
 		if res.Live == nil {
 			healthStatus = &health.HealthStatus{Status: health.HealthStatusMissing}
 		} else {
@@ -52,7 +50,7 @@ func setApplicationHealth(resources []managedResource, statuses []appv1.Resource
 			if isSelfReferencedApp(app, kubeutil.GetObjectRef(res.Live)) {
 				continue
 			}
-			healthStatus, err = health.GetResourceHealth(res.Live, healthOverrides)
+			healthStatus, err = health.GetResourceHealth(res.Live, healthOverride)
 			if err != nil && savedErr == nil {
 				errCount++
 				savedErr = fmt.Errorf("failed to get resource health for %q with name %q in namespace %q: %w", res.Live.GetKind(), res.Live.GetName(), res.Live.GetNamespace(), err)
@@ -40,6 +40,7 @@ type MetricsServer struct {
 	k8sRequestCounter                 *prometheus.CounterVec
 	clusterEventsCounter              *prometheus.CounterVec
 	redisRequestCounter               *prometheus.CounterVec
+	healthScriptReloadCounter         *prometheus.CounterVec
 	reconcileHistogram                *prometheus.HistogramVec
 	redisRequestHistogram             *prometheus.HistogramVec
 	resourceEventsProcessingHistogram *prometheus.HistogramVec
@@ -69,6 +70,13 @@ var (
 		nil,
 	)
 
+	descAppObjectSize = prometheus.NewDesc(
+		"argocd_app_object_size_bytes",
+		"Size of the serialized application object in bytes.",
+		descAppDefaultLabels,
+		nil,
+	)
+
 	syncCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "argocd_app_sync_total",
@@ -126,6 +134,14 @@ var (
 		[]string{"hostname", "initiator", "failed"},
 	)
 
+	healthScriptReloadCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_resource_health_script_reloads_total",
+			Help: "Number of reloads of the custom health scripts of the resource customizations.",
+		},
+		[]string{"hostname", "key", "valid"},
+	)
+
 	redisRequestHistogram = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "argocd_redis_request_duration",
@@ -205,6 +221,7 @@ func NewMetricsServer(addr string, appLister applister.ApplicationLister, appFil
 	registry.MustRegister(reconcileHistogram)
 	registry.MustRegister(clusterEventsCounter)
 	registry.MustRegister(redisRequestCounter)
+	registry.MustRegister(healthScriptReloadCounter)
 	registry.MustRegister(redisRequestHistogram)
 	registry.MustRegister(resourceEventsProcessingHistogram)
 	registry.MustRegister(resourceEventsNumberGauge)
@@ -227,6 +244,7 @@ func NewMetricsServer(addr string, appLister applister.ApplicationLister, appFil
 		reconcileHistogram:                reconcileHistogram,
 		clusterEventsCounter:              clusterEventsCounter,
 		redisRequestCounter:               redisRequestCounter,
+		healthScriptReloadCounter:         healthScriptReloadCounter,
 		redisRequestHistogram:             redisRequestHistogram,
 		resourceEventsProcessingHistogram: resourceEventsProcessingHistogram,
 		resourceEventsNumberGauge:         resourceEventsNumberGauge,
@@ -303,6 +321,12 @@ func (m *MetricsServer) IncRedisRequest(failed bool) {
 	m.redisRequestCounter.WithLabelValues(m.hostname, common.ApplicationController, strconv.FormatBool(failed)).Inc()
 }
 
+// IncHealthScriptReload increments the counter of the reloads of the custom health script of the given resource
+// customization, valid being false if the script failed to compile and was not applied
+func (m *MetricsServer) IncHealthScriptReload(key string, valid bool) {
+	m.healthScriptReloadCounter.WithLabelValues(m.hostname, key, strconv.FormatBool(valid)).Inc()
+}
+
 // ObserveRedisRequestDuration observes redis request duration
 func (m *MetricsServer) ObserveRedisRequestDuration(duration time.Duration) {
 	m.redisRequestHistogram.WithLabelValues(m.hostname, common.ApplicationController).Observe(duration.Seconds())
@@ -340,6 +364,7 @@ func (m *MetricsServer) SetExpiration(cacheExpiration time.Duration) error {
 		m.k8sRequestCounter.Reset()
 		m.clusterEventsCounter.Reset()
 		m.redisRequestCounter.Reset()
+		m.healthScriptReloadCounter.Reset()
 		m.reconcileHistogram.Reset()
 		m.redisRequestHistogram.Reset()
 		m.resourceEventsProcessingHistogram.Reset()
@@ -389,6 +414,7 @@ func (c *appCollector) Describe(ch chan<- *prometheus.Desc) {
 		ch <- descAppConditions
 	}
 	ch <- descAppInfo
+	ch <- descAppObjectSize
 }
 
 // Collect implements the prometheus.Collector interface
@@ -450,6 +476,12 @@ func (c *appCollector) collectApps(ch chan<- prometheus.Metric, app *argoappv1.A
 
 	addGauge(descAppInfo, 1, strconv.FormatBool(autoSyncEnabled), git.NormalizeGitURL(app.Spec.GetSource().RepoURL), destServer, app.Spec.Destination.Namespace, string(syncStatus), string(healthStatus), operation)
 
+	if size, err := argo.GetApplicationSerializedSize(app); err != nil {
+		log.Warnf("Failed to get size of application %s: %v", app.QualifiedName(), err)
+	} else {
+		addGauge(descAppObjectSize, float64(size))
+	}
+
 	if len(c.appLabels) > 0 {
 		labelValues := []string{}
 		for _, desiredLabel := range c.appLabels {
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -28,6 +29,7 @@ import (
 	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned/fake"
 	appinformer "github.com/argoproj/argo-cd/v3/pkg/client/informers/externalversions"
 	applister "github.com/argoproj/argo-cd/v3/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -600,6 +602,16 @@ argocd_app_reconcile_count{dest_server="https://localhost:6443",namespace="argoc
 	assertMetricsPrinted(t, appReconcileMetrics, body)
 }
 
+func TestAppObjectSizeMetric(t *testing.T) {
+	size, err := argo.GetApplicationSerializedSize(newFakeApp(fakeDefaultApp))
+	require.NoError(t, err)
+	testApp(t, []string{fakeDefaultApp}, fmt.Sprintf(`
+# HELP argocd_app_object_size_bytes Size of the serialized application object in bytes.
+# TYPE argocd_app_object_size_bytes gauge
+argocd_app_object_size_bytes{name="my-app",namespace="argocd",project="default"} %d
+`, size))
+}
+
 func TestOrphanedResourcesMetric(t *testing.T) {
 	cancel, appLister := newFakeLister(t.Context())
 	defer cancel()
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/argoproj/argo-cd/v3/common"
 	statecache "github.com/argoproj/argo-cd/v3/controller/cache"
@@ -34,6 +35,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/v3/util/app/history"
 	applog "github.com/argoproj/argo-cd/v3/util/app/log"
 	"github.com/argoproj/argo-cd/v3/util/app/path"
 	"github.com/argoproj/argo-cd/v3/util/argo"
@@ -44,6 +46,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/env"
 	"github.com/argoproj/argo-cd/v3/util/gpg"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/lua"
 	"github.com/argoproj/argo-cd/v3/util/settings"
 	"github.com/argoproj/argo-cd/v3/util/stats"
 )
@@ -114,6 +117,7 @@ type appStateManager struct {
 	db                    db.ArgoDB
 	settingsMgr           *settings.SettingsManager
 	appclientset          appclientset.Interface
+	kubeClientset         kubernetes.Interface
 	kubectl               kubeutil.Kubectl
 	onKubectlRun          kubeutil.OnKubectlRunFunc
 	repoClientset         apiclient.Clientset
@@ -127,6 +131,9 @@ type appStateManager struct {
 	repoErrorGracePeriod  time.Duration
 	serverSideDiff        bool
 	ignoreNormalizerOpts  normalizers.IgnoreNormalizerOpts
+	// healthScripts keeps the last custom health scripts which compiled, the scripts which do not are reported by the
+	// live state cache
+	healthScripts *lua.HealthScriptGuard
 }
 
 // GetRepoObjs will generate the manifests for the given application delegating the
@@ -985,7 +992,12 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 
 	ts.AddCheckpoint("sync_ms")
 
-	healthStatus, err := setApplicationHealth(managedResources, resourceSummaries, resourceOverrides, app, m.persistResourceHealth)
+	healthAgeThresholds, err := m.settingsMgr.GetResourceHealthAgeThresholds()
+	if err != nil {
+		conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: "error getting resource health age thresholds: " + err.Error(), LastTransitionTime: &now})
+	}
+	healthOverrides, _ := m.healthScripts.Apply(resourceOverrides)
+	healthStatus, err := setApplicationHealth(managedResources, resourceSummaries, lua.NewResourceHealthOverride(healthOverrides, healthAgeThresholds), app, m.persistResourceHealth)
 	if err != nil {
 		conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: "error setting app health: " + err.Error(), LastTransitionTime: &now})
 	}
@@ -1120,7 +1132,11 @@ func (m *appStateManager) persistRevisionHistory(
 		})
 	}
 
-	app.Status.History = app.Status.History.Trunc(app.Spec.GetRevisionHistoryLimit())
+	limit := app.Spec.GetRevisionHistoryLimit()
+	if trimmed := len(app.Status.History) - max(limit, 0); trimmed > 0 {
+		m.archiveRevisionHistory(app, app.Status.History[:trimmed])
+	}
+	app.Status.History = app.Status.History.Trunc(limit)
 
 	patch, err := json.Marshal(map[string]map[string][]v1alpha1.RevisionHistory{
 		"status": {
@@ -1134,10 +1150,40 @@ func (m *appStateManager) persistRevisionHistory(
 	return err
 }
 
+// historyArchiveTimeout bounds the archival of the trimmed revision history entries, which is best-effort
+const historyArchiveTimeout = 10 * time.Second
+
+// archiveRevisionHistory stores the revision history entries about to be trimmed from the application status in the
+// configured history archive sink, if any. Archival is best-effort: failures are logged and do not fail the sync.
+func (m *appStateManager) archiveRevisionHistory(app *v1alpha1.Application, entries v1alpha1.RevisionHistories) {
+	logCtx := log.WithFields(applog.GetAppLogFields(app))
+	sinkName, limit, err := m.settingsMgr.GetHistoryArchiveSink()
+	if err != nil {
+		logCtx.WithError(err).Warn("Failed to get revision history archive settings")
+		return
+	}
+	sink, err := history.NewSink(sinkName, m.kubeClientset, limit)
+	if err != nil {
+		logCtx.WithError(err).Warn("Failed to create revision history archive sink")
+		return
+	}
+	if sink == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), historyArchiveTimeout)
+	defer cancel()
+	if err := sink.Archive(ctx, app, entries); err != nil {
+		logCtx.WithError(err).Warnf("Failed to archive %d revision history entries", len(entries))
+		return
+	}
+	logCtx.Infof("Archived %d revision history entries to %s sink", len(entries), sinkName)
+}
+
 // NewAppStateManager creates new instance of AppStateManager
 func NewAppStateManager(
 	db db.ArgoDB,
 	appclientset appclientset.Interface,
+	kubeClientset kubernetes.Interface,
 	repoClientset apiclient.Clientset,
 	namespace string,
 	kubectl kubeutil.Kubectl,
@@ -1158,6 +1204,7 @@ func NewAppStateManager(
 		cache:                 cache,
 		db:                    db,
 		appclientset:          appclientset,
+		kubeClientset:         kubeClientset,
 		kubectl:               kubectl,
 		onKubectlRun:          onKubectlRun,
 		repoClientset:         repoClientset,
@@ -1170,6 +1217,7 @@ func NewAppStateManager(
 		repoErrorGracePeriod:  repoErrorGracePeriod,
 		serverSideDiff:        serverSideDiff,
 		ignoreNormalizerOpts:  ignoreNormalizerOpts,
+		healthScripts:         lua.NewHealthScriptGuard(),
 	}
 }
 
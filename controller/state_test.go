@@ -32,6 +32,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/v3/test"
+	"github.com/argoproj/argo-cd/v3/util/app/history"
 )
 
 // TestCompareAppStateEmpty tests comparison when both git and live have no objects
@@ -1009,6 +1010,33 @@ func Test_appStateManager_persistRevisionHistory(t *testing.T) {
 	assert.Empty(t, app.Status.History)
 }
 
+func Test_appStateManager_persistRevisionHistory_archive(t *testing.T) {
+	app := newFakeApp()
+	limit := int64(2)
+	app.Spec.RevisionHistoryLimit = &limit
+	ctrl := newFakeController(t.Context(), &fakeData{
+		apps: []runtime.Object{app},
+		configMapData: map[string]string{
+			"application.history.archive.sink":  "configmap",
+			"application.history.archive.limit": "3",
+		},
+	}, nil)
+	manager := ctrl.appStateManager.(*appStateManager)
+	for i := 0; i < 6; i++ {
+		err := manager.persistRevisionHistory(app, "my-revision", v1alpha1.ApplicationSource{}, []string{}, []v1alpha1.ApplicationSource{}, false, metav1.Time{}, v1alpha1.OperationInitiator{})
+		require.NoError(t, err)
+	}
+	require.Len(t, app.Status.History, 2)
+	assert.Equal(t, int64(4), app.Status.History[0].ID)
+
+	sink, err := history.NewSink(history.SinkConfigMap, ctrl.kubeClientset, 3)
+	require.NoError(t, err)
+	archived, err := sink.List(t.Context(), app)
+	require.NoError(t, err)
+	require.Len(t, archived, 3)
+	assert.Equal(t, []int64{1, 2, 3}, []int64{archived[0].ID, archived[1].ID, archived[2].ID})
+}
+
 // helper function to read contents of a file to string
 // panics on error
 func mustReadFile(path string) string {
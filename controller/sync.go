@@ -19,6 +19,7 @@ import (
 	"github.com/argoproj/gitops-engine/pkg/utils/kube"
 	jsonpatch "github.com/evanphx/json-patch"
 	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -37,6 +38,7 @@ import (
 	kubeutil "github.com/argoproj/argo-cd/v3/util/kube"
 	logutils "github.com/argoproj/argo-cd/v3/util/log"
 	"github.com/argoproj/argo-cd/v3/util/lua"
+	"github.com/argoproj/argo-cd/v3/util/settings"
 )
 
 const (
@@ -117,6 +119,41 @@ func newSyncOperationResult(app *v1alpha1.Application, op v1alpha1.SyncOperation
 	return syncRes
 }
 
+// syncOptionRetryOnlyFailed makes the retries of a failed sync apply only the resources which were not successfully
+// synced by the previous attempts
+const syncOptionRetryOnlyFailed = "RetryOnlyFailed=true"
+
+// syncResultForRetry returns the sync result the retry of a failed operation starts from. The results of the resources
+// which were successfully synced are kept if the RetryOnlyFailed=true sync option is set, so that the sync does not
+// apply them again and only applies the resources which failed, as well as the ones which were not reached yet, such as
+// the resources of later sync waves. Nil is returned to retry the whole sync.
+func syncResultForRetry(state *v1alpha1.OperationState) *v1alpha1.SyncOperationResult {
+	syncOp := state.Operation.Sync
+	// the results cannot be reused if the retry syncs the latest revisions
+	if state.SyncResult == nil || syncOp == nil || !syncOp.SyncOptions.HasOption(syncOptionRetryOnlyFailed) || state.Operation.Retry.Refresh {
+		return nil
+	}
+	result := state.SyncResult.DeepCopy()
+	result.Resources = nil
+	for _, res := range state.SyncResult.Resources {
+		if res.Status != common.ResultCodeSynced {
+			continue
+		}
+		succeeded := res.HookPhase == common.OperationSucceeded
+		if res.HookType == "" {
+			// resources are still running until they are healthy if the sync has several steps
+			succeeded = succeeded || res.HookPhase == common.OperationRunning
+		}
+		if succeeded {
+			result.Resources = append(result.Resources, res.DeepCopy())
+		}
+	}
+	if len(result.Resources) == 0 {
+		return nil
+	}
+	return result
+}
+
 func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alpha1.AppProject, state *v1alpha1.OperationState) {
 	syncId, err := syncid.Generate()
 	if err != nil {
@@ -138,7 +175,7 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 		state.SyncResult = newSyncOperationResult(app, syncOp)
 	}
 
-	if isBlocked, err := syncWindowPreventsSync(app, project); isBlocked {
+	if isBlocked, err := m.syncWindowPreventsSync(app, project); isBlocked {
 		// If the operation is currently running, simply let the user know the sync is blocked by a current sync window
 		if state.Phase == common.OperationRunning {
 			state.Message = "Sync operation blocked by sync window"
@@ -216,6 +253,12 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 		state.Message = fmt.Sprintf("Failed to load resource overrides: %v", err)
 		return
 	}
+	healthAgeThresholds, err := m.settingsMgr.GetResourceHealthAgeThresholds()
+	if err != nil {
+		state.Phase = common.OperationError
+		state.Message = fmt.Sprintf("Failed to load resource health age thresholds: %v", err)
+		return
+	}
 
 	initialResourcesRes := make([]common.ResourceSyncResult, len(state.SyncResult.Resources))
 	for i, res := range state.SyncResult.Resources {
@@ -306,7 +349,7 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 
 	opts := []sync.SyncOpt{
 		sync.WithLogr(logutils.NewLogrusLogger(logEntry)),
-		sync.WithHealthOverride(lua.ResourceHealthOverrides(resourceOverrides)),
+		sync.WithHealthOverride(lua.NewResourceHealthOverride(resourceOverrides, healthAgeThresholds)),
 		sync.WithPermissionValidator(func(un *unstructured.Unstructured, res *metav1.APIResource) error {
 			if !project.IsGroupKindNamePermitted(un.GroupVersionKind().GroupKind(), un.GetName(), res.Namespaced) {
 				return fmt.Errorf("resource %s:%s is not permitted in project %s", un.GroupVersionKind().Group, un.GroupVersionKind().Kind, project.Name)
@@ -556,18 +599,112 @@ func delayBetweenSyncWaves(_ common.SyncPhase, _ int, finalWave bool) error {
 	return nil
 }
 
-func syncWindowPreventsSync(app *v1alpha1.Application, proj *v1alpha1.AppProject) (bool, error) {
-	window := proj.Spec.SyncWindows.Matches(app)
+func (m *appStateManager) syncWindowPreventsSync(app *v1alpha1.Application, proj *v1alpha1.AppProject) (bool, error) {
 	isManual := false
 	if app.Status.OperationState != nil {
 		isManual = !app.Status.OperationState.Operation.InitiatedBy.Automated
 	}
-	canSync, err := window.CanSync(isManual)
+	return syncWindowsPreventSync(app, proj, isManual, m.settingsMgr, func(name string) (*v1alpha1.AppProject, error) {
+		return m.appclientset.ArgoprojV1alpha1().AppProjects(m.namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	})
+}
+
+// errSyncWindowExclusivityGroups is returned by syncWindowsPreventSync if the sync window exclusivity groups cannot be
+// read from the settings, along with the evaluation of the windows without them
+var errSyncWindowExclusivityGroups = stderrors.New("failed to get the sync window exclusivity groups")
+
+// syncWindowsPreventSync returns whether the sync windows of the project prevent the application from being synced.
+// The active allow windows of the project are suppressed while an allow window of another project of the same sync
+// window exclusivity group has been active for longer, in which case the returned error gives the reason. If the
+// exclusivity groups cannot be read, the windows of the project are evaluated on their own and
+// errSyncWindowExclusivityGroups is returned, so that a broken setting does not block every sync.
+func syncWindowsPreventSync(app *v1alpha1.Application, proj *v1alpha1.AppProject, isManual bool, settingsMgr *settings.SettingsManager, getProject func(name string) (*v1alpha1.AppProject, error)) (bool, error) {
+	windows := proj.Spec.SyncWindows.Matches(app)
+	canSync, err := windows.CanSync(isManual)
 	if err != nil {
 		// prevents sync because sync window has an error
 		return true, err
 	}
-	return !canSync, nil
+	if !canSync {
+		return true, nil
+	}
+
+	groups, err := settingsMgr.GetSyncWindowExclusivityGroups()
+	if err != nil {
+		return false, fmt.Errorf("%w, the sync windows are evaluated without them: %w", errSyncWindowExclusivityGroups, err)
+	}
+	activeAllows, since, err := activeAllowWindows(windows)
+	if err != nil || since == nil {
+		return err != nil, err
+	}
+	for _, group := range groups {
+		if !group.Contains(proj.Name) {
+			continue
+		}
+		for _, other := range group.Projects {
+			if other == proj.Name {
+				continue
+			}
+			otherProj, err := getProject(other)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return true, fmt.Errorf("error getting project %s of sync window exclusivity group %s: %w", other, group.Name, err)
+			}
+			_, otherSince, err := activeAllowWindows(&otherProj.Spec.SyncWindows)
+			if err != nil {
+				return true, fmt.Errorf("invalid sync windows of project %s: %w", other, err)
+			}
+			// the window which became active first holds the group, project names break ties
+			if otherSince == nil || otherSince.After(*since) || (otherSince.Equal(*since) && other > proj.Name) {
+				continue
+			}
+			if isManual && activeAllows.manualEnabled() {
+				return false, nil
+			}
+			return true, fmt.Errorf("allow window suppressed by the active window of project %s in exclusivity group %s", other, group.Name)
+		}
+	}
+	return false, nil
+}
+
+// activeAllowWindows returns the active allow windows and when the earliest of them became active
+func activeAllowWindows(windows *v1alpha1.SyncWindows) (syncWindowList, *time.Time, error) {
+	if !windows.HasWindows() {
+		return nil, nil, nil
+	}
+	var active syncWindowList
+	var earliest *time.Time
+	for _, w := range *windows {
+		if w.Kind != "allow" {
+			continue
+		}
+		since, err := w.ActiveSince()
+		if err != nil {
+			return nil, nil, err
+		}
+		if since == nil {
+			continue
+		}
+		active = append(active, w)
+		if earliest == nil || since.Before(*earliest) {
+			earliest = since
+		}
+	}
+	return active, earliest, nil
+}
+
+type syncWindowList []*v1alpha1.SyncWindow
+
+// manualEnabled returns whether all the windows allow manual syncs
+func (l syncWindowList) manualEnabled() bool {
+	for _, w := range l {
+		if !w.ManualSync {
+			return false
+		}
+	}
+	return len(l) > 0
 }
 
 // deriveServiceAccountToImpersonate determines the service account to be used for impersonation for the sync operation.
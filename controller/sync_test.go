@@ -279,6 +279,78 @@ func TestAppStateManager_SyncAppState(t *testing.T) {
 	})
 }
 
+func TestSyncResultForRetry(t *testing.T) {
+	newState := func(syncOptions v1alpha1.SyncOptions) *v1alpha1.OperationState {
+		return &v1alpha1.OperationState{
+			Operation: v1alpha1.Operation{
+				Sync:  &v1alpha1.SyncOperation{SyncOptions: syncOptions},
+				Retry: v1alpha1.RetryStrategy{Limit: 1},
+			},
+			SyncResult: &v1alpha1.SyncOperationResult{
+				Revision: "abc123",
+				Resources: []*v1alpha1.ResourceResult{{
+					Kind:      "ConfigMap",
+					Name:      "applied",
+					Status:    synccommon.ResultCodeSynced,
+					HookPhase: synccommon.OperationRunning,
+					SyncPhase: synccommon.SyncPhaseSync,
+				}, {
+					Kind:      "ConfigMap",
+					Name:      "failed",
+					Status:    synccommon.ResultCodeSyncFailed,
+					HookPhase: synccommon.OperationFailed,
+					SyncPhase: synccommon.SyncPhaseSync,
+				}, {
+					Kind:      "Pod",
+					Name:      "hook-succeeded",
+					HookType:  synccommon.HookTypePreSync,
+					Status:    synccommon.ResultCodeSynced,
+					HookPhase: synccommon.OperationSucceeded,
+					SyncPhase: synccommon.SyncPhasePreSync,
+				}, {
+					Kind:      "Pod",
+					Name:      "hook-failed",
+					HookType:  synccommon.HookTypePostSync,
+					Status:    synccommon.ResultCodeSynced,
+					HookPhase: synccommon.OperationFailed,
+					SyncPhase: synccommon.SyncPhasePostSync,
+				}},
+			},
+		}
+	}
+
+	t.Run("KeepsSuccessfulResources", func(t *testing.T) {
+		state := newState(v1alpha1.SyncOptions{"RetryOnlyFailed=true"})
+		result := syncResultForRetry(state)
+		require.NotNil(t, result)
+		assert.Equal(t, "abc123", result.Revision)
+		var names []string
+		for _, res := range result.Resources {
+			names = append(names, res.Name)
+		}
+		// only the failed resources are applied again by the retry
+		assert.Equal(t, []string{"applied", "hook-succeeded"}, names)
+		// the results of the previous attempt are not modified
+		assert.Len(t, state.SyncResult.Resources, 4)
+	})
+
+	t.Run("WithoutOption", func(t *testing.T) {
+		assert.Nil(t, syncResultForRetry(newState(nil)))
+	})
+
+	t.Run("WithRefresh", func(t *testing.T) {
+		state := newState(v1alpha1.SyncOptions{"RetryOnlyFailed=true"})
+		state.Operation.Retry.Refresh = true
+		assert.Nil(t, syncResultForRetry(state))
+	})
+
+	t.Run("NothingSucceeded", func(t *testing.T) {
+		state := newState(v1alpha1.SyncOptions{"RetryOnlyFailed=true"})
+		state.SyncResult.Resources = state.SyncResult.Resources[1:2]
+		assert.Nil(t, syncResultForRetry(state))
+	})
+}
+
 func TestSyncWindowDeniesSync(t *testing.T) {
 	t.Parallel()
 
@@ -351,6 +423,73 @@ func TestSyncWindowDeniesSync(t *testing.T) {
 	})
 }
 
+func TestSyncWindowExclusivityGroups(t *testing.T) {
+	newProject := func(name, duration string, manualSync bool) *v1alpha1.AppProject {
+		return &v1alpha1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{Namespace: test.FakeArgoCDNamespace, Name: name},
+			Spec: v1alpha1.AppProjectSpec{
+				SyncWindows: v1alpha1.SyncWindows{{
+					Kind:         "allow",
+					Schedule:     "* * * * *",
+					Duration:     duration,
+					Applications: []string{"*"},
+					ManualSync:   manualSync,
+				}},
+			},
+		}
+	}
+	// the window of team-a became active about an hour ago, the window of team-b at most half an hour ago
+	teamA := newProject("team-a", "1h", false)
+	teamB := newProject("team-b", "30m", true)
+	newManager := func(t *testing.T, groups string) *appStateManager {
+		t.Helper()
+		ctrl := newFakeController(t.Context(), &fakeData{
+			apps:            []runtime.Object{teamA, teamB},
+			managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+			configMapData:   map[string]string{"syncWindows.exclusivityGroups": groups},
+		}, nil)
+		return ctrl.appStateManager.(*appStateManager)
+	}
+	groups := `[{name: maintenance, projects: [team-a, team-b]}]`
+	newApp := func(project string, automated bool) *v1alpha1.Application {
+		app := newFakeApp()
+		app.Spec.Project = project
+		app.Status.OperationState = &v1alpha1.OperationState{Operation: v1alpha1.Operation{InitiatedBy: v1alpha1.OperationInitiator{Automated: automated}}}
+		return app
+	}
+
+	t.Run("Suppressed", func(t *testing.T) {
+		blocked, err := newManager(t, groups).syncWindowPreventsSync(newApp("team-b", true), teamB)
+		assert.True(t, blocked)
+		require.ErrorContains(t, err, "project team-a in exclusivity group maintenance")
+	})
+
+	t.Run("Holder", func(t *testing.T) {
+		blocked, err := newManager(t, groups).syncWindowPreventsSync(newApp("team-a", true), teamA)
+		require.NoError(t, err)
+		assert.False(t, blocked)
+	})
+
+	t.Run("NoGroup", func(t *testing.T) {
+		blocked, err := newManager(t, "").syncWindowPreventsSync(newApp("team-b", true), teamB)
+		require.NoError(t, err)
+		assert.False(t, blocked)
+	})
+
+	t.Run("ManualSync", func(t *testing.T) {
+		blocked, err := newManager(t, groups).syncWindowPreventsSync(newApp("team-b", false), teamB)
+		require.NoError(t, err)
+		assert.False(t, blocked)
+	})
+
+	t.Run("InvalidGroups", func(t *testing.T) {
+		// a group needs at least two projects, the windows of team-b are then evaluated on their own
+		blocked, err := newManager(t, `[{name: maintenance, projects: [team-b]}]`).syncWindowPreventsSync(newApp("team-b", true), teamB)
+		require.ErrorIs(t, err, errSyncWindowExclusivityGroups)
+		assert.False(t, blocked)
+	})
+}
+
 func TestNormalizeTargetResources(t *testing.T) {
 	type fixture struct {
 		comparisonResult *comparisonResult
@@ -16,6 +16,8 @@ const (
 	AnnotationKeyHook = "argocd.argoproj.io/hook"
 	// AnnotationKeyHookDeletePolicy is the policy of deleting a hook
 	AnnotationKeyHookDeletePolicy = "argocd.argoproj.io/hook-delete-policy"
+	// AnnotationKeyHookCondition is the condition under which a hook runs
+	AnnotationKeyHookCondition = "argocd.argoproj.io/hook-condition"
 	AnnotationDeletionApproved    = "argocd.argoproj.io/deletion-approved"
 
 	// Sync option that disables dry run in resource is missing in the cluster
@@ -144,6 +146,14 @@ func NewHookDeletePolicy(p string) (HookDeletePolicy, bool) {
 			p == string(HookDeletePolicyBeforeHookCreation)
 }
 
+type HookCondition string
+
+const (
+	// HookConditionPreviousPhaseChanged runs the hook only if the resources of the previous phase were changed, and
+	// skips it if they were all unchanged
+	HookConditionPreviousPhaseChanged HookCondition = "PreviousPhaseChanged"
+)
+
 type ResourceSyncResult struct {
 	// holds associated resource key
 	ResourceKey kube.ResourceKey
@@ -562,6 +562,8 @@ func (sc *syncContext) Sync() {
 		return
 	}
 
+	completedTasks := tasks.Filter(func(t *syncTask) bool { return t.completed() })
+
 	sc.log.WithValues("tasks", tasks).V(1).Info("Filtering out non-pending tasks")
 	// remove tasks that are completed, we can assume that there are no running tasks
 	tasks = tasks.Filter(func(t *syncTask) bool { return t.pending() })
@@ -570,6 +572,10 @@ func (sc *syncContext) Sync() {
 		tasks = sc.filterOutOfSyncTasks(tasks)
 	}
 
+	if len(tasks) > 0 {
+		tasks = sc.skipConditionalHooks(tasks, completedTasks)
+	}
+
 	// If no sync tasks were generated (e.g., in case all application manifests have been removed),
 	// the sync operation is successful.
 	if len(tasks) == 0 {
@@ -643,6 +649,39 @@ func (sc *syncContext) Sync() {
 	}
 }
 
+// skipConditionalHooks skips the pending hooks of the current phase which only run if the resources of the previous
+// phase were changed, if none of them were, and returns the remaining tasks
+func (sc *syncContext) skipConditionalHooks(tasks syncTasks, completedTasks syncTasks) syncTasks {
+	phase := tasks.phase()
+	previous, ok := previousSyncPhase(phase)
+	if !ok {
+		return tasks
+	}
+	conditional, tasks := tasks.Split(func(t *syncTask) bool { return t.phase == phase && t.runsOnPreviousPhaseChanged() })
+	if len(conditional) == 0 {
+		return tasks
+	}
+	if completedTasks.Any(func(t *syncTask) bool { return t.phase == previous && t.changed() }) {
+		return append(tasks, conditional...)
+	}
+	for _, task := range conditional {
+		sc.log.WithValues("task", task, "previousPhase", previous).Info("Skipping hook, the resources of the previous phase were unchanged")
+		sc.setResourceResult(task, common.ResultCodeSynced, common.OperationSucceeded, fmt.Sprintf("Skipped, no resources of the %s phase were changed", previous))
+	}
+	return tasks
+}
+
+// previousSyncPhase returns the phase which runs right before the given one, if any
+func previousSyncPhase(phase common.SyncPhase) (common.SyncPhase, bool) {
+	switch phase {
+	case common.SyncPhaseSync:
+		return common.SyncPhasePreSync, true
+	case common.SyncPhasePostSync:
+		return common.SyncPhaseSync, true
+	}
+	return "", false
+}
+
 // Terminate terminates sync operation. The method is asynchronous: it starts deletion is related K8S resources
 // such as in-flight resource hooks, updates operation status, and exists without waiting for resource completion.
 func (sc *syncContext) Terminate() {
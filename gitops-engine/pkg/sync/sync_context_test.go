@@ -1548,6 +1548,87 @@ func TestSync_FailedSyncWithSyncFailHook_ApplyFailed(t *testing.T) {
 	assert.Equal(t, synccommon.ResultCodeSynced, successfulSyncFailHookResult.Status)
 }
 
+// a sync resumed with the results of resources which were successfully synced, e.g. the retry of a failed sync which
+// only applies the failed resources, does not apply them again
+func TestSync_ResumedSyncSkipsSyncedResources(t *testing.T) {
+	pod := testingutils.NewPod()
+	pod.SetNamespace(testingutils.FakeArgoCDNamespace)
+	service := testingutils.NewService()
+	service.SetNamespace(testingutils.FakeArgoCDNamespace)
+	syncCtx := newTestSyncCtx(nil,
+		WithInitialState(synccommon.OperationRunning, "", []synccommon.ResourceSyncResult{{
+			ResourceKey: kube.GetResourceKey(pod),
+			HookPhase:   synccommon.OperationRunning,
+			Status:      synccommon.ResultCodeSynced,
+			SyncPhase:   synccommon.SyncPhaseSync,
+			Order:       1,
+		}}, metav1.Now()))
+	syncCtx.resources = groupResources(ReconciliationResult{
+		Live:   []*unstructured.Unstructured{nil, nil},
+		Target: []*unstructured.Unstructured{pod, service},
+	})
+
+	syncCtx.Sync()
+
+	phase, _, resources := syncCtx.GetState()
+	assert.Equal(t, synccommon.OperationSucceeded, phase)
+	assert.Len(t, resources, 2)
+	resourceOps, _ := syncCtx.resourceOps.(*kubetest.MockResourceOps)
+	assert.Empty(t, resourceOps.GetLastResourceCommand(kube.GetResourceKey(pod)))
+	assert.Equal(t, "apply", resourceOps.GetLastResourceCommand(kube.GetResourceKey(service)))
+}
+
+func TestSync_HookConditionPreviousPhaseChanged(t *testing.T) {
+	newSyncCtx := func(message string) (*syncContext, *unstructured.Unstructured) {
+		service := testingutils.NewService()
+		service.SetNamespace(testingutils.FakeArgoCDNamespace)
+		postSync := newHook("post-sync", synccommon.HookTypePostSync, synccommon.HookDeletePolicyBeforeHookCreation)
+		testingutils.Annotate(postSync, synccommon.AnnotationKeyHookCondition, string(synccommon.HookConditionPreviousPhaseChanged))
+		syncCtx := newTestSyncCtx(nil,
+			WithInitialState(synccommon.OperationRunning, "", []synccommon.ResourceSyncResult{{
+				ResourceKey: kube.GetResourceKey(service),
+				HookPhase:   synccommon.OperationSucceeded,
+				Status:      synccommon.ResultCodeSynced,
+				SyncPhase:   synccommon.SyncPhaseSync,
+				Message:     message,
+				Order:       1,
+			}}, metav1.Now()))
+		syncCtx.resources = groupResources(ReconciliationResult{
+			Live:   []*unstructured.Unstructured{service},
+			Target: []*unstructured.Unstructured{service},
+		})
+		syncCtx.hooks = []*unstructured.Unstructured{postSync}
+		return syncCtx, postSync
+	}
+
+	t.Run("Unchanged", func(t *testing.T) {
+		syncCtx, postSync := newSyncCtx("service/my-service unchanged")
+		syncCtx.Sync()
+
+		phase, message, resources := syncCtx.GetState()
+		assert.Equal(t, synccommon.OperationSucceeded, phase)
+		assert.Equal(t, "successfully synced (no more tasks)", message)
+		require.Len(t, resources, 2)
+		assert.Equal(t, synccommon.HookTypePostSync, resources[1].HookType)
+		assert.Equal(t, synccommon.OperationSucceeded, resources[1].HookPhase)
+		assert.Equal(t, "Skipped, no resources of the Sync phase were changed", resources[1].Message)
+		resourceOps, _ := syncCtx.resourceOps.(*kubetest.MockResourceOps)
+		assert.Empty(t, resourceOps.GetLastResourceCommand(kube.GetResourceKey(postSync)))
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		syncCtx, postSync := newSyncCtx("service/my-service configured")
+		syncCtx.Sync()
+
+		phase, _, resources := syncCtx.GetState()
+		assert.Equal(t, synccommon.OperationRunning, phase)
+		require.Len(t, resources, 2)
+		assert.Equal(t, synccommon.OperationRunning, resources[1].HookPhase)
+		resourceOps, _ := syncCtx.resourceOps.(*kubetest.MockResourceOps)
+		assert.Equal(t, "apply", resourceOps.GetLastResourceCommand(kube.GetResourceKey(postSync)))
+	})
+}
+
 func TestSync_HooksNotDeletedIfPhaseNotCompleted(t *testing.T) {
 	hook1 := newHook("hook-1", synccommon.HookTypePreSync, synccommon.HookDeletePolicyBeforeHookCreation)
 	hook2 := newHook("hook-2", synccommon.HookTypePreSync, synccommon.HookDeletePolicyHookFailed)
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -135,6 +136,21 @@ func (t *syncTask) deleteBeforeCreation() bool {
 	return t.liveObj != nil && t.pending() && t.hasHookDeletePolicy(common.HookDeletePolicyBeforeHookCreation)
 }
 
+// runsOnPreviousPhaseChanged returns whether the hook only runs if the resources of the previous phase were changed
+func (t *syncTask) runsOnPreviousPhaseChanged() bool {
+	return t.isHook() && t.obj().GetAnnotations()[common.AnnotationKeyHookCondition] == string(common.HookConditionPreviousPhaseChanged)
+}
+
+// changed returns whether the completed resource task changed the live resource. The apply output of unchanged
+// resources ends with "unchanged", any other output, e.g. the message of the health of the resource once it replaced
+// the apply output, is assumed to be a change.
+func (t *syncTask) changed() bool {
+	if t.isHook() || !t.completed() {
+		return false
+	}
+	return t.pruned() || (t.syncStatus == common.ResultCodeSynced && !strings.HasSuffix(t.message, " unchanged"))
+}
+
 func (t *syncTask) deleteOnPhaseCompletion() bool {
 	return t.deleteOnPhaseFailed() || t.deleteOnPhaseSuccessful()
 }
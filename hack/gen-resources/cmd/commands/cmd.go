@@ -2,11 +2,23 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	generator "github.com/argoproj/argo-cd/v3/hack/gen-resources/generators"
+	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-cd/v3/util/db"
 	"github.com/argoproj/argo-cd/v3/util/settings"
 
@@ -43,89 +55,264 @@ func NewCommand() *cobra.Command {
 
 	command.AddCommand(NewGenerateCommand(&generateOpts))
 	command.AddCommand(NewCleanCommand(&generateOpts))
+	command.PersistentFlags().StringVar(&cmdutil.LogLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
+	command.PersistentFlags().StringVar(&cmdutil.LogFormat, "logformat", "text", "Set the logging format. One of: text|json")
 
 	return command
 }
 
 func NewGenerateCommand(opts *util.GenerateOpts) *cobra.Command {
-	var file string
+	var (
+		file       string
+		output     string
+		reportPath string
+		runID      string
+		dryRun     bool
+		overrides  []string
+		include    []string
+		exclude    []string
+	)
 	command := &cobra.Command{
-		Use:   "generate [-f file]",
+		Use:   "generate -f file [--set key=value]...",
 		Short: "Generate entities",
 		Long:  "Generate entities",
-		Run: func(_ *cobra.Command, _ []string) {
+		Run: func(c *cobra.Command, _ []string) {
+			// the generation stops launching new objects once interrupted
+			ctx, stop := signal.NotifyContext(c.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
 			log.Printf("Retrieve configuration from %s", file)
-			err := util.Parse(opts, file)
+			err := util.Parse(opts, file, overrides...)
 			if err != nil {
 				log.Fatalf("Failed to retrieve configuration, %v", err.Error())
 			}
-			argoClientSet := util.ConnectToK8sArgoClientSet()
-			clientSet := util.ConnectToK8sClientSet()
+			// the flags override the configuration
+			if c.Flags().Changed("report") {
+				opts.ReportPath = reportPath
+			}
+			if c.Flags().Changed("dry-run") {
+				opts.DryRun = dryRun
+			}
+			if c.Flags().Changed("run-id") {
+				opts.RunID = runID
+			}
+			if opts.RunID == "" {
+				opts.RunID = uuid.NewString()
+			}
+			log.Printf("Label the generated objects with run id %s, pass --run-id %s to clean to delete only them", opts.RunID, opts.RunID)
+			if c.Flags().Changed("only") {
+				opts.Include = include
+			}
+			if c.Flags().Changed("skip") {
+				opts.Exclude = exclude
+			}
+			log.Printf("Generate the random names with seed %d, set seed to the same value to reproduce them", util.SeedRandom(opts.Seed))
+			var argoClientSet appclientset.Interface = util.ConnectToK8sArgoClientSet()
+			var clientSet kubernetes.Interface = util.ConnectToK8sClientSet()
 
-			settingsMgr := settings.NewSettingsManager(context.TODO(), clientSet, opts.Namespace)
+			var listClientSets *util.ListClientSets
+			if output != "" || opts.DryRun {
+				// the objects are created in memory and printed, the vclusters are still installed in the cluster unless
+				// the run is a dry run
+				listClientSets = util.NewListClientSets(opts.Namespace)
+				argoClientSet = listClientSets.ArgoClientSet
+				clientSet = listClientSets.ClientSet
+				if opts.ApplicationOpts.DriftOpts.Samples > 0 {
+					log.Printf("Skip drift of applications, they are not synced when created in memory")
+					opts.ApplicationOpts.DriftOpts.Samples = 0
+				}
+				if len(opts.Instances) > 0 {
+					log.Printf("Skip distributing clusters across instances, they are created in memory")
+					opts.Instances = nil
+				}
+				if opts.ApplicationOpts.VerifyOpts.TargetPercent > 0 {
+					log.Printf("Skip verification of applications, they are not synced when created in memory")
+					opts.ApplicationOpts.VerifyOpts.TargetPercent = 0
+				}
+			}
+
+			settingsMgr := settings.NewSettingsManager(ctx, clientSet, opts.Namespace)
 			argoDB := db.NewDB(opts.Namespace, settingsMgr, clientSet)
+			clusterDB := db.NewDB(opts.ClusterSecretsNamespace(), settingsMgr, clientSet)
 
-			pg := generator.NewProjectGenerator(argoClientSet)
-			ag := generator.NewApplicationGenerator(argoClientSet, clientSet)
-			rg := generator.NewRepoGenerator(util.ConnectToK8sClientSet())
-			cg := generator.NewClusterGenerator(argoDB, util.ConnectToK8sClientSet(), util.ConnectToK8sConfig())
+			registry := generator.NewRegistry()
+			registry.Register("project", "projects", generator.NewProjectGenerator(argoClientSet))
+			registry.Register("repository", "repositories", generator.NewRepoGenerator(clientSet), generator.NewRepositoryGenerator(argoDB, clientSet))
+			registry.Register("cluster", "clusters", generator.NewClusterGenerator(clusterDB, util.ConnectToK8sClientSet(), util.ConnectToK8sConfig(), newLogger(), clusterInstances(ctx, opts.Instances)...))
+			registry.Register("application", "applications", generator.NewApplicationGenerator(argoClientSet, clientSet))
+			registry.Register("applicationset", "applicationsets", generator.NewApplicationSetGenerator(argoClientSet, clientSet))
 
-			err = pg.Generate(opts)
-			if err != nil {
-				log.Fatalf("Failed to generate projects, %v", err.Error())
+			if opts.ReportPath != "" {
+				opts.Report = util.NewReport("generate")
 			}
-			err = rg.Generate(opts)
-			if err != nil {
-				log.Fatalf("Failed to generate repositories, %v", err.Error())
-			}
-			err = cg.Generate(opts)
-			if err != nil {
-				log.Fatalf("Failed to generate clusters, %v", err.Error())
+			for _, g := range selectGenerators(registry, opts) {
+				runPhase(ctx, opts, "generate", g.Phase, g.Generator.Generate)
 			}
-			err = ag.Generate(opts)
-			if err != nil {
-				log.Fatalf("Failed to generate applications, %v", err.Error())
+			writeReport(opts)
+			if output != "" {
+				err := listClientSets.Print(os.Stdout, opts.Namespace, output)
+				if err != nil {
+					log.Fatalf("Failed to print generated objects, %v", err.Error())
+				}
 			}
 		},
 	}
-	command.Flags().StringVarP(&file, "file", "f", "", "")
+	command.Flags().StringVarP(&file, "file", "f", "", "YAML file of the generation options, see examples/gen_resources.yaml")
+	command.Flags().StringArrayVar(&overrides, "set", nil, "Override an option of the file, by the dotted path of its key, e.g. --set cluster.samples=10")
+	command.Flags().StringVar(&reportPath, "report", "", "Write a JSON report of the generation to the file, overrides reportPath of the configuration")
+	command.Flags().StringVar(&runID, "run-id", "", "Label the generated clusters, namespaces and secrets with the run id, overrides runId of the configuration, defaults to a random UUID")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Log the vclusters and clusters the generation would install and create instead of creating them, overrides dryRun of the configuration")
+	command.Flags().StringSliceVar(&include, "only", nil, "Only run the given generators, e.g. --only=application, overrides include of the configuration. One or more of: project|repository|cluster|application|applicationset")
+	command.Flags().StringSliceVar(&exclude, "skip", nil, "Skip the given generators, overrides exclude of the configuration")
+	command.Flags().StringVarP(&output, "output", "o", "", "Print the generated objects as a v1/List instead of creating them, e.g. to pipe them to kubectl apply -f -. One of: yaml|json")
 	return command
 }
 
 func NewCleanCommand(opts *util.GenerateOpts) *cobra.Command {
+	var instanceFlags []string
 	command := &cobra.Command{
 		Use:   "clean",
 		Short: "Clean entities",
 		Long:  "Clean entities",
-		Run: func(_ *cobra.Command, _ []string) {
+		Run: func(c *cobra.Command, _ []string) {
+			ctx, stop := signal.NotifyContext(c.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if opts.RunID == "" && opts.CleanReportPath == "" {
+				log.Printf("WARNING: the clean is not scoped by --run-id, it deletes the generated objects of every run")
+			}
 			argoClientSet := util.ConnectToK8sArgoClientSet()
 			clientSet := util.ConnectToK8sClientSet()
-			settingsMgr := settings.NewSettingsManager(context.TODO(), clientSet, opts.Namespace)
+			settingsMgr := settings.NewSettingsManager(ctx, clientSet, opts.Namespace)
 			argoDB := db.NewDB(opts.Namespace, settingsMgr, clientSet)
 
-			pg := generator.NewProjectGenerator(argoClientSet)
-			ag := generator.NewApplicationGenerator(argoClientSet, clientSet)
-			cg := generator.NewClusterGenerator(argoDB, clientSet, util.ConnectToK8sConfig())
-			rg := generator.NewRepoGenerator(clientSet)
-
-			err := pg.Clean(opts)
+			instances, err := parseInstances(instanceFlags, opts.Namespace)
 			if err != nil {
-				log.Fatalf("Failed to clean projects, %v", err.Error())
+				log.Fatalf("Invalid instances, %v", err.Error())
 			}
-			err = ag.Clean(opts)
-			if err != nil {
-				log.Fatalf("Failed to clean applications, %v", err.Error())
-			}
-			err = cg.Clean(opts)
-			if err != nil {
-				log.Fatalf("Failed to clean clusters, %v", err.Error())
+			registry := generator.NewRegistry()
+			registry.Register("project", "projects", generator.NewProjectGenerator(argoClientSet))
+			registry.Register("applicationset", "applicationsets", generator.NewApplicationSetGenerator(argoClientSet, clientSet))
+			registry.Register("application", "applications", generator.NewApplicationGenerator(argoClientSet, clientSet))
+			registry.Register("cluster", "clusters", generator.NewClusterGenerator(db.NewDB(opts.ClusterSecretsNamespace(), settingsMgr, clientSet), clientSet, util.ConnectToK8sConfig(), newLogger(), clusterInstances(ctx, instances)...))
+			// the repositories registered through the db are deleted through it before their secrets are deleted
+			registry.Register("repository", "repositories", generator.NewRepositoryGenerator(argoDB, clientSet), generator.NewRepoGenerator(clientSet))
+
+			if opts.ReportPath != "" {
+				opts.Report = util.NewReport("clean")
 			}
-			err = rg.Clean(opts)
-			if err != nil {
-				log.Fatalf("Failed to clean repositores, %v", err.Error())
+			for _, g := range selectGenerators(registry, opts) {
+				runPhase(ctx, opts, "clean", g.Phase, g.Generator.Clean)
 			}
+			writeReport(opts)
 		},
 	}
 	command.PersistentFlags().StringVar(&opts.Namespace, "kube-namespace", "argocd", "Name of the namespace where argocd is running [$KUBE_NAMESPACE]")
+	command.Flags().StringVar(&opts.ReportPath, "report", "", "Write a JSON report of the clean to the file")
+	command.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Log the generated objects the clean would delete instead of deleting them")
+	command.Flags().StringVarP(&opts.CleanSelector, "selector", "l", "", "Only delete the generated objects matching the label selector")
+	command.Flags().StringVar(&opts.RunID, "run-id", "", "Only delete the objects generated by the run with the given run id, logged by generate")
+	command.Flags().BoolVar(&opts.CleanWait, "wait", false, "Wait until the deleted namespaces are gone before returning")
+	command.Flags().DurationVar(&opts.CleanWaitTimeout, "wait-timeout", 10*time.Minute, "How long to wait for the deleted namespaces to be gone with --wait")
+	command.Flags().StringVar(&opts.CleanReportPath, "from-report", "", "Delete exactly the clusters and vcluster namespaces listed in the JSON report of a generate run, instead of the generated ones")
+	command.Flags().StringSliceVar(&opts.Include, "only", nil, "Only clean the objects of the given generators. One or more of: project|repository|cluster|application|applicationset")
+	command.Flags().StringSliceVar(&opts.Exclude, "skip", nil, "Skip cleaning the objects of the given generators")
+	command.Flags().StringArrayVar(&instanceFlags, "instance", nil, "Delete the generated clusters of the Argo CD instance, as CONTEXT[/NAMESPACE] of the kubeconfig, instead of the one of --kube-namespace")
+	command.Flags().StringVar(&opts.ClusterOpts.ClusterSecretNamespace, "cluster-secret-namespace", "", "Namespace of the generated cluster secrets, clusterSecretNamespace of the configuration they were generated with, defaults to --kube-namespace")
+	command.Flags().StringVar(&opts.ClusterOpts.Backend, "backend", "vcluster", "Backend the clusters to delete were provisioned with, backend of the configuration they were generated with. One of: vcluster|gke")
+	command.Flags().StringVar(&opts.ClusterOpts.GKEOpts.Project, "gke-project", "", "Google Cloud project of the GKE clusters to delete with --backend=gke")
+	command.Flags().StringVar(&opts.ClusterOpts.PodPrefix, "pod-prefix", "vcluster", "Prefix of the vcluster releases to uninstall, podPrefix of the configuration they were generated with")
+	command.Flags().StringVar(&opts.ClusterOpts.NamespacePrefix, "namespace-prefix", "vcluster", "Prefix of the namespaces the vcluster releases to uninstall are in, namespacePrefix of the configuration they were generated with")
 	return command
 }
+
+// runPhase runs the phase of the command for the given kind of objects, and exits once the report of the run is written
+// if it fails
+func runPhase(ctx context.Context, opts *util.GenerateOpts, command, kind string, run func(ctx context.Context, opts *util.GenerateOpts) error) {
+	err := opts.Report.RunPhase(kind, func() error { return run(ctx, opts) })
+	if err != nil {
+		writeReport(opts)
+		log.Fatalf("Failed to %s %s, %v", command, kind, err.Error())
+	}
+}
+
+// newLogger returns the structured logger of the generators, writing to stderr at the level and in the format of the
+// --loglevel and --logformat flags
+func newLogger() *slog.Logger {
+	var level slog.Level
+	switch strings.ToLower(cmdutil.LogLevel) {
+	case "trace", "debug":
+		level = slog.LevelDebug
+	case "warn", "warning":
+		level = slog.LevelWarn
+	case "error", "fatal", "panic":
+		level = slog.LevelError
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	if strings.EqualFold(cmdutil.LogFormat, "json") {
+		return slog.New(slog.NewJSONHandler(os.Stderr, handlerOpts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, handlerOpts))
+}
+
+// selectGenerators returns the generators of the registry selected by the include and exclude filters of the options,
+// and exits if they name an unknown generator
+func selectGenerators(registry *generator.Registry, opts *util.GenerateOpts) []generator.RegisteredGenerator {
+	selected, skipped, err := registry.Select(opts.Include, opts.Exclude)
+	if err != nil {
+		log.Fatalf("Invalid generators, %v", err.Error())
+	}
+	var active []string
+	for _, g := range selected {
+		if !slices.Contains(active, g.Name) {
+			active = append(active, g.Name)
+		}
+	}
+	log.Printf("Run the generators %s", strings.Join(active, ", "))
+	if len(skipped) > 0 {
+		log.Printf("Skip the generators %s", strings.Join(skipped, ", "))
+	}
+	return selected
+}
+
+func writeReport(opts *util.GenerateOpts) {
+	if err := opts.Report.Write(opts.ReportPath); err != nil {
+		log.Printf("Failed to write report to %s, %v", opts.ReportPath, err.Error())
+	}
+}
+
+// parseInstances parses the CONTEXT[/NAMESPACE] instance flags, the namespace defaulting to the given one
+func parseInstances(flags []string, namespace string) ([]util.ArgoCDInstance, error) {
+	var instances []util.ArgoCDInstance
+	for _, flag := range flags {
+		kubeContext, instanceNamespace, _ := strings.Cut(flag, "/")
+		if kubeContext == "" {
+			return nil, fmt.Errorf("invalid instance %q, must be CONTEXT[/NAMESPACE]", flag)
+		}
+		if instanceNamespace == "" {
+			instanceNamespace = namespace
+		}
+		instances = append(instances, util.ArgoCDInstance{Context: kubeContext, Namespace: instanceNamespace})
+	}
+	return instances, nil
+}
+
+// clusterInstances connects to the given Argo CD instances the generated clusters are distributed across
+func clusterInstances(ctx context.Context, instances []util.ArgoCDInstance) []generator.ClusterInstance {
+	var clusterInstances []generator.ClusterInstance
+	for _, instance := range instances {
+		var config *rest.Config
+		if instance.Context == "" {
+			config = util.ConnectToK8sConfig()
+		} else {
+			config = util.ConnectToK8sConfigForContext(instance.Context)
+		}
+		clientSet := kubernetes.NewForConfigOrDie(config)
+		settingsMgr := settings.NewSettingsManager(ctx, clientSet, instance.Namespace)
+		clusterInstances = append(clusterInstances, generator.ClusterInstance{
+			Name:      instance.Context + "/" + instance.Namespace,
+			Namespace: instance.Namespace,
+			ClientSet: clientSet,
+			DB:        db.NewDB(instance.Namespace, settingsMgr, clientSet),
+		})
+	}
+	return clusterInstances
+}
@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+const (
+	// driftLabel marks the generated applications whose live state is changed
+	driftLabel = "argocd-generator/drift"
+	// driftReplicasAnnotation keeps the replicas of a Deployment before they were changed
+	driftReplicasAnnotation = "argocd-generator/original-replicas"
+	// driftConfigMapKey is the key added to the ConfigMaps of an application
+	driftConfigMapKey = "argocd-generator-drift"
+)
+
+func findCluster(destination v1alpha1.ApplicationDestination, clusters []v1alpha1.Cluster) (*v1alpha1.Cluster, error) {
+	for i := range clusters {
+		if (destination.Name != "" && clusters[i].Name == destination.Name) || (destination.Server != "" && clusters[i].Server == destination.Server) {
+			return &clusters[i], nil
+		}
+	}
+	return nil, fmt.Errorf("cluster of destination %q not found", destination)
+}
+
+func destinationClientSet(destination v1alpha1.ApplicationDestination, clusters []v1alpha1.Cluster) (*kubernetes.Clientset, error) {
+	cluster, err := findCluster(destination, clusters)
+	if err != nil {
+		return nil, err
+	}
+	config, err := cluster.RESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// waitForSync waits for the application to be synced and to report its resources
+func (generator *ApplicationGenerator) waitForSync(ctx context.Context, opts *util.GenerateOpts, name string) (*v1alpha1.Application, error) {
+	var app *v1alpha1.Application
+	timeout := time.Duration(opts.ApplicationOpts.DriftOpts.Timeout) * time.Second
+	err := wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		app, err = generator.argoClientSet.ArgoprojV1alpha1().Applications(opts.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return app.Status.Sync.Status == v1alpha1.SyncStatusCodeSynced && len(app.Status.Resources) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("application %s was not synced: %w", name, err)
+	}
+	return app, nil
+}
+
+// introduceDrift scales up the Deployments and edits the ConfigMaps of the given applications once they are synced, so
+// that the application controller has to detect and self-heal them
+func (generator *ApplicationGenerator) introduceDrift(ctx context.Context, opts *util.GenerateOpts, names []string, clusters []v1alpha1.Cluster) error {
+	drifted := 0
+	for _, name := range names {
+		app, err := generator.waitForSync(ctx, opts, name)
+		if err != nil {
+			return err
+		}
+		clientSet, err := destinationClientSet(app.Spec.Destination, clusters)
+		if err != nil {
+			return err
+		}
+		count, err := driftResources(ctx, clientSet, app)
+		if err != nil {
+			return fmt.Errorf("failed to introduce drift in application %s: %w", name, err)
+		}
+		log.Printf("Introduced drift in %d resources of application %s", count, name)
+		drifted += count
+	}
+	log.Printf("Introduced drift in %d resources of %d applications", drifted, len(names))
+	return nil
+}
+
+func driftResources(ctx context.Context, clientSet *kubernetes.Clientset, app *v1alpha1.Application) (int, error) {
+	count := 0
+	for _, res := range app.Status.Resources {
+		switch {
+		case res.Group == "apps" && res.Kind == "Deployment":
+			deployments := clientSet.AppsV1().Deployments(res.Namespace)
+			deployment, err := deployments.Get(ctx, res.Name, metav1.GetOptions{})
+			if err != nil {
+				return count, err
+			}
+			replicas := int32(1)
+			if deployment.Spec.Replicas != nil {
+				replicas = *deployment.Spec.Replicas
+			}
+			if deployment.Annotations == nil {
+				deployment.Annotations = map[string]string{}
+			}
+			if _, ok := deployment.Annotations[driftReplicasAnnotation]; !ok {
+				deployment.Annotations[driftReplicasAnnotation] = strconv.FormatInt(int64(replicas), 10)
+			}
+			replicas++
+			deployment.Spec.Replicas = &replicas
+			if _, err := deployments.Update(ctx, deployment, metav1.UpdateOptions{}); err != nil {
+				return count, err
+			}
+			count++
+		case res.Group == "" && res.Kind == "ConfigMap":
+			configMaps := clientSet.CoreV1().ConfigMaps(res.Namespace)
+			configMap, err := configMaps.Get(ctx, res.Name, metav1.GetOptions{})
+			if err != nil {
+				return count, err
+			}
+			if configMap.Data == nil {
+				configMap.Data = map[string]string{}
+			}
+			configMap.Data[driftConfigMapKey] = time.Now().Format(time.RFC3339)
+			if _, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// revertDrift restores the live state changed by introduceDrift in the resources of the given application, if it was
+// not already self-healed
+func revertDrift(ctx context.Context, clientSet *kubernetes.Clientset, app *v1alpha1.Application) (int, error) {
+	count := 0
+	for _, res := range app.Status.Resources {
+		switch {
+		case res.Group == "apps" && res.Kind == "Deployment":
+			deployments := clientSet.AppsV1().Deployments(res.Namespace)
+			deployment, err := deployments.Get(ctx, res.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return count, err
+			}
+			original, ok := deployment.Annotations[driftReplicasAnnotation]
+			if !ok {
+				continue
+			}
+			replicas, err := strconv.ParseInt(original, 10, 32)
+			if err != nil {
+				return count, fmt.Errorf("invalid annotation %s of deployment %s: %w", driftReplicasAnnotation, res.Name, err)
+			}
+			originalReplicas := int32(replicas)
+			deployment.Spec.Replicas = &originalReplicas
+			delete(deployment.Annotations, driftReplicasAnnotation)
+			if _, err := deployments.Update(ctx, deployment, metav1.UpdateOptions{}); err != nil {
+				return count, err
+			}
+			count++
+		case res.Group == "" && res.Kind == "ConfigMap":
+			configMaps := clientSet.CoreV1().ConfigMaps(res.Namespace)
+			configMap, err := configMaps.Get(ctx, res.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return count, err
+			}
+			if _, ok := configMap.Data[driftConfigMapKey]; !ok {
+				continue
+			}
+			delete(configMap.Data, driftConfigMapKey)
+			if _, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
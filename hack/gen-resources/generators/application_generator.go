@@ -2,8 +2,15 @@ package generator
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"maps"
 	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/argoproj/argo-cd/v3/util/settings"
@@ -20,11 +27,11 @@ import (
 )
 
 type ApplicationGenerator struct {
-	argoClientSet *appclientset.Clientset
-	clientSet     *kubernetes.Clientset
+	argoClientSet appclientset.Interface
+	clientSet     kubernetes.Interface
 }
 
-func NewApplicationGenerator(argoClientSet *appclientset.Clientset, clientSet *kubernetes.Clientset) Generator {
+func NewApplicationGenerator(argoClientSet appclientset.Interface, clientSet kubernetes.Interface) Generator {
 	return &ApplicationGenerator{argoClientSet, clientSet}
 }
 
@@ -38,8 +45,37 @@ func (generator *ApplicationGenerator) buildRandomSource(repositories []*v1alpha
 	}, nil
 }
 
-func (generator *ApplicationGenerator) buildSource(opts *util.GenerateOpts, repositories []*v1alpha1.Repository) (*v1alpha1.ApplicationSource, error) {
-	if opts.ApplicationOpts.SourceOpts.Strategy == "Random" {
+// monorepoPath returns the path with the given index in the monorepo
+func monorepoPath(opts *util.GenerateOpts, i int) string {
+	return strings.ReplaceAll(opts.ApplicationOpts.SourceOpts.MonorepoOpts.PathTemplate, "{{i}}", strconv.Itoa(i))
+}
+
+// buildMonorepoSource references the path of the application with the given index in the monorepo
+func (generator *ApplicationGenerator) buildMonorepoSource(opts *util.GenerateOpts, repositories []*v1alpha1.Repository, i int) (*v1alpha1.ApplicationSource, error) {
+	monorepoOpts := opts.ApplicationOpts.SourceOpts.MonorepoOpts
+	repoURL := monorepoOpts.RepoURL
+	if repoURL == "" {
+		if len(repositories) == 0 {
+			return nil, errors.New("no repository registered for the monorepo applications")
+		}
+		repoURL = repositories[0].Repo
+	}
+	paths := monorepoOpts.Paths
+	if paths <= 0 {
+		paths = opts.ApplicationOpts.Samples
+	}
+	return &v1alpha1.ApplicationSource{
+		RepoURL:        repoURL,
+		Path:           monorepoPath(opts, i%paths),
+		TargetRevision: monorepoOpts.TargetRevision,
+	}, nil
+}
+
+func (generator *ApplicationGenerator) buildSource(opts *util.GenerateOpts, repositories []*v1alpha1.Repository, i int) (*v1alpha1.ApplicationSource, error) {
+	switch opts.ApplicationOpts.SourceOpts.Strategy {
+	case "Monorepo":
+		return generator.buildMonorepoSource(opts, repositories, i)
+	case "Random":
 		return generator.buildRandomSource(repositories)
 	}
 	return generator.buildRandomSource(repositories)
@@ -49,43 +85,101 @@ func (generator *ApplicationGenerator) buildRandomDestination(opts *util.Generat
 	seed := rand.New(rand.NewSource(time.Now().Unix()))
 	clusterNumber := seed.Int() % len(clusters)
 	return &v1alpha1.ApplicationDestination{
-		Namespace: opts.Namespace,
+		Namespace: destinationNamespace(opts, &clusters[clusterNumber]),
 		Name:      clusters[clusterNumber].Name,
 	}, nil
 }
 
-func (generator *ApplicationGenerator) buildDestination(opts *util.GenerateOpts, clusters []v1alpha1.Cluster) (*v1alpha1.ApplicationDestination, error) {
-	if opts.ApplicationOpts.DestinationOpts.Strategy == "Random" {
+// destinationNamespace returns the namespace the applications deploy to in the given cluster, the first namespace the
+// cluster is restricted to, or DestinationNamespace for the cluster-scoped clusters. It defaults to the namespace of
+// Argo CD for compatibility with the clusters generated before the namespaces were separated.
+func destinationNamespace(opts *util.GenerateOpts, cluster *v1alpha1.Cluster) string {
+	if len(cluster.Namespaces) > 0 {
+		return cluster.Namespaces[0]
+	}
+	if opts.ClusterOpts.DestinationNamespace != "" {
+		return opts.ClusterOpts.DestinationNamespace
+	}
+	return opts.Namespace
+}
+
+// buildRoundRobinDestination targets the application with the given index at the generated clusters in turn, by
+// server
+func (generator *ApplicationGenerator) buildRoundRobinDestination(opts *util.GenerateOpts, clusters []v1alpha1.Cluster, i int) (*v1alpha1.ApplicationDestination, error) {
+	if len(clusters) == 0 {
+		return nil, errors.New("no generated cluster to target the applications at")
+	}
+	cluster := &clusters[i%len(clusters)]
+	return &v1alpha1.ApplicationDestination{
+		Namespace: destinationNamespace(opts, cluster),
+		Server:    cluster.Server,
+	}, nil
+}
+
+// generatedClusters returns the clusters created by the cluster generator, sorted by server so that the applications
+// are targeted at them in a stable order
+func generatedClusters(clusters []v1alpha1.Cluster) []v1alpha1.Cluster {
+	var generated []v1alpha1.Cluster
+	for _, cluster := range clusters {
+		if cluster.Labels[generatedByLabel] == labels[generatedByLabel] {
+			generated = append(generated, cluster)
+		}
+	}
+	slices.SortFunc(generated, func(a, b v1alpha1.Cluster) int { return strings.Compare(a.Server, b.Server) })
+	return generated
+}
+
+func (generator *ApplicationGenerator) buildDestination(opts *util.GenerateOpts, clusters []v1alpha1.Cluster, i int) (*v1alpha1.ApplicationDestination, error) {
+	switch opts.ApplicationOpts.DestinationOpts.Strategy {
+	case "RoundRobin":
+		return generator.buildRoundRobinDestination(opts, generatedClusters(clusters), i)
+	case "Random":
 		return generator.buildRandomDestination(opts, clusters)
 	}
 	return generator.buildRandomDestination(opts, clusters)
 }
 
-func (generator *ApplicationGenerator) Generate(opts *util.GenerateOpts) error {
-	settingsMgr := settings.NewSettingsManager(context.TODO(), generator.clientSet, opts.Namespace)
-	repositories, err := db.NewDB(opts.Namespace, settingsMgr, generator.clientSet).ListRepositories(context.TODO())
+func (generator *ApplicationGenerator) Generate(ctx context.Context, opts *util.GenerateOpts) error {
+	settingsMgr := settings.NewSettingsManager(ctx, generator.clientSet, opts.Namespace)
+	repositories, err := db.NewDB(opts.Namespace, settingsMgr, generator.clientSet).ListRepositories(ctx)
 	if err != nil {
 		return err
 	}
-	clusters, err := db.NewDB(opts.Namespace, settingsMgr, generator.clientSet).ListClusters(context.TODO())
+	clusters, err := db.NewDB(opts.Namespace, settingsMgr, generator.clientSet).ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+	statuses, err := newStatusDistributor(&opts.ApplicationOpts.StatusDistributionOpts, rand.New(rand.NewSource(time.Now().Unix())))
+	if err != nil {
+		return err
+	}
+	ignoreDifferences, err := newIgnoreDifferencesPicker(&opts.ApplicationOpts.IgnoreDifferencesOpts, rand.New(rand.NewSource(time.Now().Unix())))
 	if err != nil {
 		return err
 	}
 	applications := generator.argoClientSet.ArgoprojV1alpha1().Applications(opts.Namespace)
+	var drifted, generated []string
+	var lock sync.Mutex
+	var errs []error
+	paths := map[string]int{}
+	wg := util.New(opts.ApplicationOpts.Concurrency)
 	for i := 0; i < opts.ApplicationOpts.Samples; i++ {
 		log.Printf("Generate application #%v", i)
-		source, err := generator.buildSource(opts, repositories)
+		source, err := generator.buildSource(opts, repositories, i)
 		if err != nil {
 			return err
 		}
+		if i < opts.ApplicationOpts.PluginOpts.Samples {
+			applyPlugin(opts, source)
+		}
+		paths[source.Path]++
 		log.Printf("Pick source %q", source)
-		destination, err := generator.buildDestination(opts, clusters.Items)
+		destination, err := generator.buildDestination(opts, clusters.Items, i)
 		if err != nil {
 			return err
 		}
 		log.Printf("Pick destination %q", destination)
-		log.Printf("Create application")
-		_, err = applications.Create(context.TODO(), &v1alpha1.Application{
+		app := &v1alpha1.Application{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: "application-",
 				Namespace:    opts.Namespace,
@@ -96,18 +190,137 @@ func (generator *ApplicationGenerator) Generate(opts *util.GenerateOpts) error {
 				Destination: *destination,
 				Source:      source,
 			},
-		}, metav1.CreateOptions{})
-		if err != nil {
+		}
+		drift := i < opts.ApplicationOpts.DriftOpts.Samples
+		if drift {
+			// the drift is only corrected by the controller if self-heal is enabled
+			app.Labels = map[string]string{driftLabel: "true"}
+			maps.Copy(app.Labels, labels)
+			app.Spec.SyncPolicy = &v1alpha1.SyncPolicy{
+				Automated: &v1alpha1.SyncPolicyAutomated{SelfHeal: true},
+			}
+		}
+		if ignoreDifferences != nil && i < opts.ApplicationOpts.IgnoreDifferencesOpts.Samples {
+			ignoreDifferences.apply(app)
+		}
+		wg.Add()
+		go func() {
+			defer wg.Done()
+			log.Printf("Create application")
+			created, err := applications.Create(ctx, app, metav1.CreateOptions{})
+			if err != nil {
+				opts.Report.Failed("applications", err)
+				lock.Lock()
+				defer lock.Unlock()
+				errs = append(errs, err)
+				return
+			}
+			opts.Report.Created("applications", 1)
+			if !drift && statuses != nil {
+				err = statuses.apply(ctx, generator, opts, created)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			generated = append(generated, created.Name)
+			if drift {
+				drifted = append(drifted, created.Name)
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if statuses != nil {
+		statuses.report(opts.Report)
+	}
+	if ignoreDifferences != nil {
+		ignoreDifferences.report(opts.Report)
+	}
+	if plugins := min(opts.ApplicationOpts.PluginOpts.Samples, len(generated)); plugins > 0 {
+		log.Printf("Generated %d applications rendered by plugin %s", plugins, opts.ApplicationOpts.PluginOpts.Name)
+		opts.Report.Distribution("applications", "plugin", opts.ApplicationOpts.PluginOpts.Name, plugins)
+	}
+	if opts.ApplicationOpts.SourceOpts.Strategy == "Monorepo" {
+		logPathDistribution(paths)
+	}
+	if len(drifted) > 0 {
+		if err := generator.introduceDrift(ctx, opts, drifted, clusters.Items); err != nil {
 			return err
 		}
 	}
-	return nil
+	return generator.verify(ctx, opts, generated)
+}
+
+// logPathDistribution reports how many applications reference each path of the monorepo
+func logPathDistribution(paths map[string]int) {
+	distribution := map[int]int{}
+	for _, count := range paths {
+		distribution[count]++
+	}
+	counts := slices.Sorted(maps.Keys(distribution))
+	log.Printf("Generated applications reference %d distinct paths", len(paths))
+	for _, count := range counts {
+		log.Printf("%d paths are referenced by %d applications", distribution[count], count)
+	}
 }
 
-func (generator *ApplicationGenerator) Clean(opts *util.GenerateOpts) error {
+func (generator *ApplicationGenerator) Clean(ctx context.Context, opts *util.GenerateOpts) error {
 	log.Printf("Clean applications")
 	applications := generator.argoClientSet.ArgoprojV1alpha1().Applications(opts.Namespace)
-	return applications.DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{
-		LabelSelector: "app.kubernetes.io/generated-by=argocd-generator",
+	err := generator.cleanDrift(ctx, opts)
+	if err != nil {
+		return err
+	}
+	listOpts := metav1.ListOptions{LabelSelector: cleanSelector(opts)}
+	matched, err := applications.List(ctx, listOpts)
+	if err != nil {
+		return err
+	}
+	log.Printf("Delete %d applications matching %s", len(matched.Items), listOpts.LabelSelector)
+	if dryRunDelete(opts, "applications", objectNames(matched.Items)) {
+		return nil
+	}
+	if err := applications.DeleteCollection(ctx, metav1.DeleteOptions{}, listOpts); err != nil {
+		return err
+	}
+	opts.Report.Deleted("applications", len(matched.Items))
+	return nil
+}
+
+// cleanDrift reverts the drift introduced in the live state of the generated applications
+func (generator *ApplicationGenerator) cleanDrift(ctx context.Context, opts *util.GenerateOpts) error {
+	apps, err := generator.argoClientSet.ArgoprojV1alpha1().Applications(opts.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: cleanSelector(opts, driftLabel+"=true"),
 	})
+	if err != nil {
+		return err
+	}
+	if len(apps.Items) == 0 {
+		return nil
+	}
+	settingsMgr := settings.NewSettingsManager(ctx, generator.clientSet, opts.Namespace)
+	clusters, err := db.NewDB(opts.Namespace, settingsMgr, generator.clientSet).ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+	reverted := 0
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		clientSet, err := destinationClientSet(app.Spec.Destination, clusters.Items)
+		if err != nil {
+			log.Printf("Skip reverting drift of application %s, %v", app.Name, err)
+			continue
+		}
+		count, err := revertDrift(ctx, clientSet, app)
+		if err != nil {
+			return fmt.Errorf("failed to revert drift of application %s: %w", app.Name, err)
+		}
+		reverted += count
+	}
+	log.Printf("Reverted drift in %d resources of %d applications", reverted, len(apps.Items))
+	return nil
 }
@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestBuildRoundRobinDestination(t *testing.T) {
+	clusters := []argoappv1.Cluster{
+		{Server: "https://kubernetes.default.svc", Name: "in-cluster"},
+		{Server: "https://vcluster-b.vcluster-b.svc:443", Name: "test-b", Namespaces: []string{"apps"}, Labels: maps.Clone(labels)},
+		{Server: "https://vcluster-a.vcluster-a.svc:443", Name: "test-a", Namespaces: []string{"apps"}, Labels: maps.Clone(labels)},
+	}
+	opts := &util.GenerateOpts{ApplicationOpts: util.ApplicationOpts{DestinationOpts: util.DestinationOpts{Strategy: "RoundRobin"}}}
+	generator := &ApplicationGenerator{}
+
+	var servers []string
+	for i := 0; i < 4; i++ {
+		destination, err := generator.buildDestination(opts, clusters, i)
+		require.NoError(t, err)
+		assert.Equal(t, "apps", destination.Namespace)
+		servers = append(servers, destination.Server)
+	}
+	assert.Equal(t, []string{
+		"https://vcluster-a.vcluster-a.svc:443",
+		"https://vcluster-b.vcluster-b.svc:443",
+		"https://vcluster-a.vcluster-a.svc:443",
+		"https://vcluster-b.vcluster-b.svc:443",
+	}, servers)
+
+	_, err := generator.buildDestination(opts, clusters[:1], 0)
+	require.Error(t, err)
+}
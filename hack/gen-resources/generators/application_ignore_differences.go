@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"math/rand"
+	"slices"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// ignoreDifferencesTemplates is the library of ignoreDifferences rules attached to generated applications, keyed by
+// template name
+var ignoreDifferencesTemplates = map[string]v1alpha1.ResourceIgnoreDifferences{
+	"deployment-replicas": {
+		Group:        "apps",
+		Kind:         "Deployment",
+		JSONPointers: []string{"/spec/replicas"},
+	},
+	"service-cluster-ip": {
+		Kind:         "Service",
+		JSONPointers: []string{"/spec/clusterIP", "/spec/clusterIPs"},
+	},
+	"webhook-ca-bundle": {
+		Group:             "admissionregistration.k8s.io",
+		Kind:              "MutatingWebhookConfiguration",
+		JQPathExpressions: []string{".webhooks[]?.clientConfig.caBundle"},
+	},
+	"container-images": {
+		Group:             "apps",
+		Kind:              "Deployment",
+		JQPathExpressions: []string{".spec.template.spec.containers[] | select(.name == \"sidecar\") | .image"},
+	},
+	"hpa-managed-replicas": {
+		Group:                 "apps",
+		Kind:                  "Deployment",
+		ManagedFieldsManagers: []string{"kube-controller-manager"},
+	},
+	"configmap-managed-data": {
+		Kind:                  "ConfigMap",
+		ManagedFieldsManagers: []string{"kubectl-edit", "argocd-generator"},
+	},
+}
+
+// ignoreDifferencesRuleType returns the type of the rule, by how it selects the ignored fields
+func ignoreDifferencesRuleType(rule v1alpha1.ResourceIgnoreDifferences) string {
+	switch {
+	case len(rule.JQPathExpressions) > 0:
+		return "jqPathExpressions"
+	case len(rule.ManagedFieldsManagers) > 0:
+		return "managedFieldsManagers"
+	default:
+		return "jsonPointers"
+	}
+}
+
+// ignoreDifferencesPicker attaches ignoreDifferences rules drawn from the template library to generated applications,
+// and keeps track of the types of the attached rules
+type ignoreDifferencesPicker struct {
+	seed      *rand.Rand
+	templates []string
+	rules     int
+	counts    map[string]int
+}
+
+func newIgnoreDifferencesPicker(opts *util.IgnoreDifferencesOpts, seed *rand.Rand) (*ignoreDifferencesPicker, error) {
+	if opts.Samples <= 0 {
+		return nil, nil
+	}
+	templates := opts.Templates
+	if len(templates) == 0 {
+		templates = slices.Sorted(maps.Keys(ignoreDifferencesTemplates))
+	}
+	for _, name := range templates {
+		if _, ok := ignoreDifferencesTemplates[name]; !ok {
+			return nil, fmt.Errorf("unknown ignoreDifferences template %q", name)
+		}
+	}
+	rules := opts.Rules
+	if rules <= 0 || rules > len(templates) {
+		rules = len(templates)
+	}
+	return &ignoreDifferencesPicker{seed: seed, templates: templates, rules: rules, counts: map[string]int{}}, nil
+}
+
+// apply attaches distinct rules drawn at random from the templates to the application
+func (p *ignoreDifferencesPicker) apply(app *v1alpha1.Application) {
+	for _, n := range p.seed.Perm(len(p.templates))[:p.rules] {
+		rule := ignoreDifferencesTemplates[p.templates[n]]
+		app.Spec.IgnoreDifferences = append(app.Spec.IgnoreDifferences, rule)
+		p.counts[ignoreDifferencesRuleType(rule)]++
+	}
+}
+
+func (p *ignoreDifferencesPicker) report(report *util.Report) {
+	for _, ruleType := range slices.Sorted(maps.Keys(p.counts)) {
+		log.Printf("Attached %d ignoreDifferences rules with %s", p.counts[ruleType], ruleType)
+		report.Distribution("applications", "ignoreDifferences", ruleType, p.counts[ruleType])
+	}
+}
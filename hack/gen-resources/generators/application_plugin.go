@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// applyPlugin renders the given source through the config management plugin of the options, keeping its repository
+// and revision
+func applyPlugin(opts *util.GenerateOpts, source *v1alpha1.ApplicationSource) {
+	pluginOpts := opts.ApplicationOpts.PluginOpts
+	if pluginOpts.Path != "" {
+		source.Path = pluginOpts.Path
+	}
+	plugin := &v1alpha1.ApplicationSourcePlugin{Name: pluginOpts.Name}
+	for _, name := range slices.Sorted(maps.Keys(pluginOpts.Env)) {
+		plugin.Env = append(plugin.Env, &v1alpha1.EnvEntry{Name: name, Value: pluginOpts.Env[name]})
+	}
+	source.Plugin = plugin
+}
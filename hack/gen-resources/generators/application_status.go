@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"math/rand"
+	"slices"
+	"sync"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// weightedPicker picks values at random in proportion to their weights
+type weightedPicker struct {
+	values  []string
+	weights []int
+	total   int
+}
+
+func newWeightedPicker(weights map[string]int) *weightedPicker {
+	picker := &weightedPicker{}
+	// sorted so that the same seed picks the same values
+	for _, value := range slices.Sorted(maps.Keys(weights)) {
+		if weights[value] <= 0 {
+			continue
+		}
+		picker.values = append(picker.values, value)
+		picker.weights = append(picker.weights, weights[value])
+		picker.total += weights[value]
+	}
+	return picker
+}
+
+func (p *weightedPicker) pick(seed *rand.Rand) string {
+	if p.total == 0 {
+		return ""
+	}
+	n := seed.Intn(p.total)
+	for i, weight := range p.weights {
+		if n < weight {
+			return p.values[i]
+		}
+		n -= weight
+	}
+	return ""
+}
+
+func validateStatusDistribution(opts *util.StatusDistributionOpts) error {
+	for status := range opts.Sync {
+		switch v1alpha1.SyncStatusCode(status) {
+		case v1alpha1.SyncStatusCodeSynced, v1alpha1.SyncStatusCodeOutOfSync, v1alpha1.SyncStatusCodeUnknown:
+		default:
+			return fmt.Errorf("invalid sync status %q", status)
+		}
+	}
+	for status := range opts.Health {
+		switch health.HealthStatusCode(status) {
+		case health.HealthStatusHealthy, health.HealthStatusProgressing, health.HealthStatusDegraded, health.HealthStatusSuspended, health.HealthStatusMissing, health.HealthStatusUnknown:
+		default:
+			return fmt.Errorf("invalid health status %q", status)
+		}
+	}
+	return nil
+}
+
+// statusDistributor sets the synthetic status of generated applications, and keeps track of the achieved distribution
+type statusDistributor struct {
+	// lock guards the seed and the counts, the applications are created in parallel
+	lock   sync.Mutex
+	seed   *rand.Rand
+	sync   *weightedPicker
+	health *weightedPicker
+	counts map[string]int
+}
+
+func newStatusDistributor(opts *util.StatusDistributionOpts, seed *rand.Rand) (*statusDistributor, error) {
+	if len(opts.Sync) == 0 && len(opts.Health) == 0 {
+		return nil, nil
+	}
+	if err := validateStatusDistribution(opts); err != nil {
+		return nil, err
+	}
+	return &statusDistributor{
+		seed:   seed,
+		sync:   newWeightedPicker(opts.Sync),
+		health: newWeightedPicker(opts.Health),
+		counts: map[string]int{},
+	}, nil
+}
+
+// apply sets the synthetic status of the created application
+func (d *statusDistributor) apply(ctx context.Context, generator *ApplicationGenerator, opts *util.GenerateOpts, app *v1alpha1.Application) error {
+	d.lock.Lock()
+	syncStatus := v1alpha1.SyncStatusCode(d.sync.pick(d.seed))
+	healthStatus := health.HealthStatusCode(d.health.pick(d.seed))
+	d.lock.Unlock()
+	if syncStatus != "" {
+		app.Status.Sync = v1alpha1.SyncStatus{Status: syncStatus}
+	}
+	if healthStatus != "" {
+		app.Status.Health = v1alpha1.AppHealthStatus{Status: healthStatus}
+	}
+	_, err := generator.argoClientSet.ArgoprojV1alpha1().Applications(opts.Namespace).Update(ctx, app, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set status of application %s: %w", app.Name, err)
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.counts[fmt.Sprintf("%s/%s", app.Status.Sync.Status, app.Status.Health.Status)]++
+	return nil
+}
+
+// report logs the achieved distribution of the sync and health statuses
+func (d *statusDistributor) report(report *util.Report) {
+	total := 0
+	for _, count := range d.counts {
+		total += count
+	}
+	log.Printf("Set the status of %d applications", total)
+	for _, status := range slices.Sorted(maps.Keys(d.counts)) {
+		log.Printf("%s: %d (%.1f%%)", status, d.counts[status], float64(d.counts[status])*100/float64(total))
+		report.Distribution("applications", "status", status, d.counts[status])
+	}
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+// verify waits for the target percentage of the given applications to reach the desired sync and health status
+func (generator *ApplicationGenerator) verify(ctx context.Context, opts *util.GenerateOpts, names []string) error {
+	verifyOpts := opts.ApplicationOpts.VerifyOpts
+	if verifyOpts.TargetPercent <= 0 || len(names) == 0 {
+		return nil
+	}
+	generated := map[string]bool{}
+	for _, name := range names {
+		generated[name] = true
+	}
+	target := (len(names)*verifyOpts.TargetPercent + 99) / 100
+	log.Printf("Wait for %d of %d applications to be %s and %s", target, len(names), verifyOpts.SyncStatus, verifyOpts.HealthStatus)
+
+	started := time.Now()
+	reached := 0
+	timeout := time.Duration(verifyOpts.Timeout) * time.Second
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		apps, err := generator.argoClientSet.ArgoprojV1alpha1().Applications(opts.Namespace).List(ctx, metav1.ListOptions{LabelSelector: util.GeneratedBySelector})
+		if err != nil {
+			return false, err
+		}
+		reached = 0
+		for _, app := range apps.Items {
+			if generated[app.Name] && string(app.Status.Sync.Status) == verifyOpts.SyncStatus && string(app.Status.Health.Status) == verifyOpts.HealthStatus {
+				reached++
+			}
+		}
+		log.Printf("%d of %d applications are %s and %s", reached, len(names), verifyOpts.SyncStatus, verifyOpts.HealthStatus)
+		return reached >= target, nil
+	})
+	opts.Report.Distribution("applications", "verification", "reached", reached)
+	opts.Report.Distribution("applications", "verification", "notReached", len(names)-reached)
+	if err != nil {
+		return fmt.Errorf("%d of %d applications reached the desired state, %d were expected: %w", reached, len(names), target, err)
+	}
+	log.Printf("Verified %d of %d applications in %s", reached, len(names), time.Since(started).Round(time.Second))
+	return nil
+}
@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+type ApplicationSetGenerator struct {
+	argoClientSet appclientset.Interface
+	clientSet     kubernetes.Interface
+}
+
+func NewApplicationSetGenerator(argoClientSet appclientset.Interface, clientSet kubernetes.Interface) Generator {
+	return &ApplicationSetGenerator{argoClientSet, clientSet}
+}
+
+// buildListGenerator returns a list generator with FanOut elements, spread over the given clusters
+func buildListGenerator(opts *util.GenerateOpts, clusters []v1alpha1.Cluster) (*v1alpha1.ApplicationSetGenerator, error) {
+	if len(clusters) == 0 {
+		return nil, errors.New("no cluster registered for the applications of the list generator")
+	}
+	var elements []apiextensionsv1.JSON
+	for j := 0; j < opts.ApplicationSetOpts.FanOut; j++ {
+		cluster := &clusters[j%len(clusters)]
+		element, err := json.Marshal(map[string]string{
+			"index":     strconv.Itoa(j),
+			"cluster":   cluster.Name,
+			"namespace": destinationNamespace(opts, cluster),
+		})
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, apiextensionsv1.JSON{Raw: element})
+	}
+	return &v1alpha1.ApplicationSetGenerator{List: &v1alpha1.ListGenerator{Elements: elements}}, nil
+}
+
+// buildApplicationSet returns the ApplicationSet with the given name, whose generator is picked by the options
+func (generator *ApplicationSetGenerator) buildApplicationSet(opts *util.GenerateOpts, name string, i int, repositories []*v1alpha1.Repository, clusters []v1alpha1.Cluster) (*v1alpha1.ApplicationSet, error) {
+	appSetOpts := opts.ApplicationSetOpts
+	repoURL := appSetOpts.RepoURL
+	if repoURL == "" {
+		if len(repositories) == 0 {
+			return nil, errors.New("no repository registered for the applicationsets")
+		}
+		repoURL = repositories[i%len(repositories)].Repo
+	}
+	template := v1alpha1.ApplicationSetTemplate{
+		Spec: v1alpha1.ApplicationSpec{
+			Project: "default",
+			Source: &v1alpha1.ApplicationSource{
+				RepoURL:        repoURL,
+				Path:           "helm-guestbook",
+				TargetRevision: appSetOpts.TargetRevision,
+			},
+		},
+	}
+
+	var appSetGenerator *v1alpha1.ApplicationSetGenerator
+	switch appSetOpts.Generator {
+	case "List":
+		var err error
+		if appSetGenerator, err = buildListGenerator(opts, clusters); err != nil {
+			return nil, err
+		}
+		template.Name = name + "-{{.index}}"
+		template.Spec.Destination = v1alpha1.ApplicationDestination{Name: "{{.cluster}}", Namespace: "{{.namespace}}"}
+	case "Cluster":
+		// the fan-out is the number of generated clusters
+		appSetGenerator = &v1alpha1.ApplicationSetGenerator{Clusters: &v1alpha1.ClusterGenerator{
+			Selector: metav1.LabelSelector{MatchLabels: labels},
+		}}
+		template.Name = name + "-{{.name}}"
+		namespace := opts.ClusterOpts.DestinationNamespace
+		if namespace == "" {
+			namespace = opts.Namespace
+		}
+		template.Spec.Destination = v1alpha1.ApplicationDestination{Server: "{{.server}}", Namespace: namespace}
+	case "Git":
+		if len(clusters) == 0 {
+			return nil, errors.New("no cluster registered for the applications of the git generator")
+		}
+		// the fan-out is the number of the paths of the monorepo which exist in the repository
+		var directories []v1alpha1.GitDirectoryGeneratorItem
+		for j := 0; j < appSetOpts.FanOut; j++ {
+			directories = append(directories, v1alpha1.GitDirectoryGeneratorItem{Path: monorepoPath(opts, j)})
+		}
+		appSetGenerator = &v1alpha1.ApplicationSetGenerator{Git: &v1alpha1.GitGenerator{
+			RepoURL:     repoURL,
+			Revision:    appSetOpts.TargetRevision,
+			Directories: directories,
+		}}
+		template.Name = name + "-{{.path.basenameNormalized}}"
+		template.Spec.Source.Path = "{{.path.path}}"
+		cluster := &clusters[i%len(clusters)]
+		template.Spec.Destination = v1alpha1.ApplicationDestination{Name: cluster.Name, Namespace: destinationNamespace(opts, cluster)}
+	default:
+		return nil, fmt.Errorf("unknown applicationset generator %q, must be List, Cluster or Git", appSetOpts.Generator)
+	}
+
+	return &v1alpha1.ApplicationSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1alpha1.ApplicationSetSpec{
+			GoTemplate:        true,
+			GoTemplateOptions: []string{"missingkey=error"},
+			Generators:        []v1alpha1.ApplicationSetGenerator{*appSetGenerator},
+			Template:          template,
+		},
+	}, nil
+}
+
+func (generator *ApplicationSetGenerator) Generate(ctx context.Context, opts *util.GenerateOpts) error {
+	if opts.ApplicationSetOpts.Samples == 0 {
+		return nil
+	}
+	settingsMgr := settings.NewSettingsManager(ctx, generator.clientSet, opts.Namespace)
+	argoDB := db.NewDB(opts.Namespace, settingsMgr, generator.clientSet)
+	repositories, err := argoDB.ListRepositories(ctx)
+	if err != nil {
+		return err
+	}
+	clusters, err := argoDB.ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+	if opts.ApplicationSetOpts.Generator == "Cluster" {
+		log.Printf("The fan-out of the Cluster generator is the number of generated clusters, %d", len(clusters.Items))
+	}
+	appSets := generator.argoClientSet.ArgoprojV1alpha1().ApplicationSets(opts.Namespace)
+	for i := 0; i < opts.ApplicationSetOpts.Samples; i++ {
+		log.Printf("Generate applicationset #%v", i)
+		// the name is part of the names of the generated applications, so it cannot be generated by the API server
+		appSet, err := generator.buildApplicationSet(opts, "applicationset-"+util.GetRandomString(), i, repositories, clusters.Items)
+		if err != nil {
+			return err
+		}
+		if _, err := appSets.Create(ctx, appSet, metav1.CreateOptions{}); err != nil {
+			opts.Report.Failed("applicationsets", err)
+			return fmt.Errorf("failed to create applicationset %s: %w", appSet.Name, err)
+		}
+		opts.Report.Created("applicationsets", 1)
+	}
+	log.Printf("Generated %d applicationsets with the %s generator", opts.ApplicationSetOpts.Samples, opts.ApplicationSetOpts.Generator)
+	opts.Report.Distribution("applicationsets", "generator", opts.ApplicationSetOpts.Generator, opts.ApplicationSetOpts.Samples)
+	return nil
+}
+
+// Clean deletes the generated ApplicationSets, along with the applications they generated
+func (generator *ApplicationSetGenerator) Clean(ctx context.Context, opts *util.GenerateOpts) error {
+	log.Printf("Clean applicationsets")
+	appSets := generator.argoClientSet.ArgoprojV1alpha1().ApplicationSets(opts.Namespace)
+	listOpts := metav1.ListOptions{LabelSelector: cleanSelector(opts)}
+	matched, err := appSets.List(ctx, listOpts)
+	if err != nil {
+		return err
+	}
+	log.Printf("Delete %d applicationsets matching %s", len(matched.Items), listOpts.LabelSelector)
+	if dryRunDelete(opts, "applicationsets", objectNames(matched.Items)) {
+		return nil
+	}
+	if err := appSets.DeleteCollection(ctx, metav1.DeleteOptions{}, listOpts); err != nil {
+		return err
+	}
+	opts.Report.Deleted("applicationsets", len(matched.Items))
+	return nil
+}
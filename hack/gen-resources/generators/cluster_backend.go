@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+// ClusterBackend provisions the clusters registered by the ClusterGenerator. The registration and the labels of the
+// clusters do not depend on the backend.
+type ClusterBackend interface {
+	// Provision creates the cluster of the release
+	Provision(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error
+	// Credentials sets the server URI and the credentials the cluster of the release is registered with
+	Credentials(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error
+	// Teardown deletes the clusters provisioned by the generator, restricted by the clean selector and the run id
+	Teardown(ctx context.Context, opts *util.GenerateOpts) error
+}
+
+// clusterBackend returns the backend of the options the clusters are provisioned with, the one set on the generator
+// if any
+func (cg *ClusterGenerator) clusterBackend(opts *util.GenerateOpts) ClusterBackend {
+	if cg.backend != nil {
+		return cg.backend
+	}
+	if opts.ClusterOpts.Backend == "gke" {
+		return newGKEBackend(cg.log())
+	}
+	return &vclusterBackend{cg: cg}
+}
+
+// vclusterBackend installs a vcluster per cluster in the cluster of Argo CD
+type vclusterBackend struct {
+	cg *ClusterGenerator
+}
+
+func (b *vclusterBackend) Provision(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	return b.cg.install(ctx, opts, release)
+}
+
+func (b *vclusterBackend) Credentials(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	if err := b.cg.extract(ctx, opts, release); err != nil {
+		return err
+	}
+	release.credentials = vclusterTLSConfig(opts, release.index, release.credentials)
+	return nil
+}
+
+// Teardown deletes the network policies, the helm releases and the namespaces of the vclusters. The helm releases are
+// only uninstalled when the clean is not restricted.
+func (b *vclusterBackend) Teardown(ctx context.Context, opts *util.GenerateOpts) error {
+	if err := b.cg.cleanNetworkPolicies(ctx, opts); err != nil {
+		return err
+	}
+	if opts.RunID == "" && (opts.CleanSelector == "" || opts.CleanSelector == util.GeneratedBySelector) {
+		if err := b.cg.cleanHelmReleases(opts); err != nil {
+			return err
+		}
+	} else {
+		// the helm releases are not labeled, they cannot be told apart across generations and are deleted along with
+		// their namespace
+		b.cg.log().Info("Skip uninstalling vcluster releases, the clean is restricted", "selector", cleanSelector(opts))
+	}
+	return cleanNamespaces(ctx, b.cg.log(), opts, b.cg.clientSet)
+}
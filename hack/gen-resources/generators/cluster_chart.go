@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	"github.com/argoproj/argo-cd/v3/util/helm"
+)
+
+// isOCIChartRepo returns whether the vcluster chart is pulled from an OCI registry rather than a chart repository
+func isOCIChartRepo(opts *util.GenerateOpts) bool {
+	return strings.HasPrefix(opts.ClusterOpts.ChartRepo, "oci://")
+}
+
+// chartArgs returns the arguments of helm referencing the vcluster chart at its pinned version, by its OCI reference or
+// by its name in its chart repository along with the credentials of the repository
+func chartArgs(opts *util.GenerateOpts) []string {
+	clusterOpts := opts.ClusterOpts
+	var args []string
+	if isOCIChartRepo(opts) {
+		args = []string{strings.TrimSuffix(clusterOpts.ChartRepo, "/") + "/" + clusterOpts.ChartName}
+	} else {
+		args = []string{clusterOpts.ChartName, "--repo", clusterOpts.ChartRepo, "--repository-config", ""}
+		if clusterOpts.ChartUsername != "" {
+			args = append(args, "--username", clusterOpts.ChartUsername, "--password", clusterOpts.ChartPassword)
+		}
+	}
+	if clusterOpts.ChartVersion != "" {
+		args = append(args, "--version", clusterOpts.ChartVersion)
+	}
+	return args
+}
+
+// installArgs returns the arguments of the helm install of the vcluster release in the given namespace, with the values
+// file if any, the chart defaults applying otherwise
+func installArgs(opts *util.GenerateOpts, installNamespace string, releaseName string) []string {
+	args := append([]string{"upgrade", "--install", releaseName}, chartArgs(opts)...)
+	if opts.ClusterOpts.ValuesFilePath != "" {
+		args = append(args, "--values", opts.ClusterOpts.ValuesFilePath)
+	}
+	args = append(args, "--namespace", installNamespace, "--create-namespace", "--wait")
+	if opts.ClusterOpts.HelmTimeout > 0 {
+		args = append(args, "--timeout", opts.ClusterOpts.HelmTimeout.String())
+	}
+	return args
+}
+
+// chartCmd is a helm command run in its own working directory, so that the concurrent installs do not share the charts
+// they pull. The helm home of the command is its own too, both are removed when it is closed.
+type chartCmd struct {
+	*helm.Cmd
+	workDir string
+}
+
+func (c *chartCmd) Close() {
+	c.Cmd.Close()
+	_ = os.RemoveAll(c.workDir)
+}
+
+// newHelmCmd returns a helm command whose working directory is created in the given directory of the run, the default
+// temporary directory if empty
+func newHelmCmd(runDir string) (*chartCmd, error) {
+	workDir, err := os.MkdirTemp(runDir, "helm-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the working directory of helm: %w", err)
+	}
+	cmd, err := helm.NewCmd(workDir, "v3", "", "")
+	if err != nil {
+		_ = os.RemoveAll(workDir)
+		return nil, err
+	}
+	return &chartCmd{Cmd: cmd, workDir: workDir}, nil
+}
+
+// newChartCmd returns a helm command for the vcluster chart, logged in to its OCI registry if it has credentials, see
+// newHelmCmd. Each command has its own registry configuration, the login does not outlive it.
+func newChartCmd(opts *util.GenerateOpts, runDir string) (*chartCmd, error) {
+	cmd, err := newHelmCmd(runDir)
+	if err != nil {
+		return nil, err
+	}
+	if isOCIChartRepo(opts) && opts.ClusterOpts.ChartUsername != "" {
+		creds := helm.HelmCreds{Username: opts.ClusterOpts.ChartUsername, Password: opts.ClusterOpts.ChartPassword}
+		if _, err := cmd.RegistryLogin(opts.ClusterOpts.ChartRepo, creds); err != nil {
+			cmd.Close()
+			return nil, fmt.Errorf("failed to log in to the registry of the vcluster chart %s: %w", opts.ClusterOpts.ChartRepo, err)
+		}
+	}
+	return cmd, nil
+}
@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
+	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
+)
+
+const (
+	// syntheticConnectionStateAnnotation marks the generated clusters whose connection state is synthetic
+	syntheticConnectionStateAnnotation = "argocd-generator/synthetic-connection-state"
+	// syntheticMessagePrefix prefixes the message of the synthetic connection states, so that they are not mistaken for
+	// the state of a real probe
+	syntheticMessagePrefix = "[synthetic, set by argocd-generator]"
+)
+
+// connectionStateCache returns the cluster info cache of Argo CD the synthetic connection states are written to, or nil
+// if they are disabled
+func connectionStateCache(opts *util.GenerateOpts) *appstatecache.Cache {
+	stateOpts := opts.ClusterOpts.ConnectionStateOpts
+	if stateOpts.RedisAddress == "" {
+		return nil
+	}
+	client := redis.NewClient(&redis.Options{Addr: stateOpts.RedisAddress, Password: stateOpts.RedisPassword})
+	return appstatecache.NewCache(cacheutil.NewCache(cacheutil.NewRedisCache(client, time.Hour, cacheutil.RedisCompressionGZip)), time.Hour)
+}
+
+// syntheticFailed returns whether the cluster with the given index has a Failed connection state, spreading FailedPercent
+// of the clusters evenly
+func syntheticFailed(opts *util.GenerateOpts, i int) bool {
+	p := opts.ClusterOpts.ConnectionStateOpts.FailedPercent
+	return i*p/100 > (i-1)*p/100
+}
+
+// setSyntheticConnectionState writes the synthetic connection state and server version of the cluster with the given
+// index and server to the cluster info cache, and returns the status it was given
+func setSyntheticConnectionState(logger *slog.Logger, cache *appstatecache.Cache, opts *util.GenerateOpts, i int, server, version string) (argoappv1.ConnectionStatus, error) {
+	now := metav1.Now()
+	state := argoappv1.ConnectionState{
+		Status:     argoappv1.ConnectionStatusSuccessful,
+		Message:    syntheticMessagePrefix,
+		ModifiedAt: &now,
+	}
+	if syntheticFailed(opts, i) {
+		state.Status = argoappv1.ConnectionStatusFailed
+		state.Message = syntheticMessagePrefix + " " + opts.ClusterOpts.ConnectionStateOpts.FailedMessage
+	}
+	info := &argoappv1.ClusterInfo{ConnectionState: state, ServerVersion: version}
+	if err := cache.SetClusterInfo(server, info); err != nil {
+		return "", fmt.Errorf("failed to set the synthetic connection state of cluster %s: %w", server, err)
+	}
+	logger.Debug("Set synthetic connection state", "server", server, "status", state.Status)
+	return state.Status, nil
+}
+
+// verifyConnection requests /version of the cluster with the given server and credentials, and returns the connection
+// state of the cluster with the latency of the request, Failed with the error if the request fails
+func verifyConnection(ctx context.Context, server string, config argoappv1.ClusterConfig) (argoappv1.ConnectionState, time.Duration) {
+	started := time.Now()
+	err := requestVersion(ctx, server, config)
+	latency := time.Since(started)
+	now := metav1.Now()
+	if err != nil {
+		return argoappv1.ConnectionState{
+			Status:     argoappv1.ConnectionStatusFailed,
+			Message:    fmt.Sprintf("failed to get the version of the cluster after %s: %s", latency.Round(time.Millisecond), err.Error()),
+			ModifiedAt: &now,
+		}, latency
+	}
+	return argoappv1.ConnectionState{
+		Status:     argoappv1.ConnectionStatusSuccessful,
+		Message:    fmt.Sprintf("got the version of the cluster in %s", latency.Round(time.Millisecond)),
+		ModifiedAt: &now,
+	}, latency
+}
+
+func requestVersion(ctx context.Context, server string, config argoappv1.ClusterConfig) error {
+	clientSet, err := clusterClientSet(server, config)
+	if err != nil {
+		return fmt.Errorf("failed to build the client: %w", err)
+	}
+	return clientSet.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+}
+
+// verifyClusterConnection verifies the connection to the created cluster of the release if VerifyConnection is set, and
+// records its connection state in the cluster, the release and the report. A cluster which is not reachable is counted
+// but does not fail its generation.
+func (cg *ClusterGenerator) verifyClusterConnection(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease, cluster *argoappv1.Cluster) {
+	if !opts.ClusterOpts.VerifyConnection || opts.DryRun {
+		return
+	}
+	defer cg.stages.record("verify", time.Now())
+	state, latency := verifyConnection(ctx, cluster.Server, cluster.Config)
+	cluster.Info.ConnectionState = state
+	release.connectionState = &state
+	release.connectionLatency = latency
+	opts.Report.Distribution("clusters", "verifiedConnection", string(state.Status), 1)
+	if state.Status == argoappv1.ConnectionStatusFailed {
+		cg.log().Warn("Cluster is not reachable", "cluster", release.index, "server", cluster.Server, "message", state.Message)
+		return
+	}
+	cg.log().Info("Verified the connection to cluster", "cluster", release.index, "server", cluster.Server, "latency", latency.Round(time.Millisecond))
+}
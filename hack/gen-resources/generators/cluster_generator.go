@@ -4,67 +4,290 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"gopkg.in/yaml.v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/yaml"
 
+	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
 	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
 	"github.com/argoproj/argo-cd/v3/util/db"
-	"github.com/argoproj/argo-cd/v3/util/helm"
 )
 
-const POD_PREFIX = "vcluster"
+// errHelmTimeout is returned when the helm install of a vcluster does not complete within the HelmTimeout
+var errHelmTimeout = errors.New("helm install timed out")
 
-type Cluster struct {
-	Server                   string `yaml:"server"`
-	CertificateAuthorityData string `yaml:"certificate-authority-data,omitempty"`
+// defaultServerName is the name the certificates of vclusters are issued for
+const defaultServerName = "kubernetes.default.svc"
+
+// defaultServerVersion is the server version of the generated clusters if none is configured and the version of the
+// vclusters cannot be discovered
+const defaultServerVersion = "1.18"
+
+// serverVersionTimeout bounds the discovery of the server version of a vcluster, and each probe of its health
+const serverVersionTimeout = 10 * time.Second
+
+// readyAttempts is the number of probes of the health of a vcluster before it is reported not ready, see WaitForReady
+const readyAttempts = 8
+
+// describeKubeconfig returns the structure of the kubeconfig, with the size of the certificates, keys and tokens
+// instead of their data
+func describeKubeconfig(config *clientcmdapi.Config) string {
+	parts := []string{fmt.Sprintf("current-context %q", config.CurrentContext)}
+	for _, name := range slices.Sorted(maps.Keys(config.Contexts)) {
+		parts = append(parts, fmt.Sprintf("context %q cluster=%s user=%s", name, config.Contexts[name].Cluster, config.Contexts[name].AuthInfo))
+	}
+	for _, name := range slices.Sorted(maps.Keys(config.Clusters)) {
+		cluster := config.Clusters[name]
+		parts = append(parts, fmt.Sprintf("cluster %q server=%s certificate-authority-data=<%d bytes>", name, cluster.Server, len(cluster.CertificateAuthorityData)))
+	}
+	for _, name := range slices.Sorted(maps.Keys(config.AuthInfos)) {
+		authInfo := config.AuthInfos[name]
+		parts = append(parts, fmt.Sprintf("user %q client-certificate-data=<%d bytes> client-key-data=<%d bytes> token=<%d bytes>", name, len(authInfo.ClientCertificateData), len(authInfo.ClientKeyData), len(authInfo.Token)))
+	}
+	return strings.Join(parts, ", ")
 }
 
-type AuthInfo struct {
-	ClientCertificateData string `yaml:"client-certificate-data,omitempty"`
-	ClientKeyData         string `yaml:"client-key-data,omitempty"`
+// kubeconfigData are the base64 encoded fields of a kubeconfig, which clientcmd fails to decode without naming them
+type kubeconfigData struct {
+	Clusters []struct {
+		Name    string `json:"name"`
+		Cluster struct {
+			CertificateAuthorityData string `json:"certificate-authority-data"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	Users []struct {
+		Name string `json:"name"`
+		User struct {
+			ClientCertificateData string `json:"client-certificate-data"`
+			ClientKeyData         string `json:"client-key-data"`
+		} `json:"user"`
+	} `json:"users"`
 }
 
-type NamedCluster struct {
-	// Name is the nickname for this Cluster
-	Name string `yaml:"name"`
-	// Cluster holds the cluster information
-	Cluster Cluster `yaml:"cluster"`
+// kubeconfigDataError returns the error decoding the first base64 encoded field of the kubeconfig which is invalid,
+// naming the field and its cluster or user, nil if they are all valid
+func kubeconfigDataError(data []byte) error {
+	var kubeconfig kubeconfigData
+	if err := yaml.Unmarshal(data, &kubeconfig); err != nil {
+		return nil
+	}
+	type field struct{ name, value string }
+	var fields []field
+	for _, cluster := range kubeconfig.Clusters {
+		fields = append(fields, field{"certificate-authority-data of cluster " + cluster.Name, cluster.Cluster.CertificateAuthorityData})
+	}
+	for _, user := range kubeconfig.Users {
+		fields = append(fields,
+			field{"client-certificate-data of user " + user.Name, user.User.ClientCertificateData},
+			field{"client-key-data of user " + user.Name, user.User.ClientKeyData},
+		)
+	}
+	for _, field := range fields {
+		if _, err := base64.StdEncoding.DecodeString(field.value); err != nil {
+			return fmt.Errorf("decoding %s: %w", field.name, err)
+		}
+	}
+	return nil
 }
 
-type NamedAuthInfo struct {
-	// Name is the nickname for this AuthInfo
-	Name string `yaml:"name"`
-	// AuthInfo holds the auth information
-	AuthInfo AuthInfo `yaml:"user"`
+// parseKubeconfig parses the kubeconfig of a vcluster
+func parseKubeconfig(data []byte) (*clientcmdapi.Config, error) {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		if dataErr := kubeconfigDataError(data); dataErr != nil {
+			err = dataErr
+		}
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	return config, nil
 }
 
-type Config struct {
-	Clusters  []NamedCluster  `yaml:"clusters"`
-	AuthInfos []NamedAuthInfo `yaml:"users"`
+// kubeconfigCredentials returns the credentials of the cluster and user of the current context of the kubeconfig, or
+// of its only context if it has no current context. The user authenticates with its client certificate, or with its
+// bearer token if it has no certificate. The certificates read from files are not supported, the files are in the pod
+// of the vcluster.
+func kubeconfigCredentials(kubeconfig *clientcmdapi.Config) (argoappv1.ClusterConfig, error) {
+	var config argoappv1.ClusterConfig
+	contextName := kubeconfig.CurrentContext
+	if contextName == "" {
+		if len(kubeconfig.Contexts) != 1 {
+			return config, fmt.Errorf("kubeconfig has no current context and %d contexts", len(kubeconfig.Contexts))
+		}
+		contextName = slices.Collect(maps.Keys(kubeconfig.Contexts))[0]
+	}
+	kubeContext, ok := kubeconfig.Contexts[contextName]
+	if !ok {
+		return config, fmt.Errorf("context %s not found in kubeconfig", contextName)
+	}
+	cluster, ok := kubeconfig.Clusters[kubeContext.Cluster]
+	if !ok {
+		return config, fmt.Errorf("cluster %s of context %s not found in kubeconfig", kubeContext.Cluster, contextName)
+	}
+	authInfo, ok := kubeconfig.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return config, fmt.Errorf("user %s of context %s not found in kubeconfig", kubeContext.AuthInfo, contextName)
+	}
+	files := []struct{ field, path string }{
+		{"certificate-authority of cluster " + kubeContext.Cluster, cluster.CertificateAuthority},
+		{"client-certificate of user " + kubeContext.AuthInfo, authInfo.ClientCertificate},
+		{"client-key of user " + kubeContext.AuthInfo, authInfo.ClientKey},
+	}
+	for _, file := range files {
+		if file.path != "" {
+			return config, fmt.Errorf("%s references file %s, only inline data is supported", file.field, file.path)
+		}
+	}
+
+	config.CAData = cluster.CertificateAuthorityData
+	if len(authInfo.ClientCertificateData) == 0 && len(authInfo.ClientKeyData) == 0 && authInfo.Token != "" {
+		config.BearerToken = authInfo.Token
+		return config, nil
+	}
+	config.CertData = authInfo.ClientCertificateData
+	config.KeyData = authInfo.ClientKeyData
+	return config, nil
+}
+
+// ClusterInstance is an Argo CD instance the generated clusters are registered in
+type ClusterInstance struct {
+	// Name identifies the instance in the logs and the report
+	Name      string
+	Namespace string
+	ClientSet kubernetes.Interface
+	DB        db.ArgoDB
 }
 
 type ClusterGenerator struct {
 	db        db.ArgoDB
-	clientSet *kubernetes.Clientset
+	clientSet kubernetes.Interface
 	config    *rest.Config
+	// instances are the Argo CD instances the clusters are distributed across, the clusters are registered in db if
+	// empty
+	instances []ClusterInstance
+	// connectionStates is the cluster info cache the synthetic connection states are written to, nil if disabled
+	connectionStates *appstatecache.Cache
+	// inventory assigns the label sets of the inventory file to the clusters, nil if none is configured
+	inventory *clusterInventory
+	// first and last are the indexes of the clusters generated by the run
+	first, last int
+	// tokenCredentials are the shared credentials the vclusters are registered with instead of the credentials of
+	// their kubeconfig, nil unless a shared bearer token is configured
+	tokenCredentials *argoappv1.ClusterConfig
+	// kubeconfig collects the contexts of the generated clusters, nil unless KubeconfigPath is set
+	kubeconfig *clusterKubeconfig
+	// stages accumulates the durations of the stages of the generation of the clusters
+	stages *clusterStages
+	// helmInstalls bounds the helm installs run in parallel to HelmConcurrency, nil if unbounded
+	helmInstalls *util.SizedWaitGroup
+	// helmDir is the directory of the run the helm commands get their working directory in, removed once the run is over
+	helmDir string
+	// installRelease installs a vcluster release, installVCluster if nil
+	installRelease func(opts *util.GenerateOpts, installNamespace string, releaseName string) (string, error)
+	// backend provisions the clusters, the one of ClusterOpts.Backend if nil
+	backend ClusterBackend
+	// logger logs the generation and the clean of the clusters, they are not logged if nil
+	logger *slog.Logger
 }
 
-func NewClusterGenerator(db db.ArgoDB, clientSet *kubernetes.Clientset, config *rest.Config) Generator {
-	return &ClusterGenerator{db, clientSet, config}
+// NewClusterGenerator returns the generator of the clusters, whose vclusters are installed with the given client and
+// config of the cluster of Argo CD. The client may be wrapped, e.g. with rate limiting, the exec reading the kubeconfig
+// of the vclusters goes through the config. The generation is logged with the given logger, which may be nil.
+func NewClusterGenerator(db db.ArgoDB, clientSet kubernetes.Interface, config *rest.Config, logger *slog.Logger, instances ...ClusterInstance) Generator {
+	return &ClusterGenerator{db: db, clientSet: clientSet, config: config, logger: logger, instances: instances}
 }
 
-func (cg *ClusterGenerator) getClusterCredentials(namespace string, releaseSuffix string) ([]byte, []byte, []byte, error) {
+// discardLogger is the logger of the generators created without one
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// log returns the logger of the generator, one discarding the records if it has none
+func (cg *ClusterGenerator) log() *slog.Logger {
+	if cg.logger == nil {
+		return discardLogger
+	}
+	return cg.logger
+}
+
+// instance returns the Argo CD instance the cluster with the given index is registered in, nil for the default one
+func (cg *ClusterGenerator) instance(i int) *ClusterInstance {
+	if len(cg.instances) == 0 {
+		return nil
+	}
+	return &cg.instances[(i-1)%len(cg.instances)]
+}
+
+// createCluster creates the cluster with the given index, in its Argo CD instance, with a synthetic connection state if
+// enabled
+func (cg *ClusterGenerator) createCluster(ctx context.Context, opts *util.GenerateOpts, i int, cluster *argoappv1.Cluster) error {
+	defer cg.stages.record("create", time.Now())
+	if opts.DryRun {
+		cg.log().Info("Dry run: would create cluster", "cluster", i, "name", cluster.Name, "server", cluster.Server, "namespaces", cluster.Namespaces)
+		return nil
+	}
+	if cg.connectionStates != nil {
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[syntheticConnectionStateAnnotation] = "true"
+	}
+	if cg.inventory != nil {
+		row, inventoryLabels := cg.inventory.labels(i)
+		// the labels of the generator are kept for clean
+		clusterLabels := maps.Clone(inventoryLabels)
+		maps.Copy(clusterLabels, cluster.Labels)
+		cluster.Labels = clusterLabels
+		opts.Report.Distribution("clusters", "inventoryRow", strconv.Itoa(row+1), 1)
+	}
+	argoDB := cg.db
+	if instance := cg.instance(i); instance != nil {
+		argoDB = instance.DB
+		cg.log().Info("Register cluster in instance", "cluster", i, "name", cluster.Name, "instance", instance.Name)
+	}
+	if _, err := argoDB.CreateCluster(ctx, cluster); err != nil {
+		return err
+	}
+	cg.kubeconfig.add(cluster)
+	if instance := cg.instance(i); instance != nil {
+		opts.Report.Distribution("clusters", "instance", instance.Name, 1)
+	}
+	if cg.connectionStates == nil {
+		return nil
+	}
+	status, err := setSyntheticConnectionState(cg.log(), cg.connectionStates, opts, i, cluster.Server, cluster.Info.ServerVersion)
+	if err != nil {
+		return err
+	}
+	opts.Report.Distribution("clusters", "connectionState", string(status), 1)
+	return nil
+}
+
+func (cg *ClusterGenerator) getClusterCredentials(ctx context.Context, opts *util.GenerateOpts, namespace string, releaseSuffix string) (argoappv1.ClusterConfig, error) {
 	cmd := []string{
 		"sh",
 		"-c",
@@ -74,14 +297,15 @@ func (cg *ClusterGenerator) getClusterCredentials(namespace string, releaseSuffi
 	var stdout, stderr, stdin bytes.Buffer
 	option := &corev1.PodExecOptions{
 		Command:   cmd,
-		Container: "syncer",
-		Stdin:     true,
+		Container: opts.ClusterOpts.SyncerContainer,
+		Stdin:     opts.ClusterOpts.ExecStdin,
 		Stdout:    true,
 		Stderr:    true,
-		TTY:       true,
+		TTY:       opts.ClusterOpts.ExecTTY,
 	}
 
-	req := cg.clientSet.CoreV1().RESTClient().Post().Resource("pods").Name(POD_PREFIX + "-" + releaseSuffix + "-0").
+	podName := opts.ClusterOpts.PodPrefix + "-" + releaseSuffix + "-0"
+	req := cg.clientSet.CoreV1().RESTClient().Post().Resource("pods").Name(podName).
 		Namespace(namespace).SubResource("exec")
 
 	req.VersionedParams(
@@ -91,184 +315,1281 @@ func (cg *ClusterGenerator) getClusterCredentials(namespace string, releaseSuffi
 
 	exec, err := remotecommand.NewSPDYExecutor(cg.config, "POST", req.URL())
 	if err != nil {
-		return nil, nil, nil, err
+		return argoappv1.ClusterConfig{}, err
 	}
 
-	err = exec.StreamWithContext(context.Background(), remotecommand.StreamOptions{
-		Stdin:  &stdin,
+	streamOpts := remotecommand.StreamOptions{
 		Stdout: &stdout,
 		Stderr: &stderr,
-	})
+		Tty:    option.TTY,
+	}
+	if option.Stdin {
+		streamOpts.Stdin = &stdin
+	}
+	execCtx := ctx
+	if opts.ClusterOpts.ExecTimeout > 0 {
+		var cancel context.CancelFunc
+		execCtx, cancel = context.WithTimeout(ctx, opts.ClusterOpts.ExecTimeout)
+		defer cancel()
+	}
+	err = streamWithContext(execCtx, exec, streamOpts)
+	if err != nil && execCtx.Err() != nil {
+		// the exec may still be writing to the buffers, its stderr is not read
+		if ctx.Err() == nil {
+			return argoappv1.ClusterConfig{}, fmt.Errorf("exec in container %s of pod %s in namespace %s timed out after %s: %w", option.Container, podName, namespace, opts.ClusterOpts.ExecTimeout, err)
+		}
+		return argoappv1.ClusterConfig{}, fmt.Errorf("exec in container %s of pod %s in namespace %s interrupted: %w", option.Container, podName, namespace, err)
+	}
 	if err != nil {
-		return nil, nil, nil, err
+		// with a TTY the stderr is part of the stdout
+		if !option.TTY && stderr.Len() > 0 {
+			return argoappv1.ClusterConfig{}, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return argoappv1.ClusterConfig{}, err
 	}
 
-	var config Config
+	config, err := parseKubeconfig(stdout.Bytes())
+	if err != nil {
+		return argoappv1.ClusterConfig{}, fmt.Errorf("kubeconfig of vcluster %s in namespace %s: %w", releaseSuffix, namespace, err)
+	}
+
+	if opts.ClusterOpts.DebugKubeconfig {
+		cg.log().Info("Kubeconfig of vcluster", "release", releaseSuffix, "namespace", namespace, "kubeconfig", describeKubeconfig(config))
+	}
 
-	err = yaml.Unmarshal(stdout.Bytes(), &config)
+	credentials, err := kubeconfigCredentials(config)
 	if err != nil {
-		return nil, nil, nil, err
+		return argoappv1.ClusterConfig{}, fmt.Errorf("kubeconfig of vcluster %s in namespace %s: %w", releaseSuffix, namespace, err)
 	}
+	return credentials, nil
+}
 
-	if len(config.Clusters) == 0 {
-		return nil, nil, nil, errors.New("clusters empty")
+// streamWithContext streams the exec until it completes or the context is done. The upgrade of the connection to the
+// API server is not bounded by the context, the exec is given up on instead.
+func streamWithContext(ctx context.Context, exec remotecommand.Executor, streamOpts remotecommand.StreamOptions) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- exec.StreamWithContext(ctx, streamOpts)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	caData, err := base64.StdEncoding.DecodeString(config.Clusters[0].Cluster.CertificateAuthorityData)
+// helmInstallOutcome returns whether the output of helm upgrade --install reports a newly installed release, at its
+// first revision, or an upgraded one, which was already present
+func helmInstallOutcome(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		revision, ok := strings.CutPrefix(strings.TrimSpace(line), "REVISION:")
+		if !ok {
+			continue
+		}
+		switch n, err := strconv.Atoi(strings.TrimSpace(revision)); {
+		case err != nil:
+			return "unknown"
+		case n == 1:
+			return "installed"
+		default:
+			return "upgraded"
+		}
+	}
+	return "unknown"
+}
+
+// createNamespace creates the namespace a vcluster is installed in with the labels of the generator, so that clean
+// deletes it without deleting the namespaces which only share its prefix
+func createNamespace(ctx context.Context, opts *util.GenerateOpts, clientSet kubernetes.Interface, name string) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: generatedLabels(opts)}}
+	_, err := clientSet.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// installVCluster installs the vcluster release in the given namespace of the cluster of Argo CD. The namespaces the
+// registered cluster is restricted to are namespaces within the vcluster, see clusterNamespaces. It returns whether the
+// release was newly installed or upgraded, see helmInstallOutcome. The service of the vcluster is ensured by
+// ensureService.
+func (cg *ClusterGenerator) installVCluster(opts *util.GenerateOpts, installNamespace string, releaseName string) (string, error) {
+	defer cg.stages.record("helmInstall", time.Now())
+	cmd, err := newChartCmd(opts, cg.helmDir)
+	if err != nil {
+		return "", err
+	}
+	defer cmd.Close()
+	cg.log().Info("Execute helm install command", "release", releaseName, "namespace", installNamespace, "chart", opts.ClusterOpts.ChartName, "version", opts.ClusterOpts.ChartVersion, "repo", opts.ClusterOpts.ChartRepo)
+	out, err := cmd.Freestyle(installArgs(opts, installNamespace, releaseName)...)
 	if err != nil {
-		return nil, nil, nil, err
+		if isHelmTimeout(err) {
+			return "", fmt.Errorf("%w: release %s after %s: %w", errHelmTimeout, releaseName, opts.ClusterOpts.HelmTimeout, err)
+		}
+		// the error of the helm command carries the output helm writes to stderr, the reason of the failure
+		return "", fmt.Errorf("helm install of release %s of chart %s version %s failed: %w", releaseName, opts.ClusterOpts.ChartName, opts.ClusterOpts.ChartVersion, err)
 	}
+	return helmInstallOutcome(out), nil
+}
 
-	cert, err := base64.StdEncoding.DecodeString(config.AuthInfos[0].AuthInfo.ClientCertificateData)
+// isHelmTimeout returns whether the helm command failed as its --wait timeout elapsed, rather than by an error of its
+// own mentioning a timeout, e.g. of an image pull
+func isHelmTimeout(err error) bool {
+	return strings.Contains(err.Error(), "timed out waiting for the condition") || strings.Contains(err.Error(), "context deadline exceeded")
+}
+
+// helmInstall installs the vcluster release once fewer than HelmConcurrency installs are running, the wait for a free
+// slot is not part of the helmInstall stage
+func (cg *ClusterGenerator) helmInstall(ctx context.Context, opts *util.GenerateOpts, installNamespace string, releaseName string) (string, error) {
+	if cg.helmInstalls != nil {
+		if err := cg.helmInstalls.AddWithContext(ctx); err != nil {
+			return "", err
+		}
+		defer cg.helmInstalls.Done()
+	}
+	install := cg.installRelease
+	if install == nil {
+		install = cg.installVCluster
+	}
+	return install(opts, installNamespace, releaseName)
+}
+
+func (cg *ClusterGenerator) getClusterServerURI(ctx context.Context, opts *util.GenerateOpts, namespace string, releaseSuffix string) (string, error) {
+	pod, err := cg.clientSet.CoreV1().Pods(namespace).Get(ctx, opts.ClusterOpts.PodPrefix+"-"+releaseSuffix+"-0", metav1.GetOptions{})
 	if err != nil {
-		return nil, nil, nil, err
+		return "", err
 	}
+	cg.log().Debug("Get pod URI", "uri", "https://"+pod.Status.PodIP+":8443")
+	return "https://" + pod.Status.PodIP + ":8443", nil
+}
 
-	key, err := base64.StdEncoding.DecodeString(config.AuthInfos[0].AuthInfo.ClientKeyData)
+// getClusterServerURIFromService returns the URI of the service of the vcluster created by the chart, which unlike the
+// IP of its pod does not change when the pod is rescheduled
+func (cg *ClusterGenerator) getClusterServerURIFromService(ctx context.Context, opts *util.GenerateOpts, namespace string, releaseSuffix string) (string, error) {
+	name := opts.ClusterOpts.PodPrefix + "-" + releaseSuffix
+	svc, err := cg.clientSet.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return nil, nil, nil, err
+		return "", err
+	}
+	port := int32(opts.ClusterOpts.ServicePort)
+	if !slices.ContainsFunc(svc.Spec.Ports, func(p corev1.ServicePort) bool { return p.Port == port }) {
+		return "", fmt.Errorf("service %s in namespace %s does not expose port %d", name, namespace, port)
 	}
+	uri := fmt.Sprintf("https://%s.%s.svc:%d", name, namespace, port)
+	cg.log().Debug("Get service URI", "uri", uri)
+	return uri, nil
+}
 
-	return caData, cert, key, nil
+// retrieveClusterURI returns the URI of the service of the vcluster, or the URI of its pod if the chart did not
+// create a service
+func (cg *ClusterGenerator) retrieveClusterURI(ctx context.Context, opts *util.GenerateOpts, namespace, releaseSuffix string) string {
+	defer cg.stages.record("serverURI", time.Now())
+	var uri, source string
+	err := util.Retry(ctx, 8, time.Second, func() error {
+		cg.log().Debug("Attempting to get cluster uri", "release", releaseSuffix)
+		var err error
+		uri, err = cg.getClusterServerURIFromService(ctx, opts, namespace, releaseSuffix)
+		source = "service"
+		if apierrors.IsNotFound(err) {
+			cg.log().Info("No service found for vcluster, fall back to the pod IP", "release", releaseSuffix)
+			uri, err = cg.getClusterServerURI(ctx, opts, namespace, releaseSuffix)
+			source = "pod"
+		}
+		if err == nil {
+			return nil
+		}
+		permanent, reason := classifyRetry(ctx, opts, cg.clientSet, namespace, opts.ClusterOpts.PodPrefix+"-"+releaseSuffix+"-0", err)
+		if permanent {
+			cg.log().Warn("Failed to get cluster uri, not retrying as the error is permanent", "release", releaseSuffix, "reason", reason, "error", err)
+			return util.Permanent(err)
+		}
+		cg.log().Warn("Failed to get cluster uri, retrying", "release", releaseSuffix, "reason", reason, "error", err)
+		return err
+	})
+	if err != nil {
+		cg.log().Error("Failed to get cluster uri of vcluster", "release", releaseSuffix, "error", err)
+		return ""
+	}
+	opts.Report.Distribution("clusters", "serverURI", source, 1)
+	return uri
 }
 
-// TODO: also should provision service for vcluster pod
-func (cg *ClusterGenerator) installVCluster(opts *util.GenerateOpts, namespace string, releaseName string) error {
-	cmd, err := helm.NewCmd("/tmp", "v3", "", "")
+// sharedCredentials returns the TLS client config and bearer token shared by the clusters, read from
+// CredentialsSecret, the bearer token being overridden by BearerToken
+func (cg *ClusterGenerator) sharedCredentials(ctx context.Context, opts *util.GenerateOpts) (argoappv1.ClusterConfig, error) {
+	config := argoappv1.ClusterConfig{BearerToken: opts.ClusterOpts.BearerToken}
+	if opts.ClusterOpts.CredentialsSecret == "" {
+		return config, nil
+	}
+	secret, err := cg.clientSet.CoreV1().Secrets(opts.Namespace).Get(ctx, opts.ClusterOpts.CredentialsSecret, metav1.GetOptions{})
 	if err != nil {
+		return config, fmt.Errorf("failed to get cluster credentials secret %s: %w", opts.ClusterOpts.CredentialsSecret, err)
+	}
+	config.CAData = secret.Data[corev1.ServiceAccountRootCAKey]
+	config.CertData = secret.Data[corev1.TLSCertKey]
+	config.KeyData = secret.Data[corev1.TLSPrivateKeyKey]
+	if config.BearerToken == "" {
+		config.BearerToken = string(secret.Data[corev1.ServiceAccountTokenKey])
+	}
+	return config, nil
+}
+
+// clusterLabels returns the labels of the generated clusters, the configured ones along with the label of the generator,
+// and the run id, which win on conflict so that clean selects them
+func clusterLabels(opts *util.GenerateOpts) map[string]string {
+	clusterLabels := maps.Clone(opts.ClusterOpts.Labels)
+	if clusterLabels == nil {
+		clusterLabels = map[string]string{}
+	}
+	maps.Copy(clusterLabels, generatedLabels(opts))
+	return clusterLabels
+}
+
+// serverName returns the TLS server name of the cluster with the given index
+func serverName(template string, i int) string {
+	return strings.ReplaceAll(template, "{{index}}", strconv.Itoa(i))
+}
+
+// generateFromTemplate registers a cluster against the endpoint built from ServerURLTemplate, using the shared credentials
+func (cg *ClusterGenerator) generateFromTemplate(ctx context.Context, release *vclusterRelease, opts *util.GenerateOpts, config argoappv1.ClusterConfig) error {
+	i := release.index
+	release.phase = "create"
+	release.uri = strings.ReplaceAll(opts.ClusterOpts.ServerURLTemplate, "{{index}}", strconv.Itoa(i))
+	release.name = opts.ClusterOpts.ClusterNamePrefix + "-" + strconv.Itoa(i)
+	config.ServerName = serverName(opts.ClusterOpts.ServerName, i)
+	config.ProxyUrl = opts.ClusterOpts.Proxy
+	cg.log().Info("Create cluster", "cluster", i, "of", cg.last, "server", release.uri)
+	cluster := &argoappv1.Cluster{
+		Server: release.uri,
+		Name:   release.name,
+		Config: config,
+		Info: argoappv1.ClusterInfo{
+			ConnectionState: argoappv1.ConnectionState{},
+			ServerVersion:   release.version,
+		},
+		Namespaces: clusterNamespaces(opts, i),
+		// the labels and annotations of the secret are set from the ones of the cluster
+		Labels:      clusterLabels(opts),
+		Annotations: maps.Clone(opts.ClusterOpts.Annotations),
+	}
+	if err := cg.createCluster(ctx, opts, i, cluster); err != nil {
 		return err
 	}
-	log.Print("Execute helm install command")
-	_, err = cmd.Freestyle("upgrade", "--install", releaseName, "vcluster", "--values", opts.ClusterOpts.ValuesFilePath, "--repo", "https://charts.loft.sh", "--namespace", namespace, "--repository-config", "", "--create-namespace", "--wait")
-	if err != nil {
+	cg.verifyClusterConnection(ctx, opts, release, cluster)
+	return nil
+}
+
+// vclusterRelease is a vcluster going through the stages of its generation
+type vclusterRelease struct {
+	index   int
+	version string
+	// phase is the stage the release is in, install, extract or create
+	phase            string
+	started          time.Time
+	name             string
+	installNamespace string
+	releaseSuffix    string
+	// managedCluster is the name of the cluster created by a cloud backend, empty for the vclusters
+	managedCluster string
+	credentials    argoappv1.ClusterConfig
+	uri            string
+	// installOutcome is whether the helm release was installed or upgraded, empty if the vcluster was not installed
+	installOutcome string
+	// connectionState is the state of the connection to the created cluster, nil if it is not verified
+	connectionState   *argoappv1.ConnectionState
+	connectionLatency time.Duration
+}
+
+// install installs the vcluster of the release, or picks the existing one if SkipInstall is set
+func (cg *ClusterGenerator) install(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	release.phase = "install"
+	if opts.ClusterOpts.SkipInstall {
+		existing := opts.ClusterOpts.ExistingVClusters[release.index-1]
+		release.installNamespace, release.releaseSuffix = existing.Namespace, existing.ReleaseSuffix
+		cg.log().Info("Register existing vcluster", "cluster", release.index, "release", release.releaseSuffix, "namespace", release.installNamespace)
+		return nil
+	}
+	release.installNamespace = opts.ClusterOpts.NamespacePrefix + "-" + util.GetRandomString()
+
+	cg.log().Debug("Install namespace", "cluster", release.index, "namespace", release.installNamespace)
+
+	release.releaseSuffix = util.GetRandomString()
+
+	cg.log().Debug("Release suffix", "cluster", release.index, "release", release.releaseSuffix)
+
+	if opts.DryRun {
+		cg.log().Info("Dry run: would create namespace and install release", "cluster", release.index, "namespace", release.installNamespace, "release", opts.ClusterOpts.PodPrefix+"-"+release.releaseSuffix, "chart", opts.ClusterOpts.ChartName, "version", opts.ClusterOpts.ChartVersion, "repo", opts.ClusterOpts.ChartRepo)
+		return nil
+	}
+	if err := createNamespace(ctx, opts, cg.clientSet, release.installNamespace); err != nil {
 		return err
 	}
+	// the credentials of a vcluster which failed to install are not read, its pod never starts
+	outcome, err := cg.helmInstall(ctx, opts, release.installNamespace, opts.ClusterOpts.PodPrefix+"-"+release.releaseSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to install vcluster %s in namespace %s: %w", release.releaseSuffix, release.installNamespace, err)
+	}
+	release.installOutcome = outcome
+	if outcome == "upgraded" {
+		cg.log().Info("Vcluster was already present, its release was upgraded", "cluster", release.index, "release", release.releaseSuffix, "namespace", release.installNamespace)
+	} else {
+		cg.log().Info("Vcluster installed", "cluster", release.index, "release", release.releaseSuffix, "namespace", release.installNamespace, "outcome", outcome)
+	}
+	opts.Report.Distribution("clusters", "helmInstall", outcome, 1)
+	opts.Report.Distribution("clusters", "chartVersion", opts.ClusterOpts.ChartVersion, 1)
+	if opts.ClusterOpts.IsolateNamespaces {
+		return cg.isolateNamespace(ctx, opts, release.installNamespace)
+	}
 	return nil
 }
 
-func (cg *ClusterGenerator) getClusterServerURI(namespace string, releaseSuffix string) (string, error) {
-	pod, err := cg.clientSet.CoreV1().Pods(namespace).Get(context.TODO(), POD_PREFIX+"-"+releaseSuffix+"-0", metav1.GetOptions{})
+// extract reads the credentials and the server URI of the installed vcluster of the release
+func (cg *ClusterGenerator) extract(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	release.phase = "extract"
+	if opts.DryRun {
+		// the vcluster is not installed, the URI is the one of the service it would have
+		release.uri = fmt.Sprintf("https://%s-%s.%s.svc:%d", opts.ClusterOpts.PodPrefix, release.releaseSuffix, release.installNamespace, opts.ClusterOpts.ServicePort)
+		cg.log().Info("Dry run: would read the credentials of the vcluster pod", "cluster", release.index, "pod", opts.ClusterOpts.PodPrefix+"-"+release.releaseSuffix+"-0", "namespace", release.installNamespace)
+		return nil
+	}
+	if !opts.ClusterOpts.SkipInstall {
+		if err := ensureService(ctx, cg.log(), opts, cg.clientSet, release.installNamespace, release.releaseSuffix); err != nil {
+			return err
+		}
+	}
+	// the syncer writes the kubeconfig read by getClusterCredentials once it is up
+	if err := cg.waitForVClusterPod(ctx, opts, release); err != nil {
+		return err
+	}
+	if cg.tokenCredentials != nil {
+		release.credentials = *cg.tokenCredentials.DeepCopy()
+		release.uri = cg.retrieveClusterURI(ctx, opts, release.installNamespace, release.releaseSuffix)
+		cg.log().Info("Cluster server uri", "cluster", release.index, "server", release.uri)
+		return nil
+	}
+	cg.log().Debug("Get cluster credentials", "cluster", release.index)
+	podName := opts.ClusterOpts.PodPrefix + "-" + release.releaseSuffix + "-0"
+	var credentials argoappv1.ClusterConfig
+	started := time.Now()
+	err := util.Retry(ctx, 6, 2*time.Second, func() error {
+		var err error
+		if credentials, err = cg.getClusterCredentials(ctx, opts, release.installNamespace, release.releaseSuffix); err == nil {
+			return nil
+		}
+		permanent, reason := classifyRetry(ctx, opts, cg.clientSet, release.installNamespace, podName, err)
+		if permanent {
+			cg.log().Warn("Failed to get cluster credentials, not retrying as the error is permanent", "cluster", release.index, "release", release.releaseSuffix, "reason", reason)
+			return util.Permanent(fmt.Errorf("permanent error, %s: %w", reason, err))
+		}
+		cg.log().Warn("Failed to get cluster credentials, retrying", "cluster", release.index, "release", release.releaseSuffix, "reason", reason)
+		return err
+	})
+	cg.stages.record("credentials", started)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("failed to get cluster credentials %s: %w", release.releaseSuffix, err)
 	}
-	// TODO: should be moved to service instead pod
-	log.Printf("Get service for https://%s:8443", pod.Status.PodIP)
-	return "https://" + pod.Status.PodIP + ":8443", nil
+	release.credentials = credentials
+
+	cg.log().Debug("Get cluster server uri", "cluster", release.index)
+
+	release.uri = cg.retrieveClusterURI(ctx, opts, release.installNamespace, release.releaseSuffix)
+	cg.log().Info("Cluster server uri", "cluster", release.index, "server", release.uri)
+	return nil
+}
+
+// vclusterTLSConfig returns the credentials of the vcluster with the given index with the TLS and proxy options of the
+// run, the server name defaulting to the one the certificates of vclusters are issued for
+func vclusterTLSConfig(opts *util.GenerateOpts, i int, credentials argoappv1.ClusterConfig) argoappv1.ClusterConfig {
+	config := credentials
+	config.ServerName = defaultServerName
+	if opts.ClusterOpts.ServerName != "" {
+		config.ServerName = serverName(opts.ClusterOpts.ServerName, i)
+	}
+	config.Insecure = opts.ClusterOpts.InsecureTLS
+	config.ProxyUrl = opts.ClusterOpts.Proxy
+	if config.Insecure {
+		// the CA data cannot be set along with insecure
+		config.CAData = nil
+	}
+	return config
 }
 
-func (cg *ClusterGenerator) retrieveClusterURI(namespace, releaseSuffix string) string {
-	for i := 0; i < 10; i++ {
-		log.Print("Attempting to get cluster uri")
-		uri, err := cg.getClusterServerURI(namespace, releaseSuffix)
+// register creates the cluster of the release from the credentials of its backend
+func (cg *ClusterGenerator) register(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	i := release.index
+	release.phase = "create"
+	release.name = opts.ClusterOpts.ClusterNamePrefix + "-" + util.GetRandomString()
+	config := release.credentials
+	if config.Insecure {
+		cg.log().Warn("Cluster is created with insecure TLS, its certificate is not verified", "cluster", i)
+	}
+	if opts.ClusterOpts.WaitForReady && !opts.DryRun {
+		started := time.Now()
+		err := waitForClusterReady(ctx, cg.log(), release.uri, config)
+		cg.stages.record("ready", started)
 		if err != nil {
-			log.Printf("Failed to get cluster uri due to %s", err.Error())
-			time.Sleep(10 * time.Second)
-			continue
+			return err
 		}
-		return uri
 	}
-	return ""
+	// the configured server versions are kept, they are assigned on purpose
+	if len(opts.ClusterOpts.ServerVersions) == 0 && !opts.DryRun {
+		release.version = detectServerVersion(cg.log(), release.uri, config)
+	}
+
+	cg.log().Debug("Create cluster", "cluster", i)
+	cluster := &argoappv1.Cluster{
+		Server: release.uri,
+		Name:   release.name,
+		Config: config,
+		Info: argoappv1.ClusterInfo{
+			ConnectionState: argoappv1.ConnectionState{},
+			ServerVersion:   release.version,
+		},
+		Namespaces: clusterNamespaces(opts, i),
+		// the labels and annotations of the secret are set from the ones of the cluster
+		Labels:      clusterLabels(opts),
+		Annotations: maps.Clone(opts.ClusterOpts.Annotations),
+	}
+	if err := cg.createCluster(ctx, opts, i, cluster); err != nil {
+		return err
+	}
+	cg.verifyClusterConnection(ctx, opts, release, cluster)
+	return nil
 }
 
-func (cg *ClusterGenerator) generate(i int, opts *util.GenerateOpts) error {
-	log.Printf("Generate cluster #%v of #%v", i, opts.ClusterOpts.Samples)
+func (cg *ClusterGenerator) generate(ctx context.Context, backend ClusterBackend, release *vclusterRelease, opts *util.GenerateOpts) error {
+	cg.log().Info("Generate cluster", "cluster", release.index, "of", cg.last)
 
-	namespace := opts.ClusterOpts.NamespacePrefix + "-" + util.GetRandomString()
+	if err := backend.Provision(ctx, opts, release); err != nil {
+		return err
+	}
+	if err := backend.Credentials(ctx, opts, release); err != nil {
+		return err
+	}
+	return cg.register(ctx, opts, release)
+}
 
-	log.Printf("Namespace is %s", namespace)
+func (cg *ClusterGenerator) Generate(ctx context.Context, opts *util.GenerateOpts) error {
+	// the cluster generator is registered in every run, the ones generating other objects only leave the samples unset
+	if opts.ClusterOpts.Samples == 0 && opts.ClusterOpts.TargetCount == 0 {
+		return nil
+	}
+	if err := opts.ClusterOpts.Validate(); err != nil {
+		return fmt.Errorf("invalid cluster options: %w", err)
+	}
+	cg.log().Info("Execute in parallel", "concurrency", opts.ClusterOpts.Concurrency)
+
+	cg.first, cg.last = 1, opts.ClusterOpts.Samples
+	if opts.ClusterOpts.TargetCount > 0 {
+		existing, err := cg.countGeneratedClusters(ctx, opts)
+		if err != nil {
+			return err
+		}
+		shortfall := max(opts.ClusterOpts.TargetCount-existing, 0)
+		cg.log().Info("Found generated clusters, create the shortfall to reach the target", "existing", existing, "shortfall", shortfall, "target", opts.ClusterOpts.TargetCount)
+		opts.Report.Distribution("clusters", "targetCount", "existing", existing)
+		opts.Report.Distribution("clusters", "targetCount", "shortfall", shortfall)
+		if shortfall == 0 {
+			return nil
+		}
+		// the indexes continue after the existing clusters, so that the names and URLs built from them do not collide
+		cg.first, cg.last = existing+1, existing+shortfall
+	}
+
+	removeHelmDir, err := cg.createHelmDir()
+	if err != nil {
+		return err
+	}
+	defer removeHelmDir()
+	if err := cg.preflight(ctx, opts); err != nil {
+		return err
+	}
+	backend := cg.clusterBackend(opts)
+	inventory, err := loadClusterInventory(opts)
+	if err != nil {
+		return err
+	}
+	cg.inventory = inventory
+	cg.stages = newClusterStages()
+	cg.helmInstalls = nil
+	if opts.ClusterOpts.HelmConcurrency > 0 {
+		helmInstalls := util.New(opts.ClusterOpts.HelmConcurrency)
+		cg.helmInstalls = &helmInstalls
+		cg.log().Info("Install vclusters in parallel", "helmConcurrency", opts.ClusterOpts.HelmConcurrency)
+	}
+	if cg.kubeconfig, err = newClusterKubeconfig(opts); err != nil {
+		return err
+	}
+	if cg.connectionStates = connectionStateCache(opts); cg.connectionStates != nil {
+		cg.log().Warn("Clusters are created with a synthetic connection state, they are not probed")
+	}
 
-	releaseSuffix := util.GetRandomString()
+	var sharedConfig argoappv1.ClusterConfig
+	if opts.ClusterOpts.ServerURLTemplate != "" || opts.ClusterOpts.BearerToken != "" || opts.ClusterOpts.CredentialsSecret != "" {
+		var err error
+		if sharedConfig, err = cg.sharedCredentials(ctx, opts); err != nil {
+			return err
+		}
+	}
+	cg.tokenCredentials = nil
+	if opts.ClusterOpts.ServerURLTemplate == "" && sharedConfig.BearerToken != "" {
+		cg.log().Info("Register the vclusters with the shared bearer token, their kubeconfig is not read")
+		cg.tokenCredentials = &sharedConfig
+	}
 
-	log.Printf("Release suffix is %s", namespace)
+	var versionsLock sync.Mutex
+	versions := map[string]int{}
+	var namespaced, clusterWide int
+	failures := newClusterFailures(cg.last - cg.first + 1)
+	record := func(release *vclusterRelease, err error) {
+		opts.Report.ClusterResult("clusters", cg.clusterResult(release, err))
+		if err != nil {
+			failures.add(release.index, err)
+			opts.Report.Failed("clusters", err)
+			if release.installOutcome != "" {
+				cg.log().Error("Failed to generate cluster", "cluster", release.index, "vcluster", release.installOutcome, "error", err)
+				return
+			}
+			cg.log().Error("Failed to generate cluster", "cluster", release.index, "error", err)
+			return
+		}
+		opts.Report.Created("clusters", 1)
+		versionsLock.Lock()
+		defer versionsLock.Unlock()
+		versions[release.version]++
+		if clusterScoped(opts, release.index) {
+			clusterWide++
+		} else {
+			namespaced++
+		}
+	}
+	if opts.ClusterOpts.GenerateTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.ClusterOpts.GenerateTimeout)
+		defer cancel()
+	}
+	var stuck int
+	if opts.ClusterOpts.PipelineOpts.InstallConcurrency > 0 && opts.ClusterOpts.ServerURLTemplate == "" {
+		stuck = cg.generatePipeline(ctx, opts, backend, record)
+	} else {
+		stuck = cg.generateParallel(ctx, opts, func(release *vclusterRelease) error {
+			if opts.ClusterOpts.ServerURLTemplate != "" {
+				return cg.generateFromTemplate(ctx, release, opts, sharedConfig)
+			}
+			return cg.generate(ctx, backend, release, opts)
+		}, record)
+	}
+	// the clusters given up on may still record their result
+	versionsLock.Lock()
+	defer versionsLock.Unlock()
+	for _, version := range slices.Sorted(maps.Keys(versions)) {
+		cg.log().Info("Generated clusters with server version", "count", versions[version], "version", version)
+		opts.Report.Distribution("clusters", "serverVersion", version, versions[version])
+	}
+	cg.log().Info("Generated clusters by scope", "namespaced", namespaced, "clusterScoped", clusterWide)
+	opts.Report.Distribution("clusters", "scope", "namespace", namespaced)
+	opts.Report.Distribution("clusters", "scope", "cluster", clusterWide)
+	cg.stages.report(cg.log(), opts)
+	if opts.DryRun {
+		if opts.ClusterOpts.KubeconfigPath != "" {
+			cg.log().Info("Dry run: would write the kubeconfig of the generated clusters", "path", opts.ClusterOpts.KubeconfigPath)
+		}
+	} else if err := cg.kubeconfig.write(cg.log(), opts.ClusterOpts.KubeconfigPath); err != nil {
+		return errors.Join(err, failures.err())
+	}
+	if err := ctx.Err(); errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("generation of the clusters timed out after %s, %d clusters still in flight: %w", opts.ClusterOpts.GenerateTimeout, stuck, errors.Join(err, failures.err()))
+	} else if err != nil {
+		return fmt.Errorf("generation of the clusters interrupted: %w", errors.Join(err, failures.err()))
+	}
+	return failures.err()
+}
 
-	err := cg.installVCluster(opts, namespace, POD_PREFIX+"-"+releaseSuffix)
+// createHelmDir creates the directory of the run the helm commands get their working directory in, and returns the
+// function removing it once the run is over
+func (cg *ClusterGenerator) createHelmDir() (func(), error) {
+	helmDir, err := os.MkdirTemp("", "argocd-generator-")
 	if err != nil {
-		log.Printf("Skip cluster installation due error %v", err.Error())
+		return nil, fmt.Errorf("failed to create the helm directory of the run: %w", err)
 	}
+	cg.helmDir = helmDir
+	return func() {
+		if err := os.RemoveAll(helmDir); err != nil {
+			cg.log().Warn("Failed to remove the helm directory of the run", "dir", helmDir, "error", err)
+		}
+	}, nil
+}
 
-	log.Print("Get cluster credentials")
-	caData, cert, key, err := cg.getClusterCredentials(namespace, releaseSuffix)
+// clusterResult returns the result of the generation of the cluster of the release, recorded in the report of the run
+func (cg *ClusterGenerator) clusterResult(release *vclusterRelease, err error) util.ClusterResult {
+	result := util.ClusterResult{
+		Index:           release.index,
+		Name:            release.name,
+		Server:          release.uri,
+		Namespace:       release.installNamespace,
+		ReleaseSuffix:   release.releaseSuffix,
+		Success:         err == nil,
+		Phase:           release.phase,
+		DurationSeconds: time.Since(release.started).Seconds(),
+	}
+	if instance := cg.instance(release.index); instance != nil {
+		result.Instance = instance.Name
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if release.connectionState != nil {
+		result.ConnectionStatus = string(release.connectionState.Status)
+		result.ConnectionLatencySeconds = release.connectionLatency.Seconds()
+	}
+	return result
+}
 
-	for o := 0; o < 5; o++ {
-		if err == nil {
+// generateParallel generates the clusters in a pool of Concurrency goroutines, passing the result of each to record. It
+// returns once the clusters are generated or the deadline of the context, if any, elapses, with the number of clusters
+// still in flight.
+func (cg *ClusterGenerator) generateParallel(ctx context.Context, opts *util.GenerateOpts, generate func(release *vclusterRelease) error, record func(release *vclusterRelease, err error)) int {
+	wg := util.New(opts.ClusterOpts.Concurrency)
+	started := time.Now()
+	concurrency := &concurrencyStats{}
+	defer concurrency.report(cg.log(), opts, &wg)
+	for l := cg.first; l <= cg.last; l++ {
+		if l > cg.first {
+			stagger(ctx, opts)
+		}
+		// the generation of the remaining clusters is not started once the context is canceled
+		if err := wg.AddWithContext(ctx); err != nil {
+			cg.log().Warn("Generation interrupted", "notStarted", cg.last-l+1)
 			break
 		}
-		log.Printf("Failed to get cluster credentials %s, retrying...", releaseSuffix)
-		time.Sleep(10 * time.Second)
-		caData, cert, key, err = cg.getClusterCredentials(namespace, releaseSuffix)
+		if ctx.Err() != nil {
+			wg.Done()
+			cg.log().Warn("Generation interrupted", "notStarted", cg.last-l+1)
+			break
+		}
+		go func(i int) {
+			defer wg.Done()
+			inFlight := wg.InFlight()
+			cg.log().Debug("Clusters in flight", "inFlight", inFlight, "limit", wg.Limit())
+			concurrency.started(inFlight)
+			release := &vclusterRelease{index: i, version: serverVersion(opts, i), started: time.Now()}
+			err := generate(release)
+			record(release, err)
+			if opts.ClusterOpts.AdaptiveConcurrency && adaptConcurrency(cg.log(), &wg, opts.ClusterOpts.Concurrency, err) {
+				concurrency.throttled()
+			}
+		}(l)
 	}
-	if err != nil {
-		return err
+	reportStartSpread(cg.log(), opts, time.Since(started))
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		wg.Wait()
+		return 0
+	}
+	stuck := wg.WaitWithTimeout(time.Until(deadline))
+	if stuck > 0 {
+		cg.log().Warn("Generation timed out", "inFlight", stuck)
 	}
+	return stuck
+}
 
-	log.Print("Get cluster server uri")
+// clusterFailures collects the errors of the clusters which failed to generate, so that a batch reports them once all
+// the clusters are processed
+type clusterFailures struct {
+	lock   sync.Mutex
+	total  int
+	errors map[int]error
+}
+
+func newClusterFailures(total int) *clusterFailures {
+	return &clusterFailures{total: total, errors: map[int]error{}}
+}
 
-	uri := cg.retrieveClusterURI(namespace, releaseSuffix)
-	log.Printf("Cluster server uri is %s", uri)
+func (f *clusterFailures) add(index int, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.errors[index] = err
+}
 
-	log.Print("Create cluster")
-	_, err = cg.db.CreateCluster(context.TODO(), &argoappv1.Cluster{
-		Server: uri,
-		Name:   opts.ClusterOpts.ClusterNamePrefix + "-" + util.GetRandomString(),
-		Config: argoappv1.ClusterConfig{
-			TLSClientConfig: argoappv1.TLSClientConfig{
-				Insecure:   false,
-				ServerName: "kubernetes.default.svc",
-				CAData:     caData,
-				CertData:   cert,
-				KeyData:    key,
+// err returns the errors of the failed clusters ordered by index, nil if none failed
+func (f *clusterFailures) err() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if len(f.errors) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, index := range slices.Sorted(maps.Keys(f.errors)) {
+		errs = append(errs, fmt.Errorf("cluster #%d: %w", index, f.errors[index]))
+	}
+	return fmt.Errorf("generated %d/%d clusters, %d failed: %w", f.total-len(f.errors), f.total, len(f.errors), errors.Join(errs...))
+}
+
+// countGeneratedClusters returns the number of generated clusters registered in the Argo CD instances
+func (cg *ClusterGenerator) countGeneratedClusters(ctx context.Context, opts *util.GenerateOpts) (int, error) {
+	listOpts := metav1.ListOptions{LabelSelector: util.GeneratedBySelector + "," + common.LabelKeySecretType + "=" + common.LabelValueSecretTypeCluster}
+	if len(cg.instances) == 0 {
+		secrets, err := cg.clientSet.CoreV1().Secrets(opts.ClusterSecretsNamespace()).List(ctx, listOpts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count generated clusters: %w", err)
+		}
+		return len(secrets.Items), nil
+	}
+	count := 0
+	for _, instance := range cg.instances {
+		secrets, err := instance.ClientSet.CoreV1().Secrets(instance.Namespace).List(ctx, listOpts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count generated clusters of instance %s: %w", instance.Name, err)
+		}
+		count += len(secrets.Items)
+	}
+	return count, nil
+}
+
+// preflight verifies that the Argo CD namespace and the namespace of the cluster secrets exist and that the cluster
+// secrets can be created, so that a misconfigured namespace fails once instead of for every cluster
+func (cg *ClusterGenerator) preflight(ctx context.Context, opts *util.GenerateOpts) error {
+	if _, err := cg.clientSet.CoreV1().Namespaces().Get(ctx, opts.Namespace, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("argo cd namespace %s does not exist", opts.Namespace)
+		}
+		return fmt.Errorf("failed to get argo cd namespace %s: %w", opts.Namespace, err)
+	}
+	secretNamespace := opts.ClusterSecretsNamespace()
+	if secretNamespace != opts.Namespace {
+		if _, err := cg.clientSet.CoreV1().Namespaces().Get(ctx, secretNamespace, metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("cluster secret namespace %s does not exist", secretNamespace)
+			}
+			return fmt.Errorf("failed to get cluster secret namespace %s: %w", secretNamespace, err)
+		}
+	}
+	review, err := cg.clientSet.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: secretNamespace,
+				Verb:      "create",
+				Resource:  "secrets",
 			},
 		},
-		Info: argoappv1.ClusterInfo{
-			ConnectionState: argoappv1.ConnectionState{},
-			ServerVersion:   "1.18",
-		},
-		Namespaces: []string{opts.ClusterOpts.DestinationNamespace},
-		Labels:     labels,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to check permission to create secrets in namespace %s: %w", secretNamespace, err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("not permitted to create secrets in namespace %s: %s", secretNamespace, review.Status.Reason)
+	}
+	if opts.ClusterOpts.ServerURLTemplate != "" || opts.ClusterOpts.Samples == 0 || opts.ClusterOpts.Backend == "gke" {
+		return nil
+	}
+	if opts.ClusterOpts.SkipInstall {
+		return cg.verifyExistingVClusters(ctx, opts)
+	}
+	return verifyChartValues(cg.log(), opts, cg.helmDir)
+}
+
+// verifyChartValues renders the vcluster chart with the values file once, so that values which do not match the schema
+// of the chart fail before the vclusters are installed instead of during each install
+func verifyChartValues(logger *slog.Logger, opts *util.GenerateOpts, helmDir string) error {
+	if opts.ClusterOpts.ValuesFilePath != "" {
+		if _, err := os.Stat(opts.ClusterOpts.ValuesFilePath); err != nil {
+			return fmt.Errorf("failed to read values file of the vclusters: %w", err)
+		}
+	}
+	cmd, err := newChartCmd(opts, helmDir)
+	if err != nil {
+		return err
+	}
+	defer cmd.Close()
+	out, err := cmd.Freestyle(append([]string{"show", "chart"}, chartArgs(opts)...)...)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the version of the vcluster chart: %w", err)
+	}
+	var chart struct {
+		Version string `json:"version"`
+	}
+	if err := yaml.Unmarshal([]byte(out), &chart); err != nil {
+		return fmt.Errorf("failed to parse the vcluster chart: %w", err)
+	}
+	if opts.ClusterOpts.ChartVersion == "" {
+		// a chart released during the run does not change the layout of the vclusters installed after it
+		logger.Info("Pin the vcluster chart to its latest version for the run", "version", chart.Version)
+		opts.ClusterOpts.ChartVersion = chart.Version
+	} else {
+		logger.Info("Resolved the vcluster chart version", "version", chart.Version)
+	}
+	if opts.ClusterOpts.ValuesFilePath == "" {
+		logger.Info("No values file, the vclusters are installed with the default values of the chart")
+		return nil
+	}
+	logger.Info("Verify values file against the vcluster chart", "valuesFile", opts.ClusterOpts.ValuesFilePath)
+	args := append([]string{"template", opts.ClusterOpts.PodPrefix + "-preflight"}, chartArgs(opts)...)
+	_, err = cmd.Freestyle(append(args, "--values", opts.ClusterOpts.ValuesFilePath, "--namespace", opts.ClusterOpts.NamespacePrefix+"-preflight")...)
+	if err != nil {
+		return fmt.Errorf("values file %s is not valid for the vcluster chart: %w", opts.ClusterOpts.ValuesFilePath, err)
+	}
+	return nil
+}
+
+// verifyExistingVClusters verifies that the pods of the vclusters registered without installing them exist
+func (cg *ClusterGenerator) verifyExistingVClusters(ctx context.Context, opts *util.GenerateOpts) error {
+	existing := opts.ClusterOpts.ExistingVClusters
+	if len(existing) < opts.ClusterOpts.Samples {
+		return fmt.Errorf("%d existing vclusters are listed for %d samples", len(existing), opts.ClusterOpts.Samples)
+	}
+	for _, vcluster := range existing[:opts.ClusterOpts.Samples] {
+		name := opts.ClusterOpts.PodPrefix + "-" + vcluster.ReleaseSuffix + "-0"
+		if _, err := cg.clientSet.CoreV1().Pods(vcluster.Namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+			return fmt.Errorf("failed to get pod %s of existing vcluster in namespace %s: %w", name, vcluster.Namespace, err)
+		}
+	}
+	return nil
+}
+
+// clusterScoped returns whether the cluster with the given index is registered without a namespace restriction, the
+// cluster-scoped clusters are spread evenly across the indexes
+func clusterScoped(opts *util.GenerateOpts, i int) bool {
+	percent := opts.ClusterOpts.ClusterScopedPercent
+	return i*percent/100 > (i-1)*percent/100
+}
+
+// clusterNamespaces returns the namespaces, within the cluster with the given index, the cluster is restricted to
+func clusterNamespaces(opts *util.GenerateOpts, i int) []string {
+	if clusterScoped(opts, i) || opts.ClusterOpts.DestinationNamespace == "" {
+		return nil
+	}
+	return []string{opts.ClusterOpts.DestinationNamespace}
+}
+
+// serverVersion returns the server version of the cluster with the given index
+func serverVersion(opts *util.GenerateOpts, i int) string {
+	versions := opts.ClusterOpts.ServerVersions
+	switch {
+	case len(versions) == 0:
+		return defaultServerVersion
+	case opts.ClusterOpts.ServerVersionStrategy == "Random":
+		return versions[rand.Intn(len(versions))]
+	default:
+		return versions[(i-1)%len(versions)]
+	}
+}
+
+// clusterClientSet returns a client of the generated cluster with the given server and credentials, whose requests
+// time out after serverVersionTimeout
+func clusterClientSet(server string, config argoappv1.ClusterConfig) (*kubernetes.Clientset, error) {
+	cluster := &argoappv1.Cluster{Server: server, Config: config}
+	restConfig, err := cluster.RawRestConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = serverVersionTimeout
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// waitForClusterReady waits until the API server of the generated cluster with the given server and credentials
+// reports it is healthy, so that the cluster is usable once it is registered
+func waitForClusterReady(ctx context.Context, logger *slog.Logger, server string, config argoappv1.ClusterConfig) error {
+	clientSet, err := clusterClientSet(server, config)
+	if err != nil {
+		return fmt.Errorf("failed to build the client of cluster %s: %w", server, err)
+	}
+	started := time.Now()
+	err = util.Retry(ctx, readyAttempts, time.Second, func() error {
+		body, err := clientSet.Discovery().RESTClient().Get().AbsPath("/healthz").DoRaw(ctx)
+		if err != nil {
+			logger.Debug("Cluster is not ready yet", "server", server, "error", err)
+			return err
+		}
+		if status := strings.TrimSpace(string(body)); status != "ok" {
+			logger.Debug("Cluster is not ready yet", "server", server, "healthz", status)
+			return fmt.Errorf("/healthz returned %q", status)
+		}
+		return nil
 	})
 	if err != nil {
+		return fmt.Errorf("cluster %s is not ready: %w", server, err)
+	}
+	logger.Info("Cluster is ready", "server", server, "after", time.Since(started).Round(time.Second))
+	return nil
+}
+
+// detectServerVersion returns the Major.Minor server version of the vcluster with the given server and credentials, or
+// the default server version if it cannot be discovered
+func detectServerVersion(logger *slog.Logger, server string, config argoappv1.ClusterConfig) string {
+	clientSet, err := clusterClientSet(server, config)
+	if err != nil {
+		logger.Warn("Failed to build the client of cluster, it is registered with the default server version", "server", server, "version", defaultServerVersion, "error", err)
+		return defaultServerVersion
+	}
+	return discoverServerVersion(logger, clientSet.Discovery(), server)
+}
+
+// discoverServerVersion returns the Major.Minor server version reported by the discovery client of the given server, or
+// the default server version if the discovery fails
+func discoverServerVersion(logger *slog.Logger, client discovery.ServerVersionInterface, server string) string {
+	info, err := client.ServerVersion()
+	if err != nil {
+		logger.Warn("Failed to discover the server version of cluster, it is registered with the default server version", "server", server, "version", defaultServerVersion, "error", err)
+		return defaultServerVersion
+	}
+	version := info.Major + "." + strings.TrimSuffix(info.Minor, "+")
+	logger.Info("Discovered server version of cluster", "server", server, "version", version)
+	return version
+}
+
+// stagger waits for StaggerDelay plus a random jitter up to StaggerJitter before the generation of the next cluster
+// is started, or until the context is canceled
+func stagger(ctx context.Context, opts *util.GenerateOpts) {
+	delay := opts.ClusterOpts.StaggerDelay
+	if opts.ClusterOpts.StaggerJitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(opts.ClusterOpts.StaggerJitter)))
+	}
+	if delay == 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// reportStartSpread reports the time between the start of the generation of the first and the last cluster
+func reportStartSpread(logger *slog.Logger, opts *util.GenerateOpts, spread time.Duration) {
+	if opts.ClusterOpts.StaggerDelay == 0 && opts.ClusterOpts.StaggerJitter == 0 {
+		return
+	}
+	logger.Info("Started the generation of the clusters", "spread", spread.Round(time.Second))
+	opts.Report.Distribution("clusters", "startSpread", "seconds", int(spread.Seconds()))
+}
+
+// concurrencyStats records the clusters in flight in the pool generating them, and how often its concurrency was
+// lowered
+type concurrencyStats struct {
+	lock         sync.Mutex
+	peakInFlight int
+	lowered      int
+}
+
+func (s *concurrencyStats) started(inFlight int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.peakInFlight = max(s.peakInFlight, inFlight)
+}
+
+func (s *concurrencyStats) throttled() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lowered++
+}
+
+// report logs the clusters in flight and records them in the report of the run, along with the concurrency of the pool
+func (s *concurrencyStats) report(logger *slog.Logger, opts *util.GenerateOpts, wg *util.SizedWaitGroup) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	limit := wg.Limit()
+	logger.Info("Generated the clusters", "peakInFlight", s.peakInFlight, "lowered", s.lowered, "concurrency", limit)
+	opts.Report.Distribution("clusters", "concurrency", "peakInFlight", s.peakInFlight)
+	opts.Report.Distribution("clusters", "concurrency", "lowered", s.lowered)
+	opts.Report.Distribution("clusters", "concurrency", "limit", limit)
+}
+
+// adaptConcurrency halves the concurrency when the API server throttles the requests, and raises it by one after each
+// success, up to maxConcurrency. It returns whether the concurrency was lowered.
+func adaptConcurrency(logger *slog.Logger, wg *util.SizedWaitGroup, maxConcurrency int, err error) bool {
+	limit := wg.Limit()
+	switch {
+	case apierrors.IsTooManyRequests(err):
+		if limit > 1 {
+			logger.Warn("API server is throttling requests, lower concurrency", "concurrency", limit/2)
+			wg.Resize(limit / 2)
+			return true
+		}
+	case err == nil && limit < maxConcurrency:
+		wg.Resize(limit + 1)
+	}
+	return false
+}
+
+// cleanTerminatingNamespace reports the finalizers blocking the deletion of the namespace, and removes them if
+// ForceRemoveFinalizers is set
+func cleanTerminatingNamespace(ctx context.Context, logger *slog.Logger, opts *util.GenerateOpts, clientSet kubernetes.Interface, ns *corev1.Namespace) {
+	finalizers := slices.Clone(ns.Finalizers)
+	for _, finalizer := range ns.Spec.Finalizers {
+		finalizers = append(finalizers, string(finalizer))
+	}
+	logger.Warn("Namespace is stuck terminating", "namespace", ns.Name, "since", ns.DeletionTimestamp, "finalizers", finalizers)
+	if !opts.ClusterOpts.ForceRemoveFinalizers {
+		return
+	}
+	namespaces := clientSet.CoreV1().Namespaces()
+	if len(ns.Finalizers) > 0 {
+		ns.Finalizers = nil
+		updated, err := namespaces.Update(ctx, ns, metav1.UpdateOptions{})
+		if apierrors.IsNotFound(err) {
+			return
+		}
+		if err != nil {
+			logger.Error("Remove finalizers of namespace failed", "namespace", ns.Name, "error", err)
+			return
+		}
+		ns = updated
+	}
+	if len(ns.Spec.Finalizers) > 0 {
+		ns.Spec.Finalizers = nil
+		_, err := namespaces.Finalize(ctx, ns, metav1.UpdateOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			logger.Error("Finalize namespace failed", "namespace", ns.Name, "error", err)
+			return
+		}
+	}
+	logger.Info("Removed finalizers of namespace", "namespace", ns.Name)
+}
+
+func (cg *ClusterGenerator) Clean(ctx context.Context, opts *util.GenerateOpts) error {
+	cg.log().Info("Clean clusters")
+	if opts.CleanReportPath != "" {
+		return cg.cleanFromReport(ctx, opts, cg.clientSet)
+	}
+	removeHelmDir, err := cg.createHelmDir()
+	if err != nil {
+		return err
+	}
+	defer removeHelmDir()
+	// the cluster secrets are deleted even if some clusters are not
+	errs := []error{cg.clusterBackend(opts).Teardown(ctx, opts)}
+	if len(cg.instances) == 0 {
+		return errors.Join(append(errs, cleanClusterSecrets(ctx, cg.log(), opts, cg.clientSet, opts.ClusterSecretsNamespace()))...)
+	}
+	for _, instance := range cg.instances {
+		cg.log().Info("Clean clusters of instance", "instance", instance.Name)
+		if err := cleanClusterSecrets(ctx, cg.log(), opts, instance.ClientSet, instance.Namespace); err != nil {
+			errs = append(errs, fmt.Errorf("failed to clean clusters of instance %s: %w", instance.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cleanFromReport deletes the clusters and the namespaces of the vclusters listed in the report of a generate run,
+// including the ones whose generation failed. The helm releases of the vclusters are deleted along with their namespace.
+func (cg *ClusterGenerator) cleanFromReport(ctx context.Context, opts *util.GenerateOpts, clientSet kubernetes.Interface) error {
+	report, err := util.ReadReport(opts.CleanReportPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the report of the clusters to clean: %w", err)
+	}
+	if report.Command != "generate" {
+		return fmt.Errorf("report %s is the report of a %s run, not of a generate run", opts.CleanReportPath, report.Command)
+	}
+	results := report.ClusterResults("clusters")
+	cg.log().Info("Clean the clusters of the report", "count", len(results), "report", opts.CleanReportPath)
+	var errs []error
+	var deletedClusters int
+	var deletedNamespaces []string
+	for _, result := range results {
+		if result.Success {
+			argoDB := cg.db
+			if result.Instance != "" {
+				i := slices.IndexFunc(cg.instances, func(instance ClusterInstance) bool { return instance.Name == result.Instance })
+				if i < 0 {
+					errs = append(errs, fmt.Errorf("cluster #%d %s is registered in instance %s, which is not cleaned", result.Index, result.Server, result.Instance))
+					continue
+				}
+				argoDB = cg.instances[i].DB
+			}
+			if opts.DryRun {
+				cg.log().Info("Dry run: would delete cluster", "cluster", result.Index, "server", result.Server)
+			} else if err := argoDB.DeleteCluster(ctx, result.Server); err != nil && status.Code(err) != codes.NotFound {
+				errs = append(errs, fmt.Errorf("failed to delete cluster #%d %s: %w", result.Index, result.Server, err))
+			} else {
+				deletedClusters++
+			}
+		}
+		if result.Namespace == "" {
+			continue
+		}
+		// the namespaces of the vclusters registered with SkipInstall are not created by the generator
+		ns, err := clientSet.CoreV1().Namespaces().Get(ctx, result.Namespace, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get namespace %s of cluster #%d: %w", result.Namespace, result.Index, err))
+			continue
+		}
+		if ns.Labels[generatedByLabel] != labels[generatedByLabel] {
+			cg.log().Info("Keep namespace of cluster, it is not generated", "cluster", result.Index, "namespace", result.Namespace)
+			continue
+		}
+		if opts.DryRun {
+			cg.log().Info("Dry run: would delete namespace of cluster", "cluster", result.Index, "namespace", result.Namespace)
+			continue
+		}
+		if err := clientSet.CoreV1().Namespaces().Delete(ctx, result.Namespace, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete namespace %s of cluster #%d: %w", result.Namespace, result.Index, err))
+			continue
+		}
+		deletedNamespaces = append(deletedNamespaces, result.Namespace)
+	}
+	cg.log().Info("Deleted the clusters and namespaces of the report", "clusters", deletedClusters, "namespaces", len(deletedNamespaces))
+	opts.Report.Deleted("clusters", deletedClusters)
+	if opts.CleanWait {
+		errs = append(errs, waitForNamespacesDeleted(ctx, cg.log(), clientSet, deletedNamespaces, opts.CleanWaitTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+// cleanClusterSecrets deletes the generated cluster secrets in the given namespace
+func cleanClusterSecrets(ctx context.Context, logger *slog.Logger, opts *util.GenerateOpts, clientSet kubernetes.Interface, namespace string) error {
+	secrets := clientSet.CoreV1().Secrets(namespace)
+	listOpts := metav1.ListOptions{LabelSelector: cleanSelector(opts, common.LabelKeySecretType+"="+common.LabelValueSecretTypeCluster)}
+	matched, err := secrets.List(ctx, listOpts)
+	if err != nil {
+		return err
+	}
+	logger.Info("Delete cluster secrets", "count", len(matched.Items), "namespace", namespace, "selector", listOpts.LabelSelector)
+	if dryRunDelete(opts, "cluster secrets", objectNames(matched.Items)) {
+		return nil
+	}
+	if err := secrets.DeleteCollection(ctx, metav1.DeleteOptions{}, listOpts); err != nil {
 		return err
 	}
+	opts.Report.Deleted("clusters", len(matched.Items))
 	return nil
 }
 
-func (cg *ClusterGenerator) Generate(opts *util.GenerateOpts) error {
-	log.Printf("Excute in parallel with %v", opts.ClusterOpts.Concurrency)
+// helmRelease is a release listed by helm list
+type helmRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+}
+
+// cleanHelmReleases uninstalls the vcluster releases of the generator, including the ones left by failed installs, so
+// that their metadata secrets do not survive if the deletion of their namespace is skipped or fails
+func (cg *ClusterGenerator) cleanHelmReleases(opts *util.GenerateOpts) error {
+	cmd, err := newHelmCmd(cg.helmDir)
+	if err != nil {
+		return err
+	}
+	defer cmd.Close()
+	out, err := cmd.Freestyle("list", "--all-namespaces", "--all", "--filter", "^"+opts.ClusterOpts.PodPrefix+"-", "--output", "json")
+	if err != nil {
+		return fmt.Errorf("failed to list vcluster releases: %w", err)
+	}
+	var releases []helmRelease
+	if err := json.Unmarshal([]byte(out), &releases); err != nil {
+		return fmt.Errorf("failed to parse the vcluster releases: %w", err)
+	}
 
+	var lock sync.Mutex
+	var uninstalled int
 	wg := util.New(opts.ClusterOpts.Concurrency)
-	for l := 1; l <= opts.ClusterOpts.Samples; l++ {
+	for _, release := range releases {
+		if !strings.HasPrefix(release.Namespace, opts.ClusterOpts.NamespacePrefix+"-") {
+			continue
+		}
+		if opts.DryRun {
+			cg.log().Info("Dry run: would uninstall release", "release", release.Name, "namespace", release.Namespace, "status", release.Status)
+			continue
+		}
 		wg.Add()
-		go func(i int) {
+		go func(release helmRelease) {
 			defer wg.Done()
-			err := cg.generate(i, opts)
+			_, err := cmd.Freestyle("uninstall", release.Name, "--namespace", release.Namespace)
+			lock.Lock()
+			defer lock.Unlock()
 			if err != nil {
-				log.Printf("Failed to generate cluster #%v due to : %s", i, err.Error())
+				opts.Report.Failed("clusters", err)
+				cg.log().Error("Uninstall release failed", "release", release.Name, "namespace", release.Namespace, "error", err)
+				return
 			}
-		}(l)
+			cg.log().Info("Uninstalled release", "release", release.Name, "namespace", release.Namespace, "status", release.Status)
+			uninstalled++
+		}(release)
 	}
 	wg.Wait()
+	cg.log().Info("Uninstalled vcluster releases", "count", uninstalled)
+	opts.Report.Distribution("clusters", "helmReleases", "uninstalled", uninstalled)
 	return nil
 }
 
-func (cg *ClusterGenerator) Clean(opts *util.GenerateOpts) error {
-	log.Printf("Clean clusters")
-	namespaces, err := cg.clientSet.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+// cleanNamespaces deletes the namespaces of the vclusters, selected by the labels of the generator rather than by their
+// prefix so that the namespaces of other teams sharing it are kept, and waits until they are gone if CleanWait is set.
+// It returns the errors of all the namespaces which failed to be deleted.
+func cleanNamespaces(ctx context.Context, logger *slog.Logger, opts *util.GenerateOpts, clientSet kubernetes.Interface) error {
+	namespaces, err := clientSet.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: cleanSelector(opts)})
 	if err != nil {
 		return err
 	}
 
+	if dryRunDelete(opts, "namespaces", objectNames(namespaces.Items)) {
+		return nil
+	}
+
+	var terminating []corev1.Namespace
+	var lock sync.Mutex
+	var deleted []string
+	failed := map[string]error{}
+	wg := util.New(opts.ClusterOpts.Concurrency)
 	for _, ns := range namespaces.Items {
-		if strings.HasPrefix(ns.Name, POD_PREFIX) {
-			log.Printf("Delete namespace %s", ns.Name)
-			err = cg.clientSet.CoreV1().Namespaces().Delete(context.TODO(), ns.Name, metav1.DeleteOptions{})
-			if err != nil {
-				log.Printf("Delete namespace failed due: %s", err.Error())
-			}
+		if ns.DeletionTimestamp != nil {
+			// already deleted by a previous clean, but blocked by its finalizers
+			terminating = append(terminating, ns)
+			continue
 		}
+		wg.Add()
+		go func(name string) {
+			defer wg.Done()
+			err := clientSet.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil && !apierrors.IsNotFound(err) {
+				failed[name] = err
+				return
+			}
+			deleted = append(deleted, name)
+		}(ns.Name)
+	}
+	wg.Wait()
+	logger.Info("Deleted namespaces", "deleted", len(deleted), "failed", len(failed))
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(failed)) {
+		errs = append(errs, fmt.Errorf("failed to delete namespace %s: %w", name, failed[name]))
+	}
+	for i := range terminating {
+		cleanTerminatingNamespace(ctx, logger, opts, clientSet, &terminating[i])
+		deleted = append(deleted, terminating[i].Name)
+	}
+	if opts.CleanWait {
+		errs = append(errs, waitForNamespacesDeleted(ctx, logger, clientSet, deleted, opts.CleanWaitTimeout))
 	}
+	return errors.Join(errs...)
+}
+
+// namespaceDeletionInterval is the interval between the checks whether the deleted namespaces are gone
+const namespaceDeletionInterval = time.Second
 
-	secrets := cg.clientSet.CoreV1().Secrets(opts.Namespace)
-	return secrets.DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{
-		LabelSelector: "app.kubernetes.io/generated-by=argocd-generator",
+// waitForNamespacesDeleted waits until the given namespaces are gone, and returns the ones still terminating once the
+// timeout elapses
+func waitForNamespacesDeleted(ctx context.Context, logger *slog.Logger, clientSet kubernetes.Interface, names []string, timeout time.Duration) error {
+	if len(names) == 0 {
+		return nil
+	}
+	logger.Info("Wait for the namespaces to be deleted", "count", len(names))
+	started := time.Now()
+	remaining := slices.Sorted(slices.Values(names))
+	err := wait.PollUntilContextTimeout(ctx, namespaceDeletionInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		remaining = slices.DeleteFunc(remaining, func(name string) bool {
+			_, err := clientSet.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+			return apierrors.IsNotFound(err)
+		})
+		return len(remaining) == 0, nil
 	})
+	if err != nil {
+		return fmt.Errorf("namespaces %v are still terminating after %s: %w", remaining, time.Since(started).Round(time.Second), err)
+	}
+	logger.Info("Deleted namespaces are gone", "after", time.Since(started).Round(time.Second))
+	return nil
 }
@@ -0,0 +1,1176 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	dbmocks "github.com/argoproj/argo-cd/v3/util/db/mocks"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+func TestGenerateParallelFailures(t *testing.T) {
+	cg := &ClusterGenerator{first: 1, last: 10}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Concurrency: 3}}
+	failures := newClusterFailures(10)
+	cg.generateParallel(t.Context(), opts, func(release *vclusterRelease) error {
+		if release.index%4 == 0 {
+			return errors.New("install failed")
+		}
+		return nil
+	}, func(release *vclusterRelease, err error) {
+		if err != nil {
+			failures.add(release.index, err)
+		}
+	})
+	require.EqualError(t, failures.err(), "generated 8/10 clusters, 2 failed: cluster #4: install failed\ncluster #8: install failed")
+}
+
+func TestClusterGeneratorLogger(t *testing.T) {
+	t.Run("Keyed", func(t *testing.T) {
+		var out bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		cg := NewClusterGenerator(nil, nil, nil, logger).(*ClusterGenerator)
+		wg := util.New(4)
+		assert.True(t, adaptConcurrency(cg.log(), &wg, 4, apierrors.NewTooManyRequests("throttled", 1)))
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+		assert.Equal(t, "WARN", record["level"])
+		assert.Equal(t, "API server is throttling requests, lower concurrency", record["msg"])
+		assert.InDelta(t, 2, record["concurrency"], 0)
+	})
+	t.Run("Discarded", func(t *testing.T) {
+		cg := NewClusterGenerator(nil, nil, nil, nil).(*ClusterGenerator)
+		cg.first, cg.last = 1, 2
+		assert.Same(t, discardLogger, cg.log())
+		cg.generateParallel(t.Context(), &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Concurrency: 1}}, func(*vclusterRelease) error {
+			return nil
+		}, func(*vclusterRelease, error) {})
+	})
+}
+
+func TestGenerateInvalidClusterOpts(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		clientSet := fake.NewClientset()
+		cg := &ClusterGenerator{clientSet: clientSet}
+		opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Samples: -1, Concurrency: 0, NamespacePrefix: "vcluster", PodPrefix: "vcluster"}}
+		err := cg.Generate(t.Context(), opts)
+		require.EqualError(t, err, "invalid cluster options: cluster.samples must be greater than 0, got -1\ncluster.parallel must be at least 1, got 0")
+		// the run fails before anything is looked up or created
+		assert.Empty(t, clientSet.Actions())
+	})
+	t.Run("NoClusters", func(t *testing.T) {
+		clientSet := fake.NewClientset()
+		cg := &ClusterGenerator{clientSet: clientSet}
+		// the cluster options of a run generating only other objects are left unset
+		require.NoError(t, cg.Generate(t.Context(), &util.GenerateOpts{}))
+		assert.Empty(t, clientSet.Actions())
+	})
+}
+
+func TestGenerateParallelCanceled(t *testing.T) {
+	cg := &ClusterGenerator{first: 1, last: 10}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Concurrency: 1}}
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	var generated []int
+	cg.generateParallel(ctx, opts, func(release *vclusterRelease) error {
+		generated = append(generated, release.index)
+		if release.index == 3 {
+			cancel()
+		}
+		return nil
+	}, func(*vclusterRelease, error) {})
+	assert.Equal(t, []int{1, 2, 3}, generated)
+}
+
+func TestGenerateParallelTimeout(t *testing.T) {
+	cg := &ClusterGenerator{first: 1, last: 3}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Concurrency: 3}}
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	hung := make(chan struct{})
+	defer close(hung)
+	stuck := cg.generateParallel(ctx, opts, func(release *vclusterRelease) error {
+		if release.index == 2 {
+			<-hung
+		}
+		return nil
+	}, func(*vclusterRelease, error) {})
+	assert.Equal(t, 1, stuck)
+}
+
+// provisionBackend provisions the clusters with provision, as a backend of the tests driving the pipeline
+type provisionBackend struct {
+	provision func(release *vclusterRelease) error
+}
+
+func (b *provisionBackend) Provision(_ context.Context, _ *util.GenerateOpts, release *vclusterRelease) error {
+	return b.provision(release)
+}
+
+func (b *provisionBackend) Credentials(context.Context, *util.GenerateOpts, *vclusterRelease) error {
+	return nil
+}
+
+func (b *provisionBackend) Teardown(context.Context, *util.GenerateOpts) error {
+	return nil
+}
+
+func TestGeneratePipelineTimeout(t *testing.T) {
+	cg := &ClusterGenerator{first: 1, last: 3}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{PipelineOpts: util.PipelineOpts{InstallConcurrency: 3}}}
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	hung := make(chan struct{})
+	defer close(hung)
+	backend := &provisionBackend{provision: func(release *vclusterRelease) error {
+		if release.index == 2 {
+			<-hung
+		}
+		return errors.New("install failed")
+	}}
+	failures := newClusterFailures(3)
+	stuck := cg.generatePipeline(ctx, opts, backend, func(release *vclusterRelease, err error) {
+		failures.add(release.index, err)
+	})
+	assert.Equal(t, 1, stuck)
+	require.EqualError(t, failures.err(), "generated 1/3 clusters, 2 failed: cluster #1: install failed\ncluster #3: install failed")
+}
+
+func TestHelmInstallConcurrency(t *testing.T) {
+	helmInstalls := util.New(2)
+	var running, peak atomic.Int32
+	cg := &ClusterGenerator{first: 1, last: 10, helmInstalls: &helmInstalls}
+	cg.installRelease = func(_ *util.GenerateOpts, _ string, _ string) (string, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return "installed", nil
+	}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Concurrency: 5}}
+	failures := newClusterFailures(10)
+	cg.generateParallel(t.Context(), opts, func(release *vclusterRelease) error {
+		_, err := cg.helmInstall(t.Context(), opts, "vcluster-abc", "vcluster-abc")
+		return err
+	}, func(release *vclusterRelease, err error) {
+		if err != nil {
+			failures.add(release.index, err)
+		}
+	})
+	require.NoError(t, failures.err())
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestInstallFailure(t *testing.T) {
+	clientSet := fake.NewClientset()
+	cg := &ClusterGenerator{clientSet: clientSet, first: 1, last: 1}
+	cg.installRelease = func(_ *util.GenerateOpts, _ string, _ string) (string, error) {
+		return "", errors.New("Error: INSTALLATION FAILED: chart \"vcluster\" not found")
+	}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{NamespacePrefix: "vcluster", PodPrefix: "vcluster", Concurrency: 1}}
+	release := &vclusterRelease{index: 1}
+	err := cg.generate(t.Context(), &vclusterBackend{cg: cg}, release, opts)
+	require.ErrorContains(t, err, `INSTALLATION FAILED: chart "vcluster" not found`)
+	assert.Equal(t, "install", release.phase)
+
+	// the namespace is created, the service and the pod of the vcluster are never looked up
+	for _, action := range clientSet.Actions() {
+		assert.Equal(t, "namespaces", action.GetResource().Resource, "%s %s", action.GetVerb(), action.GetResource().Resource)
+	}
+}
+
+func TestClusterReport(t *testing.T) {
+	cg := &ClusterGenerator{first: 1, last: 2}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Concurrency: 2}, Report: util.NewReport("generate")}
+	cg.generateParallel(t.Context(), opts, func(release *vclusterRelease) error {
+		release.phase = "install"
+		release.installNamespace = "vcluster-" + strconv.Itoa(release.index)
+		release.releaseSuffix = "suffix-" + strconv.Itoa(release.index)
+		if release.index == 2 {
+			return errors.New("install failed")
+		}
+		release.phase = "create"
+		release.name = "test-1"
+		release.uri = "https://vcluster-1.vcluster-1.svc:443"
+		return nil
+	}, func(release *vclusterRelease, err error) {
+		opts.Report.ClusterResult("clusters", cg.clusterResult(release, err))
+	})
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, opts.Report.Write(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var report map[string]any
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "generate", report["command"])
+	for _, key := range []string{"startedAt", "finishedAt"} {
+		_, err := time.Parse(time.RFC3339Nano, report[key].(string))
+		require.NoError(t, err, key)
+	}
+	assert.IsType(t, float64(0), report["durationSeconds"])
+	phases := report["phases"].([]any)
+	require.Len(t, phases, 1)
+	clusters := phases[0].(map[string]any)["clusters"].([]any)
+	require.Len(t, clusters, 2)
+	generated, failed := clusters[0].(map[string]any), clusters[1].(map[string]any)
+	assert.IsType(t, float64(0), generated["durationSeconds"])
+	delete(generated, "durationSeconds")
+	delete(failed, "durationSeconds")
+	assert.Equal(t, map[string]any{
+		"index":         float64(1),
+		"name":          "test-1",
+		"server":        "https://vcluster-1.vcluster-1.svc:443",
+		"namespace":     "vcluster-1",
+		"releaseSuffix": "suffix-1",
+		"success":       true,
+		"phase":         "create",
+	}, generated)
+	assert.Equal(t, map[string]any{
+		"index":         float64(2),
+		"namespace":     "vcluster-2",
+		"releaseSuffix": "suffix-2",
+		"success":       false,
+		"phase":         "install",
+		"error":         "install failed",
+	}, failed)
+}
+
+func TestCleanFromReport(t *testing.T) {
+	generated := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-1", Labels: maps.Clone(labels)}}
+	existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-existing"}}
+	clientSet, argoDB := newTestDB(t, generated, existing)
+	_, err := argoDB.CreateCluster(t.Context(), &argoappv1.Cluster{Server: "https://vcluster-1.vcluster-1.svc:443", Name: "test-1", Labels: maps.Clone(labels)})
+	require.NoError(t, err)
+	report := util.NewReport("generate")
+	report.ClusterResult("clusters", util.ClusterResult{Index: 1, Server: "https://vcluster-1.vcluster-1.svc:443", Namespace: "vcluster-1", Success: true})
+	report.ClusterResult("clusters", util.ClusterResult{Index: 2, Namespace: "vcluster-existing", Success: false})
+	report.ClusterResult("clusters", util.ClusterResult{Index: 3, Namespace: "vcluster-gone", Success: false})
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, report.Write(path))
+
+	cg := &ClusterGenerator{db: argoDB}
+	opts := &util.GenerateOpts{Namespace: "argocd", CleanReportPath: path}
+	require.NoError(t, cg.cleanFromReport(t.Context(), opts, clientSet))
+
+	_, err = argoDB.GetCluster(t.Context(), "https://vcluster-1.vcluster-1.svc:443")
+	require.Error(t, err)
+	namespaces, err := clientSet.CoreV1().Namespaces().List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vcluster-existing"}, objectNames(namespaces.Items))
+}
+
+func TestClusterLabels(t *testing.T) {
+	clientSet, argoDB := newTestDB(t)
+	cg := &ClusterGenerator{db: argoDB, first: 1, last: 1}
+	opts := &util.GenerateOpts{Namespace: "argocd", ClusterOpts: util.ClusterOpts{
+		ServerURLTemplate: "https://cluster-{{index}}.test.svc:6443",
+		ClusterNamePrefix: "test",
+		Labels:            map[string]string{"team": "load-test", generatedByLabel: "someone-else"},
+		Annotations:       map[string]string{"cost-center": "1234"},
+	}}
+	require.NoError(t, cg.generateFromTemplate(t.Context(), &vclusterRelease{index: 1}, opts, argoappv1.ClusterConfig{}))
+
+	secrets, err := clientSet.CoreV1().Secrets("argocd").List(t.Context(), metav1.ListOptions{LabelSelector: util.GeneratedBySelector})
+	require.NoError(t, err)
+	require.Len(t, secrets.Items, 1)
+	assert.Equal(t, "load-test", secrets.Items[0].Labels["team"])
+	assert.Equal(t, "1234", secrets.Items[0].Annotations["cost-center"])
+	assert.Equal(t, map[string]string{"team": "load-test", generatedByLabel: "someone-else"}, opts.ClusterOpts.Labels)
+}
+
+func TestClusterFailuresNone(t *testing.T) {
+	require.NoError(t, newClusterFailures(3).err())
+}
+
+func TestGetClusterCredentialsExecTimeout(t *testing.T) {
+	// the API server never answers the exec
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	// the execs given up on are still waiting for their connection to be upgraded
+	defer server.CloseClientConnections()
+	config := &rest.Config{Host: server.URL}
+	clientSet, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+	cg := &ClusterGenerator{clientSet: clientSet, config: config}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{PodPrefix: "vcluster", SyncerContainer: "syncer", ExecTimeout: 100 * time.Millisecond}}
+
+	t.Run("Timeout", func(t *testing.T) {
+		_, err := cg.getClusterCredentials(t.Context(), opts, "vcluster-abc", "abc")
+		require.ErrorContains(t, err, "exec in container syncer of pod vcluster-abc-0 in namespace vcluster-abc timed out after 100ms")
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		started := time.Now()
+		_, err := cg.getClusterCredentials(ctx, opts, "vcluster-abc", "abc")
+		require.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "exec in container syncer of pod vcluster-abc-0 in namespace vcluster-abc interrupted")
+		assert.Less(t, time.Since(started), opts.ClusterOpts.ExecTimeout)
+	})
+}
+
+func TestParseKubeconfigAnchors(t *testing.T) {
+	config, err := parseKubeconfig([]byte(`apiVersion: v1
+kind: Config
+current-context: vcluster
+clusters:
+- name: vcluster
+  cluster: &cluster
+    server: https://localhost:8443
+    certificate-authority-data: Y2E=
+- name: vcluster-copy
+  cluster:
+    <<: *cluster
+    server: https://localhost:9443
+contexts:
+- name: vcluster
+  context:
+    cluster: vcluster-copy
+    user: admin
+users:
+- name: admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+`))
+	require.NoError(t, err)
+	credentials, err := kubeconfigCredentials(config)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ca"), credentials.CAData)
+	assert.Equal(t, "https://localhost:9443", config.Clusters["vcluster-copy"].Server)
+}
+
+func TestParseKubeconfigInvalidData(t *testing.T) {
+	_, err := parseKubeconfig([]byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: vcluster
+  cluster:
+    server: https://localhost:8443
+    certificate-authority-data: Y2E=
+users:
+- name: admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: "!key"
+`))
+	require.ErrorContains(t, err, "decoding client-key-data of user admin: illegal base64 data at input byte 0")
+}
+
+func TestKubeconfigCredentials(t *testing.T) {
+	encode := func(data string) string {
+		return base64.StdEncoding.EncodeToString([]byte(data))
+	}
+
+	t.Run("ClientCertificate", func(t *testing.T) {
+		config, err := parseKubeconfig([]byte(`
+clusters:
+- name: my-vcluster
+  cluster:
+    server: https://localhost:8443
+    certificate-authority-data: ` + encode("ca") + `
+users:
+- name: my-vcluster
+  user:
+    client-certificate-data: ` + encode("cert") + `
+    client-key-data: ` + encode("key") + `
+contexts:
+- name: my-vcluster
+  context:
+    cluster: my-vcluster
+    user: my-vcluster
+current-context: my-vcluster
+`))
+		require.NoError(t, err)
+		clusterConfig, err := kubeconfigCredentials(config)
+		require.NoError(t, err)
+		assert.Equal(t, argoappv1.ClusterConfig{TLSClientConfig: argoappv1.TLSClientConfig{
+			CAData:   []byte("ca"),
+			CertData: []byte("cert"),
+			KeyData:  []byte("key"),
+		}}, clusterConfig)
+	})
+
+	t.Run("BearerToken", func(t *testing.T) {
+		config, err := parseKubeconfig([]byte(`
+clusters:
+- name: my-vcluster
+  cluster:
+    server: https://localhost:8443
+    certificate-authority-data: ` + encode("ca") + `
+users:
+- name: my-vcluster
+  user:
+    token: my-token
+contexts:
+- name: my-vcluster
+  context:
+    cluster: my-vcluster
+    user: my-vcluster
+`))
+		require.NoError(t, err)
+		clusterConfig, err := kubeconfigCredentials(config)
+		require.NoError(t, err)
+		assert.Equal(t, argoappv1.ClusterConfig{
+			BearerToken:     "my-token",
+			TLSClientConfig: argoappv1.TLSClientConfig{CAData: []byte("ca")},
+		}, clusterConfig)
+	})
+
+	t.Run("CurrentContext", func(t *testing.T) {
+		config, err := parseKubeconfig([]byte(`
+clusters:
+- name: host
+  cluster:
+    server: https://host:6443
+    certificate-authority-data: ` + encode("host-ca") + `
+- name: my-vcluster
+  cluster:
+    server: https://localhost:8443
+    certificate-authority-data: ` + encode("ca") + `
+users:
+- name: host
+  user:
+    token: host-token
+- name: my-vcluster
+  user:
+    token: my-token
+contexts:
+- name: host
+  context:
+    cluster: host
+    user: host
+- name: my-vcluster
+  context:
+    cluster: my-vcluster
+    user: my-vcluster
+current-context: my-vcluster
+`))
+		require.NoError(t, err)
+		clusterConfig, err := kubeconfigCredentials(config)
+		require.NoError(t, err)
+		assert.Equal(t, argoappv1.ClusterConfig{
+			BearerToken:     "my-token",
+			TLSClientConfig: argoappv1.TLSClientConfig{CAData: []byte("ca")},
+		}, clusterConfig)
+	})
+
+	t.Run("NoUser", func(t *testing.T) {
+		config, err := parseKubeconfig([]byte(`
+clusters:
+- name: my-vcluster
+  cluster:
+    server: https://localhost:8443
+contexts:
+- name: my-vcluster
+  context:
+    cluster: my-vcluster
+    user: my-vcluster
+current-context: my-vcluster
+`))
+		require.NoError(t, err)
+		_, err = kubeconfigCredentials(config)
+		require.EqualError(t, err, "user my-vcluster of context my-vcluster not found in kubeconfig")
+	})
+
+	t.Run("CertificateFile", func(t *testing.T) {
+		config, err := parseKubeconfig([]byte(`
+clusters:
+- name: my-vcluster
+  cluster:
+    server: https://localhost:8443
+    certificate-authority: /data/ca.crt
+users:
+- name: my-vcluster
+  user:
+    token: my-token
+contexts:
+- name: my-vcluster
+  context:
+    cluster: my-vcluster
+    user: my-vcluster
+current-context: my-vcluster
+`))
+		require.NoError(t, err)
+		_, err = kubeconfigCredentials(config)
+		require.EqualError(t, err, "certificate-authority of cluster my-vcluster references file /data/ca.crt, only inline data is supported")
+	})
+}
+
+func TestClusterKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{KubeconfigPath: path}}
+	for _, name := range []string{"test-a", "test-b"} {
+		kubeconfig, err := newClusterKubeconfig(opts)
+		require.NoError(t, err)
+		kubeconfig.add(&argoappv1.Cluster{
+			Name:       name,
+			Server:     "https://vcluster-" + name + ".vcluster.svc:443",
+			Namespaces: []string{"apps"},
+			Config:     argoappv1.ClusterConfig{BearerToken: "token-" + name},
+		})
+		require.NoError(t, kubeconfig.write(discardLogger, path))
+	}
+
+	config, err := clientcmd.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Len(t, config.Contexts, 2)
+	assert.Equal(t, "apps", config.Contexts["test-a"].Namespace)
+	assert.Equal(t, "https://vcluster-test-a.vcluster.svc:443", config.Clusters["test-a"].Server)
+	assert.Equal(t, "token-test-b", config.AuthInfos["test-b"].Token)
+}
+
+func TestAdaptConcurrency(t *testing.T) {
+	wg := util.New(4)
+	throttled := apierrors.NewTooManyRequests("throttled", 1)
+	assert.True(t, adaptConcurrency(discardLogger, &wg, 4, throttled))
+	assert.Equal(t, 2, wg.Limit())
+	assert.False(t, adaptConcurrency(discardLogger, &wg, 4, nil))
+	assert.Equal(t, 3, wg.Limit())
+	assert.False(t, adaptConcurrency(discardLogger, &wg, 3, nil))
+	assert.Equal(t, 3, wg.Limit())
+}
+
+func TestCleanNamespaces(t *testing.T) {
+	clientSet := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-abc", Labels: maps.Clone(labels)}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-prod"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	)
+	require.NoError(t, cleanNamespaces(t.Context(), discardLogger, &util.GenerateOpts{}, clientSet))
+
+	namespaces, err := clientSet.CoreV1().Namespaces().List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	var names []string
+	for _, ns := range namespaces.Items {
+		names = append(names, ns.Name)
+	}
+	assert.ElementsMatch(t, []string{"vcluster-prod", "default"}, names)
+}
+
+func TestCleanRunID(t *testing.T) {
+	newClientSet := func() *fake.Clientset {
+		return fake.NewClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-a", Labels: generatedLabels(&util.GenerateOpts{RunID: "run-a"})}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-b", Labels: generatedLabels(&util.GenerateOpts{RunID: "run-b"})}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		)
+	}
+	namespaceNames := func(t *testing.T, clientSet *fake.Clientset) []string {
+		t.Helper()
+		namespaces, err := clientSet.CoreV1().Namespaces().List(t.Context(), metav1.ListOptions{})
+		require.NoError(t, err)
+		return objectNames(namespaces.Items)
+	}
+
+	t.Run("Scoped", func(t *testing.T) {
+		clientSet := newClientSet()
+		var deletedSecrets []string
+		clientSet.PrependReactor("delete-collection", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			deletedSecrets = append(deletedSecrets, action.(clienttesting.DeleteCollectionAction).GetListRestrictions().Labels.String())
+			return true, nil, nil
+		})
+		cg := &ClusterGenerator{clientSet: clientSet}
+		// the helm releases are not uninstalled when the clean is scoped to a run
+		opts := &util.GenerateOpts{Namespace: "argocd", RunID: "run-a", ClusterOpts: util.ClusterOpts{Concurrency: 2}}
+		require.NoError(t, cg.Clean(t.Context(), opts))
+
+		assert.Equal(t, []string{"default", "vcluster-b"}, namespaceNames(t, clientSet))
+		assert.Equal(t, []string{"app.kubernetes.io/generated-by=argocd-generator,argocd-generator/run-id=run-a,argocd.argoproj.io/secret-type=cluster"}, deletedSecrets)
+	})
+
+	t.Run("Unscoped", func(t *testing.T) {
+		clientSet := newClientSet()
+		require.NoError(t, cleanNamespaces(t.Context(), discardLogger, &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Concurrency: 2}}, clientSet))
+		assert.Equal(t, []string{"default"}, namespaceNames(t, clientSet))
+	})
+}
+
+func TestVClusterTLSConfig(t *testing.T) {
+	credentials := argoappv1.ClusterConfig{TLSClientConfig: argoappv1.TLSClientConfig{CAData: []byte("ca"), CertData: []byte("cert")}}
+
+	t.Run("Default", func(t *testing.T) {
+		config := vclusterTLSConfig(&util.GenerateOpts{}, 3, credentials)
+		assert.Equal(t, defaultServerName, config.ServerName)
+		assert.False(t, config.Insecure)
+		assert.Equal(t, []byte("ca"), config.CAData)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{ServerName: "vcluster-{{index}}.test.svc", InsecureTLS: true, Proxy: "http://proxy.example.com:3128"}}
+		config := vclusterTLSConfig(opts, 3, credentials)
+		assert.Equal(t, "vcluster-3.test.svc", config.ServerName)
+		assert.Equal(t, "http://proxy.example.com:3128", config.ProxyUrl)
+		assert.True(t, config.Insecure)
+		assert.Nil(t, config.CAData)
+		assert.Equal(t, []byte("cert"), config.CertData)
+		assert.Equal(t, []byte("ca"), credentials.CAData)
+	})
+}
+
+func TestCleanNamespacesDryRun(t *testing.T) {
+	clientSet := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-abc", Labels: maps.Clone(labels)}})
+	require.NoError(t, cleanNamespaces(t.Context(), discardLogger, &util.GenerateOpts{DryRun: true}, clientSet))
+
+	_, err := clientSet.CoreV1().Namespaces().Get(t.Context(), "vcluster-abc", metav1.GetOptions{})
+	require.NoError(t, err)
+}
+
+func TestCleanNamespacesFailures(t *testing.T) {
+	clientSet := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-a", Labels: maps.Clone(labels)}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-b", Labels: maps.Clone(labels)}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-c", Labels: maps.Clone(labels)}},
+	)
+	clientSet.PrependReactor("delete", "namespaces", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if name := action.(clienttesting.DeleteAction).GetName(); name != "vcluster-b" {
+			return true, nil, apierrors.NewForbidden(corev1.Resource("namespaces"), name, errors.New("denied"))
+		}
+		return false, nil, nil
+	})
+	err := cleanNamespaces(t.Context(), discardLogger, &util.GenerateOpts{ClusterOpts: util.ClusterOpts{Concurrency: 2}}, clientSet)
+	require.ErrorContains(t, err, "failed to delete namespace vcluster-a")
+	require.ErrorContains(t, err, "failed to delete namespace vcluster-c")
+
+	namespaces, err := clientSet.CoreV1().Namespaces().List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vcluster-a", "vcluster-c"}, objectNames(namespaces.Items))
+}
+
+func TestWaitForNamespacesDeleted(t *testing.T) {
+	t.Run("Gone", func(t *testing.T) {
+		clientSet := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-a", Labels: maps.Clone(labels)}})
+		// the namespace terminates for the first check
+		var gets atomic.Int32
+		clientSet.PrependReactor("get", "namespaces", func(clienttesting.Action) (bool, runtime.Object, error) {
+			if gets.Add(1) == 1 {
+				return true, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-a"}}, nil
+			}
+			return false, nil, nil
+		})
+		opts := &util.GenerateOpts{CleanWait: true, CleanWaitTimeout: time.Minute, ClusterOpts: util.ClusterOpts{Concurrency: 2}}
+		require.NoError(t, cleanNamespaces(t.Context(), discardLogger, opts, clientSet))
+		assert.Equal(t, int32(2), gets.Load())
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		clientSet := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-a"}})
+		err := waitForNamespacesDeleted(t.Context(), discardLogger, clientSet, []string{"vcluster-a"}, 100*time.Millisecond)
+		require.ErrorContains(t, err, "namespaces [vcluster-a] are still terminating")
+	})
+
+	t.Run("None", func(t *testing.T) {
+		require.NoError(t, waitForNamespacesDeleted(t.Context(), discardLogger, fake.NewClientset(), nil, time.Minute))
+	})
+}
+
+func TestDiscoverServerVersion(t *testing.T) {
+	t.Run("Discovered", func(t *testing.T) {
+		client := fake.NewClientset().Discovery().(*fakediscovery.FakeDiscovery)
+		client.FakedServerVersion = &version.Info{Major: "1", Minor: "30+"}
+		assert.Equal(t, "1.30", discoverServerVersion(discardLogger, client, "https://vcluster-abc.vcluster-abc.svc:443"))
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		client := fake.NewClientset().Discovery().(*fakediscovery.FakeDiscovery)
+		client.PrependReactor("get", "version", func(clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		assert.Equal(t, defaultServerVersion, discoverServerVersion(discardLogger, client, "https://vcluster-abc.vcluster-abc.svc:443"))
+	})
+}
+
+func TestVerifyConnection(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"30"}`))
+	}))
+	defer server.Close()
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	t.Run("Successful", func(t *testing.T) {
+		cg := &ClusterGenerator{stages: newClusterStages()}
+		opts := &util.GenerateOpts{Report: util.NewReport("generate"), ClusterOpts: util.ClusterOpts{VerifyConnection: true}}
+		release := &vclusterRelease{index: 1, uri: server.URL}
+		cluster := &argoappv1.Cluster{Server: server.URL, Config: argoappv1.ClusterConfig{
+			BearerToken:     "token",
+			TLSClientConfig: argoappv1.TLSClientConfig{CAData: caData},
+		}}
+		cg.verifyClusterConnection(t.Context(), opts, release, cluster)
+
+		assert.Equal(t, argoappv1.ConnectionStatusSuccessful, cluster.Info.ConnectionState.Status)
+		result := cg.clusterResult(release, nil)
+		assert.Equal(t, "Successful", result.ConnectionStatus)
+		assert.Positive(t, result.ConnectionLatencySeconds)
+		assert.Equal(t, map[string]int{"Successful": 1}, opts.Report.Phases[0].Distributions["verifiedConnection"])
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		cg := &ClusterGenerator{}
+		opts := &util.GenerateOpts{Report: util.NewReport("generate"), ClusterOpts: util.ClusterOpts{VerifyConnection: true}}
+		release := &vclusterRelease{index: 1, uri: server.URL}
+		// the certificate of the server is not trusted
+		cluster := &argoappv1.Cluster{Server: server.URL, Config: argoappv1.ClusterConfig{BearerToken: "token"}}
+		cg.verifyClusterConnection(t.Context(), opts, release, cluster)
+
+		assert.Equal(t, argoappv1.ConnectionStatusFailed, cluster.Info.ConnectionState.Status)
+		assert.Contains(t, cluster.Info.ConnectionState.Message, "certificate")
+		assert.Equal(t, "Failed", cg.clusterResult(release, nil).ConnectionStatus)
+		assert.Equal(t, map[string]int{"Failed": 1}, opts.Report.Phases[0].Distributions["verifiedConnection"])
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cg := &ClusterGenerator{}
+		release := &vclusterRelease{index: 1, uri: server.URL}
+		cg.verifyClusterConnection(t.Context(), &util.GenerateOpts{}, release, &argoappv1.Cluster{Server: server.URL})
+		assert.Empty(t, cg.clusterResult(release, nil).ConnectionStatus)
+	})
+}
+
+func TestClassifyRetry(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, waiting string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "vcluster-abc"}, Status: corev1.PodStatus{Phase: phase}}
+		if waiting != "" {
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "syncer", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waiting}}}}
+		}
+		return p
+	}
+	clientSet := fake.NewClientset(
+		pod("running", corev1.PodRunning, ""),
+		pod("failed", corev1.PodFailed, ""),
+		pod("crashing", corev1.PodRunning, "CrashLoopBackOff"),
+	)
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{PermanentErrors: []string{"x509"}}}
+	notFound := apierrors.NewNotFound(corev1.Resource("pods"), "missing")
+
+	for _, tc := range []struct {
+		pod       string
+		err       error
+		permanent bool
+	}{
+		{"missing", notFound, false},
+		{"running", errors.New("connection refused"), false},
+		{"running", errors.New(`container not found ("syncer")`), true},
+		{"running", errors.New("x509: certificate signed by unknown authority"), true},
+		{"failed", errors.New("connection refused"), true},
+		{"crashing", errors.New("connection refused"), true},
+	} {
+		permanent, reason := classifyRetry(t.Context(), opts, clientSet, "vcluster-abc", tc.pod, tc.err)
+		assert.Equal(t, tc.permanent, permanent, "pod %s with error %v, classified as %s", tc.pod, tc.err, reason)
+	}
+}
+
+func TestWaitForPodReady(t *testing.T) {
+	pod := func(ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "vcluster-abc-0", Namespace: "vcluster-abc"},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	t.Run("AlreadyReady", func(t *testing.T) {
+		clientSet := fake.NewClientset(pod(corev1.ConditionTrue))
+		require.NoError(t, waitForPodReady(t.Context(), clientSet, "vcluster-abc", "vcluster-abc-0"))
+	})
+
+	t.Run("BecomesReady", func(t *testing.T) {
+		clientSet := fake.NewClientset(pod(corev1.ConditionFalse))
+		watcher := watch.NewFake()
+		clientSet.PrependWatchReactor("pods", clienttesting.DefaultWatchReactor(watcher, nil))
+		go func() {
+			watcher.Modify(pod(corev1.ConditionFalse))
+			watcher.Modify(pod(corev1.ConditionTrue))
+		}()
+		require.NoError(t, waitForPodReady(t.Context(), clientSet, "vcluster-abc", "vcluster-abc-0"))
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		clientSet := fake.NewClientset(pod(corev1.ConditionFalse))
+		watcher := watch.NewFake()
+		clientSet.PrependWatchReactor("pods", clienttesting.DefaultWatchReactor(watcher, nil))
+		failed := pod(corev1.ConditionFalse)
+		failed.Status.Phase = corev1.PodFailed
+		go watcher.Modify(failed)
+		require.EqualError(t, waitForPodReady(t.Context(), clientSet, "vcluster-abc", "vcluster-abc-0"), "pod vcluster-abc-0 in namespace vcluster-abc failed")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		clientSet := fake.NewClientset(pod(corev1.ConditionFalse))
+		watcher := watch.NewFake()
+		clientSet.PrependWatchReactor("pods", clienttesting.DefaultWatchReactor(watcher, nil))
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, waitForPodReady(ctx, clientSet, "vcluster-abc", "vcluster-abc-0"), context.DeadlineExceeded)
+	})
+}
+
+func TestGetClusterServerURI(t *testing.T) {
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{PodPrefix: "vcluster"}}
+
+	t.Run("PodFound", func(t *testing.T) {
+		clientSet := fake.NewClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "vcluster-abc-0", Namespace: "vcluster-abc"},
+			Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+		})
+		cg := &ClusterGenerator{clientSet: clientSet}
+		uri, err := cg.getClusterServerURI(t.Context(), opts, "vcluster-abc", "abc")
+		require.NoError(t, err)
+		assert.Equal(t, "https://10.0.0.1:8443", uri)
+	})
+
+	t.Run("PodNotFound", func(t *testing.T) {
+		cg := &ClusterGenerator{clientSet: fake.NewClientset()}
+		_, err := cg.getClusterServerURI(t.Context(), opts, "vcluster-abc", "abc")
+		require.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestRetrieveClusterURI(t *testing.T) {
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{PodPrefix: "vcluster", ServicePort: 443}}
+	pod := func(phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "vcluster-abc-0", Namespace: "vcluster-abc"},
+			Status:     corev1.PodStatus{Phase: phase, PodIP: "10.0.0.1"},
+		}
+	}
+
+	t.Run("Service", func(t *testing.T) {
+		clientSet := fake.NewClientset(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "vcluster-abc", Namespace: "vcluster-abc"},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 443}}},
+		})
+		cg := &ClusterGenerator{clientSet: clientSet}
+		assert.Equal(t, "https://vcluster-abc.vcluster-abc.svc:443", cg.retrieveClusterURI(t.Context(), opts, "vcluster-abc", "abc"))
+	})
+
+	t.Run("RetriedUntilPodFound", func(t *testing.T) {
+		clientSet := fake.NewClientset(pod(corev1.PodRunning))
+		var gets int
+		clientSet.PrependReactor("get", "pods", func(clienttesting.Action) (bool, runtime.Object, error) {
+			gets++
+			if gets == 1 {
+				return true, nil, apierrors.NewNotFound(corev1.Resource("pods"), "vcluster-abc-0")
+			}
+			return false, nil, nil
+		})
+		cg := &ClusterGenerator{clientSet: clientSet}
+		assert.Equal(t, "https://10.0.0.1:8443", cg.retrieveClusterURI(t.Context(), opts, "vcluster-abc", "abc"))
+		assert.Equal(t, 2, gets)
+	})
+
+	t.Run("PermanentError", func(t *testing.T) {
+		clientSet := fake.NewClientset(pod(corev1.PodFailed))
+		var gets int
+		clientSet.PrependReactor("get", "services", func(clienttesting.Action) (bool, runtime.Object, error) {
+			gets++
+			return true, nil, errors.New("connection refused")
+		})
+		cg := &ClusterGenerator{clientSet: clientSet}
+		assert.Empty(t, cg.retrieveClusterURI(t.Context(), opts, "vcluster-abc", "abc"))
+		assert.Equal(t, 1, gets)
+	})
+}
+
+func TestRegisterCreatesCluster(t *testing.T) {
+	argoDB := dbmocks.NewArgoDB(t)
+	var created *argoappv1.Cluster
+	argoDB.EXPECT().CreateCluster(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, cluster *argoappv1.Cluster) (*argoappv1.Cluster, error) {
+		created = cluster
+		return cluster, nil
+	}).Once()
+	cg := &ClusterGenerator{db: argoDB, first: 1, last: 1}
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{ClusterNamePrefix: "test", DestinationNamespace: "apps", ServerVersions: []string{"1.30"}}}
+	// the credentials are the ones of the vcluster backend
+	credentials := vclusterTLSConfig(opts, 1, argoappv1.ClusterConfig{BearerToken: "token"})
+	release := &vclusterRelease{index: 1, version: "1.30", uri: "https://vcluster-abc.vcluster-abc.svc:443", credentials: credentials}
+	require.NoError(t, cg.register(t.Context(), opts, release))
+
+	require.NotNil(t, created)
+	assert.Equal(t, "https://vcluster-abc.vcluster-abc.svc:443", created.Server)
+	assert.Equal(t, release.name, created.Name)
+	assert.Equal(t, "token", created.Config.BearerToken)
+	assert.Equal(t, defaultServerName, created.Config.ServerName)
+	assert.Equal(t, "1.30", created.Info.ServerVersion)
+	assert.Equal(t, []string{"apps"}, created.Namespaces)
+	assert.Equal(t, labels, created.Labels)
+}
+
+func TestClean(t *testing.T) {
+	generatedLabels := map[string]string{generatedByLabel: "argocd-generator", "run": "1"}
+	clientSet := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-abc", Labels: generatedLabels}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-other", Labels: maps.Clone(labels)}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-team"}},
+	)
+	var deletedSecrets []string
+	clientSet.PrependReactor("delete-collection", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		deleteAction := action.(clienttesting.DeleteCollectionAction)
+		deletedSecrets = append(deletedSecrets, deleteAction.GetNamespace()+" "+deleteAction.GetListRestrictions().Labels.String())
+		return true, nil, nil
+	})
+	cg := &ClusterGenerator{clientSet: clientSet}
+	// the helm releases are not uninstalled when the clean is restricted by a selector
+	opts := &util.GenerateOpts{Namespace: "argocd", CleanSelector: "run=1", ClusterOpts: util.ClusterOpts{Concurrency: 2}}
+	require.NoError(t, cg.Clean(t.Context(), opts))
+
+	namespaces, err := clientSet.CoreV1().Namespaces().List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vcluster-other", "vcluster-team"}, objectNames(namespaces.Items))
+	assert.Equal(t, []string{"argocd app.kubernetes.io/generated-by=argocd-generator,argocd.argoproj.io/secret-type=cluster,run=1"}, deletedSecrets)
+}
+
+func TestClusterSecretNamespace(t *testing.T) {
+	clientSet, _ := newTestDB(t)
+	// the settings of Argo CD are read from its namespace and the cluster secrets are written to the other one
+	argoDB := db.NewDB("tenant", settings.NewSettingsManager(t.Context(), clientSet, "argocd"), clientSet)
+	var deletedSecrets []string
+	clientSet.PrependReactor("delete-collection", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		deletedSecrets = append(deletedSecrets, action.GetNamespace())
+		return true, nil, nil
+	})
+	cg := &ClusterGenerator{db: argoDB, clientSet: clientSet, first: 1, last: 1}
+	opts := &util.GenerateOpts{Namespace: "argocd", CleanSelector: "run=1", ClusterOpts: util.ClusterOpts{
+		ServerURLTemplate:      "https://cluster-{{index}}.test.svc:6443",
+		ClusterNamePrefix:      "test",
+		ClusterSecretNamespace: "tenant",
+		Concurrency:            2,
+	}}
+	require.NoError(t, cg.generateFromTemplate(t.Context(), &vclusterRelease{index: 1}, opts, argoappv1.ClusterConfig{}))
+
+	secrets, err := clientSet.CoreV1().Secrets("tenant").List(t.Context(), metav1.ListOptions{LabelSelector: util.GeneratedBySelector})
+	require.NoError(t, err)
+	assert.Len(t, secrets.Items, 1)
+	count, err := cg.countGeneratedClusters(t.Context(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, cg.Clean(t.Context(), opts))
+	assert.Equal(t, []string{"tenant"}, deletedSecrets)
+}
+
+func TestClusterBackend(t *testing.T) {
+	cg := &ClusterGenerator{}
+	assert.IsType(t, &vclusterBackend{}, cg.clusterBackend(&util.GenerateOpts{ClusterOpts: util.ClusterOpts{Backend: "vcluster"}}))
+	assert.IsType(t, &gkeBackend{}, cg.clusterBackend(&util.GenerateOpts{ClusterOpts: util.ClusterOpts{Backend: "gke"}}))
+}
+
+func TestGKEBackend(t *testing.T) {
+	opts := &util.GenerateOpts{RunID: "Run-A", ClusterOpts: util.ClusterOpts{Concurrency: 2, GKEOpts: util.GKEOpts{
+		Project:     "load-test",
+		Location:    "europe-west1-b",
+		NamePrefix:  "argocd-generator",
+		MachineType: "e2-small",
+		NumNodes:    1,
+	}}}
+	var lock sync.Mutex
+	var calls []string
+	backend := &gkeBackend{logger: discardLogger, gcloud: func(_ context.Context, env []string, args ...string) ([]byte, error) {
+		lock.Lock()
+		calls = append(calls, strings.Join(args, " "))
+		lock.Unlock()
+		switch args[2] {
+		case "get-credentials":
+			kubeconfig := clientcmdapi.NewConfig()
+			kubeconfig.Clusters["gke"] = &clientcmdapi.Cluster{Server: "https://34.1.2.3", CertificateAuthorityData: []byte("ca")}
+			kubeconfig.AuthInfos["gke"] = &clientcmdapi.AuthInfo{Exec: &clientcmdapi.ExecConfig{Command: "gke-gcloud-auth-plugin"}}
+			kubeconfig.Contexts["gke"] = &clientcmdapi.Context{Cluster: "gke", AuthInfo: "gke"}
+			kubeconfig.CurrentContext = "gke"
+			return nil, clientcmd.WriteToFile(*kubeconfig, strings.TrimPrefix(env[0], "KUBECONFIG="))
+		case "list":
+			return []byte("argocd-generator-a europe-west1-b\nargocd-generator-b us-central1\n"), nil
+		}
+		return nil, nil
+	}}
+
+	t.Run("Provision", func(t *testing.T) {
+		calls = nil
+		release := &vclusterRelease{index: 1}
+		require.NoError(t, backend.Provision(t.Context(), opts, release))
+		assert.Regexp(t, `^argocd-generator-[a-z0-9]{12}$`, release.managedCluster)
+		assert.Equal(t, []string{"container clusters create " + release.managedCluster + " --project load-test --location europe-west1-b --machine-type e2-small --num-nodes 1 --labels generated-by=argocd-generator,argocd-generator-run-id=run-a --quiet"}, calls)
+	})
+
+	t.Run("Credentials", func(t *testing.T) {
+		calls = nil
+		release := &vclusterRelease{index: 1, managedCluster: "argocd-generator-a"}
+		require.NoError(t, backend.Credentials(t.Context(), opts, release))
+		assert.Equal(t, []string{"container clusters get-credentials argocd-generator-a --project load-test --location europe-west1-b"}, calls)
+		assert.Equal(t, "https://34.1.2.3", release.uri)
+		assert.Equal(t, []byte("ca"), release.credentials.CAData)
+		require.NotNil(t, release.credentials.ExecProviderConfig)
+		assert.Equal(t, "argocd-k8s-auth", release.credentials.ExecProviderConfig.Command)
+		assert.Equal(t, []string{"gcp"}, release.credentials.ExecProviderConfig.Args)
+	})
+
+	t.Run("Teardown", func(t *testing.T) {
+		calls = nil
+		require.NoError(t, backend.Teardown(t.Context(), opts))
+		require.Len(t, calls, 3)
+		assert.Equal(t, "container clusters list --project load-test --filter resourceLabels.generated-by=argocd-generator AND resourceLabels.argocd-generator-run-id=run-a --format value(name,location)", calls[0])
+		assert.ElementsMatch(t, []string{
+			"container clusters delete argocd-generator-a --project load-test --location europe-west1-b --quiet",
+			"container clusters delete argocd-generator-b --project load-test --location us-central1 --quiet",
+		}, calls[1:])
+	})
+
+	t.Run("TeardownRestricted", func(t *testing.T) {
+		calls = nil
+		restricted := *opts
+		restricted.CleanSelector = "team=a"
+		require.NoError(t, backend.Teardown(t.Context(), &restricted))
+		assert.Empty(t, calls)
+	})
+}
+
+func TestChartArgs(t *testing.T) {
+	t.Run("ChartRepository", func(t *testing.T) {
+		opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{
+			ChartRepo:     "https://charts.example.com",
+			ChartName:     "vcluster",
+			ChartVersion:  "0.19.5",
+			ChartUsername: "user",
+			ChartPassword: "password",
+		}}
+		assert.Equal(t, []string{"vcluster", "--repo", "https://charts.example.com", "--repository-config", "", "--username", "user", "--password", "password", "--version", "0.19.5"}, chartArgs(opts))
+	})
+
+	t.Run("OCIRegistry", func(t *testing.T) {
+		opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{
+			ChartRepo:     "oci://registry.example.com/charts/",
+			ChartName:     "vcluster",
+			ChartVersion:  "0.19.5",
+			ChartUsername: "user",
+			ChartPassword: "password",
+		}}
+		assert.Equal(t, []string{"oci://registry.example.com/charts/vcluster", "--version", "0.19.5"}, chartArgs(opts))
+	})
+}
+
+func TestInstallArgs(t *testing.T) {
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{ChartRepo: "oci://registry.example.com/charts", ChartName: "vcluster"}}
+	t.Run("NoValuesFile", func(t *testing.T) {
+		assert.Equal(t, []string{"upgrade", "--install", "vcluster-abc", "oci://registry.example.com/charts/vcluster", "--namespace", "vcluster-abc", "--create-namespace", "--wait"}, installArgs(opts, "vcluster-abc", "vcluster-abc"))
+	})
+
+	t.Run("ValuesFile", func(t *testing.T) {
+		opts := &util.GenerateOpts{ClusterOpts: opts.ClusterOpts}
+		opts.ClusterOpts.ValuesFilePath = "values.yaml"
+		opts.ClusterOpts.HelmTimeout = 5 * time.Minute
+		assert.Equal(t, []string{"upgrade", "--install", "vcluster-abc", "oci://registry.example.com/charts/vcluster", "--values", "values.yaml", "--namespace", "vcluster-abc", "--create-namespace", "--wait", "--timeout", "5m0s"}, installArgs(opts, "vcluster-abc", "vcluster-abc"))
+	})
+}
+
+func TestCreateHelmDir(t *testing.T) {
+	cg := &ClusterGenerator{}
+	removeHelmDir, err := cg.createHelmDir()
+	require.NoError(t, err)
+	cmd, err := newHelmCmd(cg.helmDir)
+	require.NoError(t, err)
+	assert.Equal(t, cg.helmDir, filepath.Dir(cmd.WorkDir))
+
+	removeHelmDir()
+	assert.NoDirExists(t, cg.helmDir)
+}
+
+func TestIsHelmTimeout(t *testing.T) {
+	assert.True(t, isHelmTimeout(errors.New("Error: UPGRADE FAILED: timed out waiting for the condition")))
+	assert.True(t, isHelmTimeout(errors.New("Error: INSTALLATION FAILED: context deadline exceeded")))
+	assert.False(t, isHelmTimeout(errors.New(`Error: INSTALLATION FAILED: failed to do request: Head "https://registry.example.com/v2/charts/vcluster/manifests/0.19.5": dial tcp: lookup registry.example.com: i/o timeout: request timed out`)))
+	assert.False(t, isHelmTimeout(errors.New(`Error: INSTALLATION FAILED: chart "vcluster" not found`)))
+}
+
+func TestNewHelmCmd(t *testing.T) {
+	runDir := t.TempDir()
+	first, err := newHelmCmd(runDir)
+	require.NoError(t, err)
+	second, err := newHelmCmd(runDir)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.WorkDir, second.WorkDir)
+	assert.Equal(t, runDir, filepath.Dir(first.WorkDir))
+
+	first.Close()
+	second.Close()
+	entries, err := os.ReadDir(runDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestEnsureService(t *testing.T) {
+	opts := &util.GenerateOpts{ClusterOpts: util.ClusterOpts{PodPrefix: "vcluster", ServicePort: 443}}
+
+	t.Run("Missing", func(t *testing.T) {
+		clientSet := fake.NewClientset()
+		require.NoError(t, ensureService(t.Context(), discardLogger, opts, clientSet, "vcluster-abc", "abc"))
+		service, err := clientSet.CoreV1().Services("vcluster-abc").Get(t.Context(), "vcluster-abc", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, labels, service.Labels)
+		assert.Equal(t, map[string]string{"statefulset.kubernetes.io/pod-name": "vcluster-abc-0"}, service.Spec.Selector)
+		assert.Equal(t, int32(443), service.Spec.Ports[0].Port)
+		assert.Equal(t, int32(8443), service.Spec.Ports[0].TargetPort.IntVal)
+	})
+
+	t.Run("CreatedByChart", func(t *testing.T) {
+		chartService := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "vcluster-abc", Namespace: "vcluster-abc"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "vcluster"}},
+		}
+		clientSet := fake.NewClientset(chartService)
+		require.NoError(t, ensureService(t.Context(), discardLogger, opts, clientSet, "vcluster-abc", "abc"))
+		service, err := clientSet.CoreV1().Services("vcluster-abc").Get(t.Context(), "vcluster-abc", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, chartService, service)
+	})
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+const (
+	// gkeGeneratedByLabel and gkeRunIDLabel are the labels of the GKE clusters of the generator, the labels of Google
+	// Cloud do not allow the keys of the Kubernetes labels
+	gkeGeneratedByLabel = "generated-by"
+	gkeRunIDLabel       = "argocd-generator-run-id"
+	// gkeSuffixLength is the length of the random suffix of the names of the GKE clusters
+	gkeSuffixLength = 12
+)
+
+// gkeBackend creates a GKE cluster per cluster with gcloud container clusters create, and registers it with the gcp
+// auth of argocd-k8s-auth
+type gkeBackend struct {
+	// gcloud runs gcloud with the given arguments and additional environment, and returns its combined output
+	gcloud func(ctx context.Context, env []string, args ...string) ([]byte, error)
+	logger *slog.Logger
+}
+
+func newGKEBackend(logger *slog.Logger) *gkeBackend {
+	return &gkeBackend{gcloud: runGcloud, logger: logger}
+}
+
+func runGcloud(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "gcloud", args...)
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return out, fmt.Errorf("gcloud %s failed: %w: %s", strings.Join(args[:min(len(args), 3)], " "), err, strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
+
+// gkeLabels returns the labels of the GKE clusters of the run, as the value of --labels
+func gkeLabels(opts *util.GenerateOpts) string {
+	gkeLabels := []string{gkeGeneratedByLabel + "=" + labels[generatedByLabel]}
+	if opts.RunID != "" {
+		// the values of the labels of Google Cloud are lowercase
+		gkeLabels = append(gkeLabels, gkeRunIDLabel+"="+strings.ToLower(opts.RunID))
+	}
+	return strings.Join(gkeLabels, ",")
+}
+
+// createArgs returns the arguments of gcloud creating the GKE cluster with the given name
+func createArgs(opts *util.GenerateOpts, name string) []string {
+	gkeOpts := opts.ClusterOpts.GKEOpts
+	args := []string{
+		"container", "clusters", "create", name,
+		"--project", gkeOpts.Project,
+		"--location", gkeOpts.Location,
+		"--machine-type", gkeOpts.MachineType,
+		"--num-nodes", strconv.Itoa(gkeOpts.NumNodes),
+		"--labels", gkeLabels(opts),
+		"--quiet",
+	}
+	return append(args, gkeOpts.ExtraArgs...)
+}
+
+func (b *gkeBackend) Provision(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	release.phase = "install"
+	release.managedCluster = opts.ClusterOpts.GKEOpts.NamePrefix + "-" + util.GetRandomString()[:gkeSuffixLength]
+	if opts.DryRun {
+		b.logger.Info("Dry run: would create GKE cluster", "cluster", release.index, "name", release.managedCluster, "location", opts.ClusterOpts.GKEOpts.Location)
+		return nil
+	}
+	b.logger.Info("Create GKE cluster", "cluster", release.index, "name", release.managedCluster, "location", opts.ClusterOpts.GKEOpts.Location)
+	if _, err := b.gcloud(ctx, nil, createArgs(opts, release.managedCluster)...); err != nil {
+		return fmt.Errorf("failed to create GKE cluster %s: %w", release.managedCluster, err)
+	}
+	opts.Report.Distribution("clusters", "backend", "gke", 1)
+	return nil
+}
+
+// Credentials reads the endpoint and the CA of the GKE cluster from the kubeconfig written by gcloud container clusters
+// get-credentials. The user of the kubeconfig runs gke-gcloud-auth-plugin on the machine of the generator, the cluster
+// is registered with argocd-k8s-auth instead, which is in the image of Argo CD.
+func (b *gkeBackend) Credentials(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	release.phase = "extract"
+	gkeOpts := opts.ClusterOpts.GKEOpts
+	if opts.DryRun {
+		release.uri = "https://" + release.managedCluster
+		b.logger.Info("Dry run: would get the credentials of GKE cluster", "cluster", release.index, "name", release.managedCluster)
+		return nil
+	}
+	dir, err := os.MkdirTemp("", "argocd-generator-gke-")
+	if err != nil {
+		return fmt.Errorf("failed to create the directory of the kubeconfig of GKE cluster %s: %w", release.managedCluster, err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "kubeconfig")
+	args := []string{"container", "clusters", "get-credentials", release.managedCluster, "--project", gkeOpts.Project, "--location", gkeOpts.Location}
+	if _, err := b.gcloud(ctx, []string{"KUBECONFIG=" + path}, args...); err != nil {
+		return fmt.Errorf("failed to get the credentials of GKE cluster %s: %w", release.managedCluster, err)
+	}
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the kubeconfig of GKE cluster %s: %w", release.managedCluster, err)
+	}
+	kubeContext, ok := kubeconfig.Contexts[kubeconfig.CurrentContext]
+	if !ok {
+		return fmt.Errorf("kubeconfig of GKE cluster %s has no current context", release.managedCluster)
+	}
+	cluster, ok := kubeconfig.Clusters[kubeContext.Cluster]
+	if !ok {
+		return fmt.Errorf("cluster %s of the kubeconfig of GKE cluster %s not found", kubeContext.Cluster, release.managedCluster)
+	}
+	release.uri = cluster.Server
+	release.credentials = argoappv1.ClusterConfig{
+		TLSClientConfig: argoappv1.TLSClientConfig{CAData: cluster.CertificateAuthorityData},
+		ExecProviderConfig: &argoappv1.ExecProviderConfig{
+			Command:    "argocd-k8s-auth",
+			Args:       []string{"gcp"},
+			APIVersion: "client.authentication.k8s.io/v1beta1",
+		},
+		ProxyUrl: opts.ClusterOpts.Proxy,
+	}
+	b.logger.Info("Cluster server uri of GKE cluster", "cluster", release.index, "name", release.managedCluster, "server", release.uri)
+	return nil
+}
+
+// Teardown deletes the GKE clusters of the generator, of the run if a run id is set. The clusters are not deleted when
+// the clean is restricted by a selector, the labels of the GKE clusters are not the ones of the generated objects.
+func (b *gkeBackend) Teardown(ctx context.Context, opts *util.GenerateOpts) error {
+	if opts.CleanSelector != "" && opts.CleanSelector != util.GeneratedBySelector {
+		b.logger.Info("Skip deleting GKE clusters, the clean is restricted", "selector", opts.CleanSelector)
+		return nil
+	}
+	filter := "resourceLabels." + gkeGeneratedByLabel + "=" + labels[generatedByLabel]
+	if opts.RunID != "" {
+		filter += " AND resourceLabels." + gkeRunIDLabel + "=" + strings.ToLower(opts.RunID)
+	}
+	out, err := b.gcloud(ctx, nil, "container", "clusters", "list", "--project", opts.ClusterOpts.GKEOpts.Project, "--filter", filter, "--format", "value(name,location)")
+	if err != nil {
+		return fmt.Errorf("failed to list the GKE clusters: %w", err)
+	}
+	// the locations of the clusters by name, the clusters listed are in all the locations of the project
+	locations := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 {
+			locations[fields[0]] = fields[1]
+		}
+	}
+	names := slices.Sorted(maps.Keys(locations))
+	b.logger.Info("Delete GKE clusters", "count", len(names), "filter", filter)
+	if dryRunDelete(opts, "GKE clusters", names) {
+		return nil
+	}
+	var lock sync.Mutex
+	failed := map[string]error{}
+	wg := util.New(max(opts.ClusterOpts.Concurrency, 1))
+	for _, name := range names {
+		wg.Add()
+		go func(name string) {
+			defer wg.Done()
+			_, err := b.gcloud(ctx, nil, "container", "clusters", "delete", name, "--project", opts.ClusterOpts.GKEOpts.Project, "--location", locations[name], "--quiet")
+			if err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+				failed[name] = err
+			}
+		}(name)
+	}
+	wg.Wait()
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(failed)) {
+		errs = append(errs, fmt.Errorf("failed to delete GKE cluster %s: %w", name, failed[name]))
+	}
+	return errors.Join(errs...)
+}
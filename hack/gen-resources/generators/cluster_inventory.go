@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+// inventoryWeightColumn is the column of a CSV inventory holding the weight of the rows
+const inventoryWeightColumn = "weight"
+
+// inventoryRow is a label set of the inventory, assigned to the generated clusters
+type inventoryRow struct {
+	Labels map[string]string `yaml:"labels"`
+	// Weight is the relative share of the clusters given the labels with the Weighted assignment, defaults to 1
+	Weight int `yaml:"weight"`
+}
+
+// clusterInventory assigns the label sets of an inventory file to the generated clusters
+type clusterInventory struct {
+	rows     []inventoryRow
+	weighted bool
+
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+// loadClusterInventory loads the inventory of the options, nil if none is configured. The file is YAML, a list of
+// labels and weights, or CSV, whose columns are the label keys and the optional weight column.
+func loadClusterInventory(opts *util.GenerateOpts) (*clusterInventory, error) {
+	inventoryOpts := opts.ClusterOpts.InventoryOpts
+	if inventoryOpts.Path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(inventoryOpts.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster inventory: %w", err)
+	}
+	var rows []inventoryRow
+	if strings.EqualFold(filepath.Ext(inventoryOpts.Path), ".csv") {
+		rows, err = parseCSVInventory(data)
+	} else {
+		err = yaml.UnmarshalStrict(data, &rows)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cluster inventory %s: %w", inventoryOpts.Path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("cluster inventory %s has no rows", inventoryOpts.Path)
+	}
+	var errs []error
+	for i := range rows {
+		if rows[i].Weight == 0 {
+			rows[i].Weight = 1
+		}
+		if rows[i].Weight < 0 {
+			errs = append(errs, fmt.Errorf("row %d: weight must not be negative", i+1))
+		}
+		for _, key := range slices.Sorted(maps.Keys(rows[i].Labels)) {
+			for _, msg := range validation.IsQualifiedName(key) {
+				errs = append(errs, fmt.Errorf("row %d: invalid label key %q: %s", i+1, key, msg))
+			}
+			for _, msg := range validation.IsValidLabelValue(rows[i].Labels[key]) {
+				errs = append(errs, fmt.Errorf("row %d: invalid value %q of label %s: %s", i+1, rows[i].Labels[key], key, msg))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid cluster inventory %s: %w", inventoryOpts.Path, err)
+	}
+	return &clusterInventory{
+		rows:     rows,
+		weighted: inventoryOpts.Assignment == "Weighted",
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+func parseCSVInventory(data []byte) ([]inventoryRow, error) {
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	header := records[0]
+	var rows []inventoryRow
+	for l, record := range records[1:] {
+		row := inventoryRow{Labels: map[string]string{}}
+		for c, value := range record {
+			if header[c] != inventoryWeightColumn {
+				// an empty value does not set the label
+				if value != "" {
+					row.Labels[header[c]] = value
+				}
+				continue
+			}
+			if value == "" {
+				continue
+			}
+			if row.Weight, err = strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("row %d: invalid weight %q: %w", l+1, value, err)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// labels returns the index of the row assigned to the cluster with the given index and its labels, the rows being
+// assigned in order or picked randomly by weight
+func (inventory *clusterInventory) labels(i int) (int, map[string]string) {
+	if !inventory.weighted {
+		row := (i - 1) % len(inventory.rows)
+		return row, inventory.rows[row].Labels
+	}
+	total := 0
+	for _, row := range inventory.rows {
+		total += row.Weight
+	}
+	inventory.lock.Lock()
+	pick := inventory.rand.Intn(total)
+	inventory.lock.Unlock()
+	for r, row := range inventory.rows {
+		if pick < row.Weight {
+			return r, row.Labels
+		}
+		pick -= row.Weight
+	}
+	return len(inventory.rows) - 1, inventory.rows[len(inventory.rows)-1].Labels
+}
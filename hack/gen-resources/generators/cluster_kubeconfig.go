@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// clusterKubeconfig collects the credentials of the generated clusters into a kubeconfig with a context per cluster,
+// so that they can be inspected with kubectl without reading the kubeconfig of their vcluster again
+type clusterKubeconfig struct {
+	lock   sync.Mutex
+	config *clientcmdapi.Config
+	added  int
+}
+
+// newClusterKubeconfig returns the kubeconfig the generated clusters are added to, or nil if KubeconfigPath is empty.
+// The kubeconfig is loaded from KubeconfigPath if it exists, so that the clusters of the previous runs are kept.
+func newClusterKubeconfig(opts *util.GenerateOpts) (*clusterKubeconfig, error) {
+	path := opts.ClusterOpts.KubeconfigPath
+	if path == "" {
+		return nil, nil
+	}
+	config, err := clientcmd.LoadFromFile(path)
+	if os.IsNotExist(err) {
+		config, err = clientcmdapi.NewConfig(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+	return &clusterKubeconfig{config: config}, nil
+}
+
+// add adds a context named after the cluster, with its server and credentials, replacing the one of a previous run
+func (k *clusterKubeconfig) add(cluster *argoappv1.Cluster) {
+	if k == nil {
+		return
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.config.Clusters[cluster.Name] = &clientcmdapi.Cluster{
+		Server:                   cluster.Server,
+		CertificateAuthorityData: cluster.Config.CAData,
+		InsecureSkipTLSVerify:    cluster.Config.Insecure,
+		TLSServerName:            cluster.Config.ServerName,
+	}
+	k.config.AuthInfos[cluster.Name] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: cluster.Config.CertData,
+		ClientKeyData:         cluster.Config.KeyData,
+		Token:                 cluster.Config.BearerToken,
+	}
+	context := &clientcmdapi.Context{Cluster: cluster.Name, AuthInfo: cluster.Name}
+	if len(cluster.Namespaces) > 0 {
+		context.Namespace = cluster.Namespaces[0]
+	}
+	k.config.Contexts[cluster.Name] = context
+	k.added++
+}
+
+// write writes the kubeconfig to the given path, readable by the user only as it holds the credentials of the clusters
+func (k *clusterKubeconfig) write(logger *slog.Logger, path string) error {
+	if k == nil {
+		return nil
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if err := clientcmd.WriteToFile(*k.config, path); err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	logger.Info("Wrote the contexts of the generated clusters to kubeconfig", "count", k.added, "path", path)
+	return nil
+}
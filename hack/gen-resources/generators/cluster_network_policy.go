@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+const (
+	// denyAllNetworkPolicy is the name of the policy denying all the traffic of a vcluster namespace
+	denyAllNetworkPolicy = "argocd-generator-deny-all"
+	// allowNetworkPolicy is the name of the policy allowing the traffic the vcluster needs
+	allowNetworkPolicy = "argocd-generator-allow"
+)
+
+func networkPort(protocol corev1.Protocol, port int) networkingv1.NetworkPolicyPort {
+	p := intstr.FromInt(port)
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p}
+}
+
+// vclusterNetworkPolicies returns the policies isolating the given vcluster namespace: all the traffic is denied but
+// the traffic within the namespace, the ingress from Argo CD, and the egress to DNS and to the API server of the host
+func vclusterNetworkPolicies(opts *util.GenerateOpts, namespace string) []*networkingv1.NetworkPolicy {
+	ingressAndEgress := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}
+	sameNamespace := networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}}
+	argoCDNamespace := networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{
+		MatchLabels: map[string]string{corev1.LabelMetadataName: opts.Namespace},
+	}}
+	return []*networkingv1.NetworkPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: denyAllNetworkPolicy, Namespace: namespace, Labels: generatedLabels(opts)},
+			Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: ingressAndEgress},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: allowNetworkPolicy, Namespace: namespace, Labels: generatedLabels(opts)},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: ingressAndEgress,
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{From: []networkingv1.NetworkPolicyPeer{sameNamespace, argoCDNamespace}},
+				},
+				Egress: []networkingv1.NetworkPolicyEgressRule{
+					{To: []networkingv1.NetworkPolicyPeer{sameNamespace}},
+					{Ports: []networkingv1.NetworkPolicyPort{networkPort(corev1.ProtocolUDP, 53), networkPort(corev1.ProtocolTCP, 53)}},
+					// the syncer of the vcluster talks to the API server of the host, whose address cannot be selected
+					{Ports: []networkingv1.NetworkPolicyPort{networkPort(corev1.ProtocolTCP, 443), networkPort(corev1.ProtocolTCP, 6443)}},
+				},
+			},
+		},
+	}
+}
+
+// isolateNamespace applies the network policies isolating the given vcluster namespace
+func (cg *ClusterGenerator) isolateNamespace(ctx context.Context, opts *util.GenerateOpts, namespace string) error {
+	policies := cg.clientSet.NetworkingV1().NetworkPolicies(namespace)
+	for _, policy := range vclusterNetworkPolicies(opts, namespace) {
+		_, err := policies.Create(ctx, policy, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			var existing *networkingv1.NetworkPolicy
+			if existing, err = policies.Get(ctx, policy.Name, metav1.GetOptions{}); err == nil {
+				policy.ResourceVersion = existing.ResourceVersion
+				_, err = policies.Update(ctx, policy, metav1.UpdateOptions{})
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply network policy %s in namespace %s: %w", policy.Name, namespace, err)
+		}
+	}
+	cg.log().Debug("Isolated namespace with network policies", "namespace", namespace)
+	return nil
+}
+
+// cleanNetworkPolicies deletes the network policies isolating the vcluster namespaces
+func (cg *ClusterGenerator) cleanNetworkPolicies(ctx context.Context, opts *util.GenerateOpts) error {
+	listOpts := metav1.ListOptions{LabelSelector: cleanSelector(opts)}
+	policies, err := cg.clientSet.NetworkingV1().NetworkPolicies(metav1.NamespaceAll).List(ctx, listOpts)
+	if err != nil {
+		return err
+	}
+	if dryRunDelete(opts, "network policies", objectNames(policies.Items)) {
+		return nil
+	}
+	deleted := 0
+	for _, policy := range policies.Items {
+		err := cg.clientSet.NetworkingV1().NetworkPolicies(policy.Namespace).Delete(ctx, policy.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete network policy %s in namespace %s: %w", policy.Name, policy.Namespace, err)
+		}
+		deleted++
+	}
+	cg.log().Info("Deleted network policies", "count", deleted, "selector", listOpts.LabelSelector)
+	opts.Report.Distribution("clusters", "networkPolicies", "deleted", deleted)
+	return nil
+}
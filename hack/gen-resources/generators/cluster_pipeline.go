@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+// pipelineStage is a stage of the generation of the vclusters, run by a pool of workers
+type pipelineStage struct {
+	name        string
+	concurrency int
+	run         func(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error
+
+	lock      sync.Mutex
+	processed int
+	busy      time.Duration
+}
+
+func (stage *pipelineStage) process(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	started := time.Now()
+	err := stage.run(ctx, opts, release)
+	stage.lock.Lock()
+	defer stage.lock.Unlock()
+	stage.busy += time.Since(started)
+	if err == nil {
+		stage.processed++
+	}
+	return err
+}
+
+// start runs the workers of the stage on the releases of in, and returns the channel of the releases they processed
+// successfully, closed once in is closed and drained. Failed releases are passed to record.
+func (stage *pipelineStage) start(ctx context.Context, opts *util.GenerateOpts, in <-chan *vclusterRelease, record func(release *vclusterRelease, err error)) <-chan *vclusterRelease {
+	out := make(chan *vclusterRelease)
+	var wg sync.WaitGroup
+	for w := 0; w < stage.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for release := range in {
+				if err := stage.process(ctx, opts, release); err != nil {
+					record(release, err)
+					continue
+				}
+				out <- release
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// generatePipeline generates the vclusters through bounded install, extract and create pools connected by channels,
+// and logs the throughput of each stage. Like generateParallel, it gives up on the clusters still in flight once the
+// deadline of the context is reached, and returns their number.
+func (cg *ClusterGenerator) generatePipeline(ctx context.Context, opts *util.GenerateOpts, backend ClusterBackend, record func(release *vclusterRelease, err error)) int {
+	pipelineOpts := opts.ClusterOpts.PipelineOpts
+	stages := []*pipelineStage{
+		{name: "install", concurrency: pipelineOpts.InstallConcurrency, run: backend.Provision},
+		{name: "extract", concurrency: max(pipelineOpts.ExtractConcurrency, 1), run: backend.Credentials},
+		{name: "create", concurrency: max(pipelineOpts.CreateConcurrency, 1), run: cg.register},
+	}
+	cg.log().Info("Generate clusters through a pipeline", "install", stages[0].concurrency, "extract", stages[1].concurrency, "create", stages[2].concurrency)
+
+	// inFlight counts the releases fed to the pipeline whose result is not recorded yet
+	var inFlight atomic.Int64
+	finish := func(release *vclusterRelease, err error) {
+		record(release, err)
+		inFlight.Add(-1)
+	}
+	started := time.Now()
+	releases := make(chan *vclusterRelease)
+	go func() {
+		defer close(releases)
+		for i := cg.first; i <= cg.last; i++ {
+			if i > cg.first {
+				stagger(ctx, opts)
+			}
+			if ctx.Err() != nil {
+				cg.log().Warn("Generation interrupted", "notStarted", cg.last-i+1)
+				break
+			}
+			cg.log().Info("Generate cluster", "cluster", i, "of", cg.last)
+			inFlight.Add(1)
+			select {
+			case releases <- &vclusterRelease{index: i, version: serverVersion(opts, i), started: time.Now()}:
+			case <-ctx.Done():
+				inFlight.Add(-1)
+				cg.log().Warn("Generation interrupted", "notStarted", cg.last-i+1)
+				return
+			}
+		}
+		reportStartSpread(cg.log(), opts, time.Since(started))
+	}()
+	var out <-chan *vclusterRelease = releases
+	for _, stage := range stages {
+		out = stage.start(ctx, opts, out, finish)
+	}
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for release := range out {
+			finish(release, nil)
+		}
+	}()
+	var stuck int
+	if deadline, ok := ctx.Deadline(); ok {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		select {
+		case <-drained:
+		case <-timer.C:
+			if stuck = int(inFlight.Load()); stuck > 0 {
+				cg.log().Warn("Generation timed out", "inFlight", stuck)
+			}
+		}
+	} else {
+		<-drained
+	}
+
+	elapsed := time.Since(started)
+	for _, stage := range stages {
+		// the workers of the clusters given up on may still be running
+		stage.lock.Lock()
+		processed, busy := stage.processed, stage.busy
+		stage.lock.Unlock()
+		throughput := 0.0
+		if elapsed > 0 {
+			throughput = float64(processed) / elapsed.Minutes()
+		}
+		cg.log().Info("Stage processed the clusters", "stage", stage.name, "processed", processed, "elapsed", elapsed.Round(time.Second), "workers", stage.concurrency, "clustersPerMinute", throughput, "busy", busy.Round(time.Second))
+		opts.Report.Distribution("clusters", "pipelineStage", stage.name, processed)
+	}
+	return stuck
+}
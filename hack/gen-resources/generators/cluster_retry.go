@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+// permanentErrors are the messages of the errors reading the credentials and the URI of a vcluster which retrying does
+// not fix, see PermanentErrors for the configured ones
+var permanentErrors = []string{
+	"container not found",
+	"cannot exec into a container in a completed pod",
+}
+
+// permanentWaitingReasons are the reasons a container of the pod of a vcluster is waiting for which retrying does not fix
+var permanentWaitingReasons = []string{
+	"CrashLoopBackOff",
+	"ImagePullBackOff",
+	"ErrImagePull",
+	"InvalidImageName",
+	"CreateContainerConfigError",
+}
+
+// classifyRetry returns whether the error reading the credentials or the URI of the vcluster with the given pod is
+// permanent, so that it is not retried, along with the reason of the decision. The pod is not found while the vcluster
+// is starting, the error is permanent if it matches a permanent error, or if the pod failed or one of its containers
+// waits for a permanent reason.
+func classifyRetry(ctx context.Context, opts *util.GenerateOpts, clientSet kubernetes.Interface, namespace, podName string, err error) (bool, string) {
+	if apierrors.IsNotFound(err) {
+		return false, "not found yet"
+	}
+	for _, message := range append(slices.Clone(permanentErrors), opts.ClusterOpts.PermanentErrors...) {
+		if strings.Contains(err.Error(), message) {
+			return true, fmt.Sprintf("error contains %q", message)
+		}
+	}
+	pod, getErr := clientSet.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if getErr != nil {
+		return false, "pod state unknown"
+	}
+	if pod.Status.Phase == corev1.PodFailed {
+		return true, fmt.Sprintf("pod %s failed", podName)
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && slices.Contains(permanentWaitingReasons, status.State.Waiting.Reason) {
+			return true, fmt.Sprintf("container %s of pod %s is in %s", status.Name, podName, status.State.Waiting.Reason)
+		}
+	}
+	return false, "transient"
+}
+
+// podReady returns whether the PodReady condition of the pod is true
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// waitForPodReady watches the given pod until its PodReady condition is true, so that the credentials of its vcluster
+// are read once its syncer is up instead of retrying blindly. It fails if the pod fails, or once the context is done.
+// The watch is restarted from a fresh list if it is closed by the API server.
+func waitForPodReady(ctx context.Context, clientSet kubernetes.Interface, namespace, podName string) error {
+	pods := clientSet.CoreV1().Pods(namespace)
+	selector := fields.OneTermEqualSelector("metadata.name", podName).String()
+	for {
+		list, err := pods.List(ctx, metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			return fmt.Errorf("failed to list pod %s in namespace %s: %w", podName, namespace, err)
+		}
+		for i := range list.Items {
+			if done, err := podReadyOrFailed(&list.Items[i]); done {
+				return err
+			}
+		}
+		watcher, err := pods.Watch(ctx, metav1.ListOptions{FieldSelector: selector, ResourceVersion: list.ResourceVersion})
+		if err != nil {
+			return fmt.Errorf("failed to watch pod %s in namespace %s: %w", podName, namespace, err)
+		}
+		done, err := watchPodReady(ctx, watcher, namespace, podName)
+		watcher.Stop()
+		if done {
+			return err
+		}
+	}
+}
+
+// watchPodReady consumes the events of the watch of the pod until it is ready, fails or the context is done. It
+// returns false if the watch is closed before.
+func watchPodReady(ctx context.Context, watcher watch.Interface, namespace, podName string) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return true, fmt.Errorf("pod %s in namespace %s is not ready: %w", podName, namespace, ctx.Err())
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			if event.Type == watch.Error {
+				return true, fmt.Errorf("failed to watch pod %s in namespace %s: %w", podName, namespace, apierrors.FromObject(event.Object))
+			}
+			pod, isPod := event.Object.(*corev1.Pod)
+			if !isPod || event.Type == watch.Deleted {
+				continue
+			}
+			if done, err := podReadyOrFailed(pod); done {
+				return true, err
+			}
+		}
+	}
+}
+
+// podReadyOrFailed returns whether the wait for the pod is over, with an error if the pod failed
+func podReadyOrFailed(pod *corev1.Pod) (bool, error) {
+	if pod.Status.Phase == corev1.PodFailed {
+		return true, fmt.Errorf("pod %s in namespace %s failed", pod.Name, pod.Namespace)
+	}
+	return podReady(pod), nil
+}
+
+// waitForVClusterPod waits for the pod of the vcluster of the release to be ready, bounded by PodReadyTimeout
+func (cg *ClusterGenerator) waitForVClusterPod(ctx context.Context, opts *util.GenerateOpts, release *vclusterRelease) error {
+	defer cg.stages.record("podReady", time.Now())
+	podName := opts.ClusterOpts.PodPrefix + "-" + release.releaseSuffix + "-0"
+	if opts.ClusterOpts.PodReadyTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.ClusterOpts.PodReadyTimeout)
+		defer cancel()
+	}
+	cg.log().Debug("Wait for vcluster pod to be ready", "cluster", release.index, "pod", podName, "namespace", release.installNamespace)
+	return waitForPodReady(ctx, cg.clientSet, release.installNamespace, podName)
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+// vclusterPort is the port the API server of the vclusters listens on in their pod
+const vclusterPort = 8443
+
+// vclusterService returns the service exposing the API server of the pod of the vcluster with the given release suffix
+// on ServicePort, named like the service of the chart
+func vclusterService(opts *util.GenerateOpts, namespace, releaseSuffix string) *corev1.Service {
+	name := opts.ClusterOpts.PodPrefix + "-" + releaseSuffix
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: generatedLabels(opts)},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			// the pod of the statefulset of the chart keeps its name when it is recreated
+			Selector: map[string]string{"statefulset.kubernetes.io/pod-name": name + "-0"},
+			Ports: []corev1.ServicePort{{
+				Name:       "https",
+				Port:       int32(opts.ClusterOpts.ServicePort),
+				TargetPort: intstr.FromInt32(vclusterPort),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+// ensureService creates the service of the vcluster with the given release suffix if the chart did not create one, so
+// that the registered server of the cluster does not change when its pod is restarted
+func ensureService(ctx context.Context, logger *slog.Logger, opts *util.GenerateOpts, clientSet kubernetes.Interface, namespace, releaseSuffix string) error {
+	service := vclusterService(opts, namespace, releaseSuffix)
+	services := clientSet.CoreV1().Services(namespace)
+	_, err := services.Get(ctx, service.Name, metav1.GetOptions{})
+	if err == nil {
+		opts.Report.Distribution("clusters", "service", "chart", 1)
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get service %s in namespace %s: %w", service.Name, namespace, err)
+	}
+	_, err = services.Create(ctx, service, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		opts.Report.Distribution("clusters", "service", "chart", 1)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create service %s in namespace %s: %w", service.Name, namespace, err)
+	}
+	logger.Info("Created service, the chart did not create one", "service", service.Name, "namespace", namespace)
+	opts.Report.Distribution("clusters", "service", "created", 1)
+	return nil
+}
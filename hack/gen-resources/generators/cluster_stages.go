@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+// clusterStages accumulates the durations of the stages of the generation of the clusters, helm install, pod readiness,
+// credentials, server URI, readiness, creation and connection verification, across the goroutines generating them
+type clusterStages struct {
+	lock   sync.Mutex
+	stages map[string]*util.StageStats
+}
+
+func newClusterStages() *clusterStages {
+	return &clusterStages{stages: map[string]*util.StageStats{}}
+}
+
+// record records the duration of the given stage of a cluster, which started at the given time. The stages may be nil.
+func (s *clusterStages) record(stage string, started time.Time) {
+	if s == nil {
+		return
+	}
+	seconds := time.Since(started).Seconds()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stats := s.stages[stage]
+	if stats == nil {
+		stats = &util.StageStats{}
+		s.stages[stage] = stats
+	}
+	stats.Count++
+	stats.TotalSeconds += seconds
+	stats.MaxSeconds = max(stats.MaxSeconds, seconds)
+}
+
+// report logs the durations of the stages and records them in the report of the run
+func (s *clusterStages) report(logger *slog.Logger, opts *util.GenerateOpts) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, stage := range slices.Sorted(maps.Keys(s.stages)) {
+		stats := s.stages[stage]
+		logger.Info("Stage of the clusters", "stage", stage, "count", stats.Count, "totalSeconds", stats.TotalSeconds, "averageSeconds", stats.TotalSeconds/float64(stats.Count), "maxSeconds", stats.MaxSeconds)
+		opts.Report.Stage("clusters", stage, *stats)
+	}
+}
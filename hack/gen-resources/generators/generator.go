@@ -1,12 +1,142 @@
 package generator
 
-import "github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+const generatedByLabel = "app.kubernetes.io/generated-by"
+
+// runIDLabel labels the objects generated by a run with its RunID, so that clean can delete only them
+const runIDLabel = "argocd-generator/run-id"
 
 var labels = map[string]string{
-	"app.kubernetes.io/generated-by": "argocd-generator",
+	generatedByLabel: "argocd-generator",
+}
+
+// cleanSelector returns the label selector of the generated objects deleted by Clean, restricted by the clean selector
+// and the run id of the options and by the given requirements
+func cleanSelector(opts *util.GenerateOpts, requirements ...string) string {
+	selector := []string{util.GeneratedBySelector}
+	if opts.CleanSelector != "" && opts.CleanSelector != util.GeneratedBySelector {
+		selector = append(selector, opts.CleanSelector)
+	}
+	if opts.RunID != "" {
+		selector = append(selector, runIDLabel+"="+opts.RunID)
+	}
+	return strings.Join(append(selector, requirements...), ",")
+}
+
+// generatedLabels returns the labels of the objects generated by the run, the label of the generator and the one of
+// the RunID if any
+func generatedLabels(opts *util.GenerateOpts) map[string]string {
+	generated := maps.Clone(labels)
+	if opts.RunID != "" {
+		generated[runIDLabel] = opts.RunID
+	}
+	return generated
+}
+
+// dryRunDelete logs the generated objects of the given kind, by name, Clean would delete, and returns whether the run is
+// a dry run, in which case they must not be deleted
+func dryRunDelete(opts *util.GenerateOpts, kind string, names []string) bool {
+	if !opts.DryRun {
+		return false
+	}
+	log.Printf("Dry run: would delete %d %s %v", len(names), kind, names)
+	return true
+}
+
+// objectNames returns the names of the given objects, prefixed with their namespace if namespaced
+func objectNames[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) []string {
+	names := make([]string, 0, len(items))
+	for i := range items {
+		object := PT(&items[i])
+		if object.GetNamespace() != "" {
+			names = append(names, object.GetNamespace()+"/"+object.GetName())
+			continue
+		}
+		names = append(names, object.GetName())
+	}
+	return names
 }
 
 type Generator interface {
-	Generate(opts *util.GenerateOpts) error
-	Clean(opts *util.GenerateOpts) error
+	Generate(ctx context.Context, opts *util.GenerateOpts) error
+	Clean(ctx context.Context, opts *util.GenerateOpts) error
+}
+
+// RegisteredGenerator is a generator of a registry, along with the name of the phase of the report its objects are
+// recorded in
+type RegisteredGenerator struct {
+	Name      string
+	Phase     string
+	Generator Generator
+}
+
+// Registry is the set of generators of a run, keyed by name, e.g. cluster, run in the order they are registered
+type Registry struct {
+	generators []RegisteredGenerator
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register registers the generators of the given name, the objects of which are recorded in the given phase of the
+// report. The generators of the same name run in the order they are registered.
+func (r *Registry) Register(name, phase string, generators ...Generator) {
+	for _, generator := range generators {
+		r.generators = append(r.generators, RegisteredGenerator{Name: name, Phase: phase, Generator: generator})
+	}
+}
+
+// Names returns the names of the registered generators, in the order they are registered
+func (r *Registry) Names() []string {
+	var names []string
+	for _, generator := range r.generators {
+		if !slices.Contains(names, generator.Name) {
+			names = append(names, generator.Name)
+		}
+	}
+	return names
+}
+
+// Select returns the registered generators with one of the included names, all of them if none is included, and
+// without the excluded ones, along with the names of the skipped generators. Unknown names are reported as errors.
+func (r *Registry) Select(include, exclude []string) ([]RegisteredGenerator, []string, error) {
+	names := r.Names()
+	var errs []error
+	for _, name := range append(slices.Clone(include), exclude...) {
+		if !slices.Contains(names, name) {
+			errs = append(errs, fmt.Errorf("unknown generator %q, must be one of %s", name, strings.Join(names, ", ")))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	var selected []RegisteredGenerator
+	var skipped []string
+	for _, generator := range r.generators {
+		if (len(include) > 0 && !slices.Contains(include, generator.Name)) || slices.Contains(exclude, generator.Name) {
+			if !slices.Contains(skipped, generator.Name) {
+				skipped = append(skipped, generator.Name)
+			}
+			continue
+		}
+		selected = append(selected, generator)
+	}
+	return selected, skipped, nil
 }
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+)
+
+func TestRegistrySelect(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("project", "projects", &ProjectGenerator{})
+	registry.Register("repository", "repositories", &RepoGenerator{}, &RepositoryGenerator{})
+	registry.Register("cluster", "clusters", &ClusterGenerator{})
+	registry.Register("application", "applications", &ApplicationGenerator{})
+	names := func(generators []RegisteredGenerator) []string {
+		var names []string
+		for _, generator := range generators {
+			names = append(names, generator.Name)
+		}
+		return names
+	}
+
+	t.Run("All", func(t *testing.T) {
+		selected, skipped, err := registry.Select(nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"project", "repository", "repository", "cluster", "application"}, names(selected))
+		assert.Empty(t, skipped)
+	})
+
+	t.Run("Include", func(t *testing.T) {
+		selected, skipped, err := registry.Select([]string{"application"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"application"}, names(selected))
+		assert.Equal(t, []string{"project", "repository", "cluster"}, skipped)
+	})
+
+	t.Run("Exclude", func(t *testing.T) {
+		selected, skipped, err := registry.Select([]string{"repository", "cluster"}, []string{"cluster"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"repository", "repository"}, names(selected))
+		assert.Equal(t, []string{"project", "cluster", "application"}, skipped)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, _, err := registry.Select([]string{"app"}, nil)
+		require.EqualError(t, err, `unknown generator "app", must be one of project, repository, cluster, application`)
+	})
+}
+
+func TestGeneratedLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{generatedByLabel: "argocd-generator"}, generatedLabels(&util.GenerateOpts{}))
+	assert.Equal(t, map[string]string{generatedByLabel: "argocd-generator", runIDLabel: "run-a"}, generatedLabels(&util.GenerateOpts{RunID: "run-a"}))
+	// the labels of the generator are not modified
+	assert.Equal(t, map[string]string{generatedByLabel: "argocd-generator"}, labels)
+}
+
+func TestCleanSelector(t *testing.T) {
+	assert.Equal(t, "app.kubernetes.io/generated-by=argocd-generator", cleanSelector(&util.GenerateOpts{}))
+	assert.Equal(t, "app.kubernetes.io/generated-by=argocd-generator,team=a,argocd-generator/run-id=run-a,kind=x", cleanSelector(&util.GenerateOpts{CleanSelector: "team=a", RunID: "run-a"}, "kind=x"))
+}
@@ -2,8 +2,14 @@ package generator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -13,39 +19,130 @@ import (
 )
 
 type ProjectGenerator struct {
-	clientSet *appclientset.Clientset
+	clientSet appclientset.Interface
 }
 
-func NewProjectGenerator(clientSet *appclientset.Clientset) Generator {
+func NewProjectGenerator(clientSet appclientset.Interface) Generator {
 	return &ProjectGenerator{clientSet}
 }
 
-func (pg *ProjectGenerator) Generate(opts *util.GenerateOpts) error {
+// randomSyncWindows returns sync windows with random, valid, cron schedules and durations
+func randomSyncWindows(opts *util.GenerateOpts, seed *rand.Rand) (v1alpha1.SyncWindows, error) {
+	var windows v1alpha1.SyncWindows
+	for i := 0; i < opts.ProjectOpts.SyncWindows; i++ {
+		kind := "allow"
+		if seed.Intn(100) < opts.ProjectOpts.DenyWindowsPercent {
+			kind = "deny"
+		}
+		window := &v1alpha1.SyncWindow{
+			Kind:         kind,
+			Schedule:     fmt.Sprintf("%d %d * * %d", seed.Intn(60), seed.Intn(24), seed.Intn(7)),
+			Duration:     (time.Duration(1+seed.Intn(12*4)) * 15 * time.Minute).String(),
+			Applications: []string{"*"},
+			ManualSync:   seed.Intn(2) == 0,
+		}
+		if err := window.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid generated sync window: %w", err)
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// projectSpec returns the spec of a generated project with the given sync windows, restricted to the source
+// repositories, destinations and cluster-scoped resources of the options
+func projectSpec(opts *util.GenerateOpts, windows v1alpha1.SyncWindows) v1alpha1.AppProjectSpec {
+	spec := v1alpha1.AppProjectSpec{
+		Description: "generated-project",
+		SyncWindows: windows,
+		SourceRepos: slices.Clone(opts.ProjectOpts.SourceRepos),
+	}
+	for _, destination := range opts.ProjectOpts.Destinations {
+		spec.Destinations = append(spec.Destinations, v1alpha1.ApplicationDestination{
+			Server:    destination.Server,
+			Name:      destination.Name,
+			Namespace: destination.Namespace,
+		})
+	}
+	for _, groupKind := range opts.ProjectOpts.ClusterResourceAllowlist {
+		spec.ClusterResourceWhitelist = append(spec.ClusterResourceWhitelist, v1alpha1.ClusterResourceRestrictionItem{
+			Group: groupKind.Group,
+			Kind:  groupKind.Kind,
+		})
+	}
+	return spec
+}
+
+func (pg *ProjectGenerator) Generate(ctx context.Context, opts *util.GenerateOpts) error {
 	projects := pg.clientSet.ArgoprojV1alpha1().AppProjects(opts.Namespace)
+	seed := rand.New(rand.NewSource(time.Now().Unix()))
+	windowCount := 0
+	var lock sync.Mutex
+	failed := map[int]error{}
+	wg := util.New(opts.ProjectOpts.Concurrency)
 	for i := 0; i < opts.ProjectOpts.Samples; i++ {
-		log.Printf("Generate project #%v", i)
-		_, err := projects.Create(context.TODO(), &v1alpha1.AppProject{
-			ObjectMeta: metav1.ObjectMeta{
-				GenerateName: "project-",
-				Namespace:    opts.Namespace,
-				Labels:       labels,
-			},
-			Spec: v1alpha1.AppProjectSpec{
-				Description: "generated-project",
-			},
-		}, metav1.CreateOptions{})
+		// the windows are drawn before the project is created in parallel, the seed is not safe for concurrent use
+		windows, err := randomSyncWindows(opts, seed)
 		if err != nil {
-			log.Printf("Project #%v failed to generate", i)
-			return fmt.Errorf("error in generated-project: %w", err)
+			wg.Wait()
+			return err
+		}
+		if err := wg.AddWithContext(ctx); err != nil {
+			break
 		}
+		go func(i int, windows v1alpha1.SyncWindows) {
+			defer wg.Done()
+			log.Printf("Generate project #%v", i)
+			_, err := projects.Create(ctx, &v1alpha1.AppProject{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "project-",
+					Namespace:    opts.Namespace,
+					Labels:       labels,
+				},
+				Spec: projectSpec(opts, windows),
+			}, metav1.CreateOptions{})
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				opts.Report.Failed("projects", err)
+				log.Printf("Project #%v failed to generate", i)
+				failed[i] = fmt.Errorf("error in generated-project #%d: %w", i, err)
+				return
+			}
+			opts.Report.Created("projects", 1)
+			windowCount += len(windows)
+		}(i, windows)
 	}
-	return nil
+	wg.Wait()
+	if windowCount > 0 {
+		log.Printf("Generated %d sync windows in %d projects", windowCount, opts.ProjectOpts.Samples-len(failed))
+		opts.Report.Distribution("projects", "syncWindows", "total", windowCount)
+	}
+	var errs []error
+	for _, i := range slices.Sorted(maps.Keys(failed)) {
+		errs = append(errs, failed[i])
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("generation of the projects interrupted: %w", err))
+	}
+	return errors.Join(errs...)
 }
 
-func (pg *ProjectGenerator) Clean(opts *util.GenerateOpts) error {
+func (pg *ProjectGenerator) Clean(ctx context.Context, opts *util.GenerateOpts) error {
 	log.Printf("Clean projects")
 	projects := pg.clientSet.ArgoprojV1alpha1().AppProjects(opts.Namespace)
-	return projects.DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{
-		LabelSelector: "app.kubernetes.io/generated-by=argocd-generator",
-	})
+	listOpts := metav1.ListOptions{LabelSelector: cleanSelector(opts)}
+	matched, err := projects.List(ctx, listOpts)
+	if err != nil {
+		return err
+	}
+	log.Printf("Delete %d projects matching %s", len(matched.Items), listOpts.LabelSelector)
+	if dryRunDelete(opts, "projects", objectNames(matched.Items)) {
+		return nil
+	}
+	if err := projects.DeleteCollection(ctx, metav1.DeleteOptions{}, listOpts); err != nil {
+		return err
+	}
+	opts.Report.Deleted("projects", len(matched.Items))
+	return nil
 }
@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
 
 	"k8s.io/client-go/kubernetes"
@@ -23,11 +24,11 @@ type Repo struct {
 }
 
 type RepoGenerator struct {
-	clientSet *kubernetes.Clientset
+	clientSet kubernetes.Interface
 	bar       *util.Bar
 }
 
-func NewRepoGenerator(clientSet *kubernetes.Clientset) Generator {
+func NewRepoGenerator(clientSet kubernetes.Interface) Generator {
 	return &RepoGenerator{clientSet: clientSet, bar: &util.Bar{}}
 }
 
@@ -50,10 +51,9 @@ func fetchRepos(ctx context.Context, token string, page int) ([]Repo, error) {
 	return repos, nil
 }
 
-func FetchRepos(token string, samples int) ([]Repo, error) {
+func FetchRepos(ctx context.Context, token string, samples int) ([]Repo, error) {
 	log.Print("Fetch repos started")
 	var (
-		ctx   = context.Background()
 		repos []Repo
 		page  = 1
 	)
@@ -79,23 +79,22 @@ func FetchRepos(token string, samples int) ([]Repo, error) {
 	return repos, nil
 }
 
-func (rg *RepoGenerator) Generate(opts *util.GenerateOpts) error {
-	repos, err := FetchRepos(opts.GithubToken, opts.RepositoryOpts.Samples)
+func (rg *RepoGenerator) Generate(ctx context.Context, opts *util.GenerateOpts) error {
+	repos, err := FetchRepos(ctx, opts.GithubToken, opts.RepositoryOpts.Samples)
 	if err != nil {
 		return err
 	}
 
 	secrets := rg.clientSet.CoreV1().Secrets(opts.Namespace)
+	secretLabels := generatedLabels(opts)
+	secretLabels[common.LabelKeySecretType] = common.LabelValueSecretTypeRepository
 	rg.bar.NewOption(0, int64(len(repos)))
 	for _, repo := range repos {
-		_, err = secrets.Create(context.TODO(), &corev1.Secret{
+		_, err = secrets.Create(ctx, &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: "repo-",
 				Namespace:    opts.Namespace,
-				Labels: map[string]string{
-					"app.kubernetes.io/generated-by": "argocd-generator",
-					"argocd.argoproj.io/secret-type": "repository",
-				},
+				Labels:       secretLabels,
 				Annotations: map[string]string{
 					"managed-by": "argocd.argoproj.io",
 				},
@@ -106,6 +105,11 @@ func (rg *RepoGenerator) Generate(opts *util.GenerateOpts) error {
 				"project": []byte("default"),
 			},
 		}, metav1.CreateOptions{})
+		if err != nil {
+			opts.Report.Failed("repositories", err)
+		} else {
+			opts.Report.Created("repositories", 1)
+		}
 		rg.bar.Increment()
 		rg.bar.Play()
 	}
@@ -116,10 +120,21 @@ func (rg *RepoGenerator) Generate(opts *util.GenerateOpts) error {
 	return nil
 }
 
-func (rg *RepoGenerator) Clean(opts *util.GenerateOpts) error {
+func (rg *RepoGenerator) Clean(ctx context.Context, opts *util.GenerateOpts) error {
 	log.Printf("Clean repos")
 	secrets := rg.clientSet.CoreV1().Secrets(opts.Namespace)
-	return secrets.DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{
-		LabelSelector: "app.kubernetes.io/generated-by=argocd-generator",
-	})
+	listOpts := metav1.ListOptions{LabelSelector: cleanSelector(opts, common.LabelKeySecretType+"="+common.LabelValueSecretTypeRepository)}
+	matched, err := secrets.List(ctx, listOpts)
+	if err != nil {
+		return err
+	}
+	log.Printf("Delete %d repository secrets matching %s", len(matched.Items), listOpts.LabelSelector)
+	if dryRunDelete(opts, "repository secrets", objectNames(matched.Items)) {
+		return nil
+	}
+	if err := secrets.DeleteCollection(ctx, metav1.DeleteOptions{}, listOpts); err != nil {
+		return err
+	}
+	opts.Report.Deleted("repositories", len(matched.Items))
+	return nil
 }
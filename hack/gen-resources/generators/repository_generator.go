@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/db"
+)
+
+// repoSecretPrefix is the prefix of the names of the secrets of the repositories created by the repository layer
+const repoSecretPrefix = "repo"
+
+// RepositoryGenerator registers the repositories built from the URL template of RepoOpts through the repository layer
+// of Argo CD, the way argocd repo add does, unlike RepoGenerator which writes the secrets of the forks it fetches
+type RepositoryGenerator struct {
+	db        db.ArgoDB
+	clientSet kubernetes.Interface
+}
+
+func NewRepositoryGenerator(db db.ArgoDB, clientSet kubernetes.Interface) Generator {
+	return &RepositoryGenerator{db: db, clientSet: clientSet}
+}
+
+// templateRepository returns the repository with the given index built from the templates of the options
+func templateRepository(opts *util.GenerateOpts, i int) *argoappv1.Repository {
+	repoOpts := opts.RepositoryOpts.RepoOpts
+	index := strconv.Itoa(i)
+	return &argoappv1.Repository{
+		// helm repositories are referenced by name
+		Name:     "generated-repo-" + index,
+		Repo:     strings.ReplaceAll(repoOpts.URLTemplate, "{{index}}", index),
+		Type:     repoOpts.Type,
+		Username: strings.ReplaceAll(repoOpts.UsernameTemplate, "{{index}}", index),
+		Password: strings.ReplaceAll(repoOpts.PasswordTemplate, "{{index}}", index),
+	}
+}
+
+// createRepository registers the repository and labels its secret, the repository layer does not set the labels of
+// the generator
+func (rg *RepositoryGenerator) createRepository(ctx context.Context, opts *util.GenerateOpts, repo *argoappv1.Repository) error {
+	if _, err := rg.db.CreateRepository(ctx, repo); err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"labels": generatedLabels(opts)}})
+	if err != nil {
+		return err
+	}
+	name := db.RepoURLToSecretName(repoSecretPrefix, repo.Repo, repo.Project)
+	if _, err := rg.clientSet.CoreV1().Secrets(opts.Namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to label the secret %s of repository %s: %w", name, repo.Repo, err)
+	}
+	return nil
+}
+
+func (rg *RepositoryGenerator) Generate(ctx context.Context, opts *util.GenerateOpts) error {
+	repoOpts := opts.RepositoryOpts.RepoOpts
+	if repoOpts.URLTemplate == "" || repoOpts.Samples == 0 {
+		return nil
+	}
+	log.Printf("Register %d %s repositories from %s", repoOpts.Samples, repoOpts.Type, repoOpts.URLTemplate)
+	var lock sync.Mutex
+	failed := map[int]error{}
+	wg := util.New(repoOpts.Concurrency)
+	for i := 1; i <= repoOpts.Samples; i++ {
+		if err := wg.AddWithContext(ctx); err != nil {
+			break
+		}
+		go func(i int) {
+			defer wg.Done()
+			repo := templateRepository(opts, i)
+			err := rg.createRepository(ctx, opts, repo)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				opts.Report.Failed("repositories", err)
+				log.Printf("Repository #%v %s failed to register", i, repo.Repo)
+				failed[i] = fmt.Errorf("error in repository #%d %s: %w", i, repo.Repo, err)
+				return
+			}
+			opts.Report.Created("repositories", 1)
+		}(i)
+	}
+	wg.Wait()
+	var errs []error
+	for _, i := range slices.Sorted(maps.Keys(failed)) {
+		errs = append(errs, failed[i])
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("registration of the repositories interrupted: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// Clean deletes the generated repositories through the repository layer, by the URL and project of their labeled
+// secrets, which include the ones of RepoGenerator
+func (rg *RepositoryGenerator) Clean(ctx context.Context, opts *util.GenerateOpts) error {
+	log.Printf("Clean registered repositories")
+	listOpts := metav1.ListOptions{LabelSelector: cleanSelector(opts, common.LabelKeySecretType+"="+common.LabelValueSecretTypeRepository)}
+	matched, err := rg.clientSet.CoreV1().Secrets(opts.Namespace).List(ctx, listOpts)
+	if err != nil {
+		return err
+	}
+	log.Printf("Delete %d repositories matching %s", len(matched.Items), listOpts.LabelSelector)
+	if dryRunDelete(opts, "repositories", objectNames(matched.Items)) {
+		return nil
+	}
+	var errs []error
+	deleted := 0
+	for _, secret := range matched.Items {
+		url := string(secret.Data["url"])
+		if err := rg.db.DeleteRepository(ctx, url, string(secret.Data["project"])); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete repository %s: %w", url, err))
+			continue
+		}
+		deleted++
+	}
+	opts.Report.Deleted("repositories", deleted)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/v3/hack/gen-resources/util"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// newTestDB returns a fake clientset of the Argo CD namespace, with the given objects, and the db backed by it
+func newTestDB(t *testing.T, objects ...runtime.Object) (*fake.Clientset, db.ArgoDB) {
+	t.Helper()
+	clientSet := fake.NewClientset(append(objects,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "argocd-cm", Namespace: "argocd", Labels: map[string]string{"app.kubernetes.io/part-of": "argocd"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "argocd-secret", Namespace: "argocd"}},
+	)...)
+	return clientSet, db.NewDB("argocd", settings.NewSettingsManager(t.Context(), clientSet, "argocd"), clientSet)
+}
+
+func TestRepositoryGenerator(t *testing.T) {
+	clientSet, argoDB := newTestDB(t)
+	opts := &util.GenerateOpts{Namespace: "argocd", RepositoryOpts: util.RepositoryOpts{RepoOpts: util.RepoOpts{
+		URLTemplate:      "https://git.example.com/org/repo-{{index}}.git",
+		Samples:          3,
+		Type:             "git",
+		UsernameTemplate: "user-{{index}}",
+		PasswordTemplate: "password",
+		Concurrency:      2,
+	}}}
+	rg := NewRepositoryGenerator(argoDB, clientSet)
+
+	require.NoError(t, rg.Generate(t.Context(), opts))
+	repos, err := argoDB.ListRepositories(t.Context())
+	require.NoError(t, err)
+	var urls []string
+	for _, repo := range repos {
+		urls = append(urls, repo.Repo)
+		assert.Equal(t, "git", repo.Type)
+		assert.Equal(t, "password", repo.Password)
+	}
+	assert.ElementsMatch(t, []string{"https://git.example.com/org/repo-1.git", "https://git.example.com/org/repo-2.git", "https://git.example.com/org/repo-3.git"}, urls)
+	secrets, err := clientSet.CoreV1().Secrets("argocd").List(t.Context(), metav1.ListOptions{LabelSelector: util.GeneratedBySelector})
+	require.NoError(t, err)
+	assert.Len(t, secrets.Items, 3)
+
+	require.NoError(t, rg.Clean(t.Context(), opts))
+	repos, err = argoDB.ListRepositories(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, repos)
+}
@@ -1,69 +1,742 @@
 package util
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 )
 
 type SourceOpts struct {
 	Strategy string `yaml:"strategy"`
+	// MonorepoOpts configures the sources of the Monorepo strategy
+	MonorepoOpts MonorepoOpts `yaml:"monorepo"`
+}
+
+// MonorepoOpts configures applications which all reference different paths of the same repository
+type MonorepoOpts struct {
+	// RepoURL is the repository referenced by the applications, defaults to the first registered repository
+	RepoURL string `yaml:"repoURL"`
+	// PathTemplate is the path of the application, the {{i}} placeholder is replaced with the path index
+	PathTemplate string `yaml:"pathTemplate"`
+	// Paths is the number of distinct paths, the applications are spread evenly across them. Defaults to one path per
+	// application.
+	Paths int `yaml:"paths"`
+	// TargetRevision is the revision referenced by the applications
+	TargetRevision string `yaml:"targetRevision"`
 }
 
 type DestinationOpts struct {
+	// Strategy is Random, which targets the applications at the registered clusters at random, or RoundRobin, which
+	// targets them at the generated clusters in turn
 	Strategy string `yaml:"strategy"`
 }
 
+// DriftOpts configures the drift introduced in the live state of generated applications, to exercise self-heal
+type DriftOpts struct {
+	// Samples is the number of generated applications whose live state is changed once they are synced
+	Samples int `yaml:"samples"`
+	// Timeout is how long to wait, in seconds, for an application to be synced before changing its live state
+	Timeout int `yaml:"timeout"`
+}
+
+// VerifyOpts waits for the generated applications to reach the desired state once they are created, as an end to end
+// check of the application controller
+type VerifyOpts struct {
+	// TargetPercent is the percentage of the generated applications which must reach the desired state, the
+	// verification is disabled if zero
+	TargetPercent int `yaml:"targetPercent"`
+	// Timeout is how long to wait, in seconds, for the applications to reach the desired state
+	Timeout int `yaml:"timeout"`
+	// SyncStatus is the desired sync status, defaults to Synced
+	SyncStatus string `yaml:"syncStatus"`
+	// HealthStatus is the desired health status, defaults to Healthy
+	HealthStatus string `yaml:"healthStatus"`
+}
+
+// IgnoreDifferencesOpts attaches ignoreDifferences rules to generated applications, to exercise the normalizers
+type IgnoreDifferencesOpts struct {
+	// Samples is the number of generated applications with ignoreDifferences rules
+	Samples int `yaml:"samples"`
+	// Rules is the number of rules attached to each application, all the templates if zero
+	Rules int `yaml:"rules"`
+	// Templates are the names of the rule templates to draw from, the whole library if empty
+	Templates []string `yaml:"templates"`
+}
+
+// PluginOpts renders the sources of generated applications through a config management plugin, to exercise the plugin
+// sidecars of the repo server
+type PluginOpts struct {
+	// Samples is the number of generated applications rendered through the plugin
+	Samples int `yaml:"samples"`
+	// Name is the name of the plugin, as configured in its sidecar
+	Name string `yaml:"name"`
+	// Path is the path the plugin renders in the repositories of the applications, defaults to the path of the source
+	// strategy
+	Path string `yaml:"path"`
+	// Env are the environment variables passed to the plugin
+	Env map[string]string `yaml:"env"`
+}
+
+// StatusDistributionOpts sets a synthetic status on generated applications, the weights of the sync and health statuses
+// are their relative proportions, e.g. {Synced: 3, OutOfSync: 1}. The status is overwritten by the application
+// controller when it refreshes the applications, so it is meant to be used while the controller is scaled down.
+type StatusDistributionOpts struct {
+	Sync   map[string]int `yaml:"sync"`
+	Health map[string]int `yaml:"health"`
+}
+
 type ApplicationOpts struct {
-	Samples         int             `yaml:"samples"`
-	SourceOpts      SourceOpts      `yaml:"source"`
-	DestinationOpts DestinationOpts `yaml:"destination"`
+	Samples int `yaml:"samples"`
+	// Concurrency is the number of applications created in parallel, defaults to 2
+	Concurrency            int                    `yaml:"parallel"`
+	SourceOpts             SourceOpts             `yaml:"source"`
+	DestinationOpts        DestinationOpts        `yaml:"destination"`
+	DriftOpts              DriftOpts              `yaml:"drift"`
+	StatusDistributionOpts StatusDistributionOpts `yaml:"statusDistribution"`
+	IgnoreDifferencesOpts  IgnoreDifferencesOpts  `yaml:"ignoreDifferences"`
+	VerifyOpts             VerifyOpts             `yaml:"verify"`
+	PluginOpts             PluginOpts             `yaml:"plugin"`
+}
+
+// ApplicationSetOpts configures the generated ApplicationSets, whose applications are generated by the ApplicationSet
+// controller
+type ApplicationSetOpts struct {
+	Samples int `yaml:"samples"`
+	// Generator is the generator of the ApplicationSets, List, Cluster or Git. The Cluster generator selects the
+	// generated clusters.
+	Generator string `yaml:"generator"`
+	// FanOut is the number of applications of each ApplicationSet with the List generator, and the number of paths of
+	// the monorepo, built from the path template of the applications, with the Git generator
+	FanOut int `yaml:"fanOut"`
+	// RepoURL is the repository of the applications, defaults to the generated repositories
+	RepoURL string `yaml:"repoURL"`
+	// TargetRevision is the revision of the applications, defaults to HEAD
+	TargetRevision string `yaml:"targetRevision"`
 }
 
 type RepositoryOpts struct {
 	Samples int `yaml:"samples"`
+	// RepoOpts registers repositories built from a URL template through the repository layer of Argo CD, in addition
+	// to the forks fetched from GitHub
+	RepoOpts RepoOpts `yaml:"template"`
+}
+
+// RepoOpts configures the repositories registered from a URL template, which need not exist, to load the repo server
+// and the manifest generation with many repositories
+type RepoOpts struct {
+	// URLTemplate is the URL of the repositories, the {{index}} placeholder is replaced with the index of the
+	// repository, e.g. https://git.example.com/org/repo-{{index}}.git. The repositories are not registered if empty.
+	URLTemplate string `yaml:"urlTemplate"`
+	// Samples is the number of registered repositories
+	Samples int `yaml:"samples"`
+	// Type is the type of the repositories, git or helm, defaults to git
+	Type string `yaml:"type"`
+	// UsernameTemplate and PasswordTemplate are the credentials of the repositories, with the {{index}} placeholder of
+	// URLTemplate. The repositories have no credentials if empty.
+	UsernameTemplate string `yaml:"usernameTemplate"`
+	PasswordTemplate string `yaml:"passwordTemplate"`
+	// Concurrency is the number of repositories registered in parallel, defaults to 2
+	Concurrency int `yaml:"parallel"`
 }
 
 type ProjectOpts struct {
 	Samples int `yaml:"samples"`
+	// Concurrency is the number of projects created in parallel, defaults to 2
+	Concurrency int `yaml:"parallel"`
+	// SyncWindows is the number of sync windows with random schedules and durations attached to each project
+	SyncWindows int `yaml:"syncWindows"`
+	// DenyWindowsPercent is the percentage of the sync windows which deny syncs, the others allow them
+	DenyWindowsPercent int `yaml:"denyWindowsPercent"`
+	// SourceRepos are the repositories the applications of the projects may deploy from, e.g. *
+	SourceRepos []string `yaml:"sourceRepos"`
+	// Destinations are the clusters and namespaces the applications of the projects may deploy to
+	Destinations []ProjectDestination `yaml:"destinations"`
+	// ClusterResourceAllowlist are the cluster-scoped resources the applications of the projects may deploy
+	ClusterResourceAllowlist []ProjectGroupKind `yaml:"clusterResourceAllowlist"`
+}
+
+// ProjectDestination is a destination of the generated projects, by server or name of the cluster, which accept the *
+// wildcard
+type ProjectDestination struct {
+	Server    string `yaml:"server"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+// ProjectGroupKind is a group and kind of resources of the generated projects, which accept the * wildcard
+type ProjectGroupKind struct {
+	Group string `yaml:"group"`
+	Kind  string `yaml:"kind"`
 }
 
 type ClusterOpts struct {
-	Samples              int    `yaml:"samples"`
-	NamespacePrefix      string `yaml:"namespacePrefix"`
-	ValuesFilePath       string `yaml:"valuesFilePath"`
+	Samples int `yaml:"samples"`
+	// Backend provisions the clusters, vcluster, the default, installs a vcluster per cluster in the cluster of Argo
+	// CD, gke creates a GKE cluster per cluster with gcloud
+	Backend string `yaml:"backend"`
+	// GKEOpts configures the GKE clusters of the gke backend
+	GKEOpts GKEOpts `yaml:"gke"`
+	// NamespacePrefix is the prefix of the namespaces, in the cluster of Argo CD, the vclusters are installed in
+	NamespacePrefix string `yaml:"namespacePrefix"`
+	// ValuesFilePath is the values file the vclusters are installed with, the defaults of the chart apply if empty
+	ValuesFilePath string `yaml:"valuesFilePath"`
+	// DestinationNamespace is the namespace, within the vclusters, the generated clusters are restricted to and the
+	// generated applications deploy to. It is unrelated to the namespaces the vclusters are installed in.
 	DestinationNamespace string `yaml:"destinationNamespace"`
 	ClusterNamePrefix    string `yaml:"clusterNamePrefix"`
-	Concurrency          int    `yaml:"parallel"`
+	// Labels and Annotations are set on the generated clusters, e.g. a team or a cost center to filter them in the UI.
+	// The label of the generator overrides a label with the same key, so that clean still selects the clusters.
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+	Concurrency int               `yaml:"parallel"`
+	// HelmConcurrency is the number of helm installs of vclusters run in parallel, so that the clusters can be
+	// generated with a high Concurrency while the expensive installs are throttled. The installs are bounded by
+	// Concurrency, and by the install workers of the pipeline, only, if zero.
+	HelmConcurrency int `yaml:"helmConcurrency"`
+	// AdaptiveConcurrency lowers the concurrency when the API server throttles the requests, and raises it back up to
+	// Concurrency once the requests succeed again
+	AdaptiveConcurrency bool `yaml:"adaptiveConcurrency"`
+	// StaggerDelay spaces out the start of the generation of each cluster, e.g. 2s, so that the helm installs do not
+	// all hit the API server at once
+	StaggerDelay time.Duration `yaml:"staggerDelay"`
+	// StaggerJitter adds a random delay, up to the given duration, to StaggerDelay
+	StaggerJitter time.Duration `yaml:"staggerJitter"`
+	// ServerURLTemplate registers clusters against existing endpoints instead of installing vclusters. The {{index}}
+	// placeholder is replaced with the index of the cluster, e.g. https://cluster-{{index}}.test.svc:6443
+	ServerURLTemplate string `yaml:"serverURLTemplate"`
+	// CredentialsSecret is the name of the secret, in the namespace of Argo CD, holding the credentials shared by the
+	// clusters registered with ServerURLTemplate, in its ca.crt, tls.crt, tls.key and token keys. The vclusters are
+	// registered with its ca.crt and token instead of the credentials of their kubeconfig if it has a token.
+	CredentialsSecret string `yaml:"credentialsSecret"`
+	// BearerToken is a static bearer token shared by all the generated clusters, overriding the token of
+	// CredentialsSecret. The vclusters are registered with it instead of the credentials of their kubeconfig.
+	BearerToken string `yaml:"bearerToken"`
+	// Proxy is the URL of the HTTP or SOCKS5 proxy Argo CD reaches the generated clusters through, e.g.
+	// http://proxy.example.com:3128, none if empty
+	Proxy string `yaml:"proxy"`
+	// ServerName is the name used to verify the certificate of the clusters, the {{index}} placeholder is replaced with
+	// the index of the cluster. Defaults to kubernetes.default.svc for vclusters.
+	ServerName string `yaml:"serverName"`
+	// ServicePort is the port of the service of the vclusters the clusters are registered with, defaults to 443. The
+	// service is created if the chart creates none.
+	ServicePort int `yaml:"servicePort"`
+	// PodPrefix is the prefix of the names of the helm releases of the vclusters, which the chart names their pods and
+	// services after, defaults to vcluster
+	PodPrefix string `yaml:"podPrefix"`
+	// SyncerContainer is the container of the pods of the vclusters their kubeconfig is read from, defaults to syncer
+	SyncerContainer string `yaml:"syncerContainer"`
+	// InsecureTLS registers the vclusters without verifying their certificates, and without their CA data
+	InsecureTLS bool `yaml:"insecureTLS"`
+	// ClusterScopedPercent is the percentage of generated clusters registered without a namespace restriction, the
+	// others are restricted to DestinationNamespace
+	ClusterScopedPercent int `yaml:"clusterScopedPercent"`
+	// ChartRepo is the repository of the vcluster chart, a chart repository or an oci:// registry for mirrors, defaults
+	// to https://charts.loft.sh
+	ChartRepo string `yaml:"chartRepo"`
+	// ChartName is the name of the vcluster chart in ChartRepo, defaults to vcluster
+	ChartName string `yaml:"chartName"`
+	// ChartUsername and ChartPassword authenticate to ChartRepo, with the auth flags of helm for a chart repository or
+	// a login to an OCI registry
+	ChartUsername string `yaml:"chartUsername"`
+	ChartPassword string `yaml:"chartPassword"`
+	// ChartVersion is the version of the vcluster chart the vclusters are installed with, e.g. 0.19.5. Defaults to the
+	// latest version when the run starts.
+	ChartVersion string `yaml:"chartVersion"`
+	// HelmTimeout bounds the helm install of a vcluster, e.g. 5m. Defaults to the timeout of helm.
+	HelmTimeout time.Duration `yaml:"helmTimeout"`
+	// GenerateTimeout bounds the generation of all the clusters, e.g. 30m. Once it elapses no more clusters are started,
+	// and the clusters still in flight are given up on instead of blocking the run. It is not supported by the pipeline.
+	GenerateTimeout time.Duration `yaml:"generateTimeout"`
+	// ForceRemoveFinalizers removes the finalizers of the vcluster namespaces stuck terminating on clean
+	ForceRemoveFinalizers bool `yaml:"forceRemoveFinalizers"`
+	// ServerVersions are the server versions assigned to the generated clusters, defaults to the version discovered from
+	// the vclusters, or 1.18 if it cannot be discovered
+	ServerVersions []string `yaml:"serverVersions"`
+	// ServerVersionStrategy is how the server versions are assigned, RoundRobin or Random
+	ServerVersionStrategy string `yaml:"serverVersionStrategy"`
+	// ExecTimeout bounds the exec reading the kubeconfig of a vcluster, so that a stuck syncer container does not block
+	// the run, defaults to 30s
+	ExecTimeout time.Duration `yaml:"execTimeout"`
+	// PodReadyTimeout bounds the wait for the pod of a vcluster to be ready before its credentials are read, defaults
+	// to 5m
+	PodReadyTimeout time.Duration `yaml:"podReadyTimeout"`
+	// ExecStdin attaches the stdin of the exec reading the kubeconfig of the vclusters
+	ExecStdin bool `yaml:"execStdin"`
+	// ExecTTY allocates a TTY for the exec reading the kubeconfig of the vclusters. Some syncer images inject carriage
+	// returns in the output when it is enabled, so it is disabled by default.
+	ExecTTY bool `yaml:"execTTY"`
+	// WaitForReady waits for the API server of each vcluster to report it is healthy on /healthz, with bounded retries,
+	// before registering it, so that its connection state does not flap while its control plane starts
+	WaitForReady bool `yaml:"waitForReady"`
+	// VerifyConnection requests /version of each generated cluster with the credentials it is registered with, once it
+	// is created, and records the latency and the connection state in the report. A cluster which is not reachable is
+	// still registered.
+	VerifyConnection bool `yaml:"verifyConnection"`
+	// PermanentErrors are substrings of the errors reading the credentials and the URI of the vclusters which are not
+	// retried, in addition to a missing container, a failed pod and a container in CrashLoopBackOff or failing to pull
+	// its image
+	PermanentErrors []string `yaml:"permanentErrors"`
+	// DebugKubeconfig logs the structure of the kubeconfig of the vclusters, without the certificates and keys
+	DebugKubeconfig bool `yaml:"debugKubeconfig"`
+	// SkipInstall only registers the vclusters listed in ExistingVClusters, which are already running, instead of
+	// installing new ones
+	SkipInstall bool `yaml:"skipInstall"`
+	// ExistingVClusters are the vclusters registered when SkipInstall is set, one per sample
+	ExistingVClusters []ExistingVCluster `yaml:"existingVClusters"`
+	// ConnectionStateOpts sets a synthetic connection state on the generated clusters instead of probing them
+	ConnectionStateOpts ConnectionStateOpts `yaml:"connectionState"`
+	// PipelineOpts runs the stages of the generation of the vclusters in separate pools
+	PipelineOpts PipelineOpts `yaml:"pipeline"`
+	// TargetCount tops up the generated clusters to the given count instead of generating Samples clusters, counting the
+	// generated clusters already registered. It does not support SkipInstall.
+	TargetCount int `yaml:"targetCount"`
+	// InventoryOpts labels the generated clusters from the label sets of an inventory file
+	InventoryOpts InventoryOpts `yaml:"inventory"`
+	// IsolateNamespaces applies network policies to the namespaces of the installed vclusters, denying the traffic
+	// other than within the namespace, from the Argo CD namespace, and to DNS and the API server of the host
+	IsolateNamespaces bool `yaml:"isolateNamespaces"`
+	// ClusterSecretNamespace is the namespace the secrets of the generated clusters are created in, e.g. an application
+	// namespace of an Argo CD running in namespaced mode, defaults to the namespace of Argo CD. The secrets of the
+	// clusters registered in the Instances are created in the namespace of their instance.
+	ClusterSecretNamespace string `yaml:"clusterSecretNamespace"`
+	// KubeconfigPath is the path of a kubeconfig written with a context per generated cluster, with the server and the
+	// credentials the cluster is registered with, not written if empty. The contexts are added to the file if it exists.
+	KubeconfigPath string `yaml:"kubeconfigPath"`
+}
+
+// GKEOpts configures the GKE clusters created by the gke backend with the gcloud of the machine running the generator.
+// The clusters are registered with the gcp auth of argocd-k8s-auth, Argo CD must be able to access them through its
+// workload identity.
+type GKEOpts struct {
+	// Project is the Google Cloud project the clusters are created in
+	Project string `yaml:"project"`
+	// Location is the zone or the region the clusters are created in
+	Location string `yaml:"location"`
+	// NamePrefix is the prefix of the names of the clusters, followed by a random suffix
+	NamePrefix string `yaml:"namePrefix"`
+	// MachineType and NumNodes are the machine type and the number of the nodes of the clusters
+	MachineType string `yaml:"machineType"`
+	NumNodes    int    `yaml:"numNodes"`
+	// ExtraArgs are passed to gcloud container clusters create, e.g. --release-channel=rapid
+	ExtraArgs []string `yaml:"extraArgs"`
+}
+
+// InventoryOpts configures the inventory file whose rows are the label sets of the generated clusters
+type InventoryOpts struct {
+	// Path is the path of the inventory, a YAML list of labels and weights, or a CSV file whose columns are the label
+	// keys and an optional weight column. The inventory is disabled if empty.
+	Path string `yaml:"path"`
+	// Assignment is how the rows are assigned to the clusters, Ordered, the default, or Weighted
+	Assignment string `yaml:"assignment"`
+}
+
+// PipelineOpts configures the pools of the stages the vclusters go through, so that a vcluster waiting for its install
+// does not hold back the extraction of the credentials and the creation of the others. The pipeline is disabled if
+// InstallConcurrency is zero, and does not adapt its concurrency.
+type PipelineOpts struct {
+	// InstallConcurrency is the number of vclusters installed in parallel
+	InstallConcurrency int `yaml:"installConcurrency"`
+	// ExtractConcurrency is the number of vclusters whose credentials and server URI are read in parallel, defaults to
+	// InstallConcurrency
+	ExtractConcurrency int `yaml:"extractConcurrency"`
+	// CreateConcurrency is the number of clusters created in parallel, defaults to InstallConcurrency
+	CreateConcurrency int `yaml:"createConcurrency"`
+}
+
+// ConnectionStateOpts configures the synthetic connection state written to the cluster info cache of Argo CD for each
+// generated cluster, so that the clusters are listed as connected or failed without being reachable. The application
+// controller overwrites the state of the clusters it monitors.
+type ConnectionStateOpts struct {
+	// RedisAddress is the address of the Redis used by Argo CD, e.g. localhost:6379 with a port-forward. The
+	// synthetic connection state is disabled if empty.
+	RedisAddress string `yaml:"redisAddress"`
+	// RedisPassword is the password of the Redis used by Argo CD, if any
+	RedisPassword string `yaml:"redisPassword"`
+	// FailedPercent is the percentage of clusters with a Failed connection state, the others are Successful
+	FailedPercent int `yaml:"failedPercent"`
+	// FailedMessage is the message of the Failed connection states
+	FailedMessage string `yaml:"failedMessage"`
+}
+
+// ExistingVCluster is a vcluster installed out of band, its pod is named <podPrefix>-<releaseSuffix>-0
+type ExistingVCluster struct {
+	Namespace     string `yaml:"namespace"`
+	ReleaseSuffix string `yaml:"releaseSuffix"`
 }
 
 type GenerateOpts struct {
 	ApplicationOpts ApplicationOpts `yaml:"application"`
-	ClusterOpts     ClusterOpts     `yaml:"cluster"`
-	RepositoryOpts  RepositoryOpts  `yaml:"repository"`
-	ProjectOpts     ProjectOpts     `yaml:"project"`
-	GithubToken     string
-	Namespace       string `yaml:"namespace"`
+	// ApplicationSetOpts configures the generated ApplicationSets
+	ApplicationSetOpts ApplicationSetOpts `yaml:"applicationSet"`
+	ClusterOpts        ClusterOpts        `yaml:"cluster"`
+	RepositoryOpts     RepositoryOpts     `yaml:"repository"`
+	ProjectOpts        ProjectOpts        `yaml:"project"`
+	GithubToken        string
+	Namespace          string `yaml:"namespace"`
+	// Seed seeds the random names of the generated objects, so that a run can be reproduced with the seed logged by a
+	// previous one. The names are drawn in the same order, the clusters get the same names if generated one at a time.
+	// Defaults to a time based seed.
+	Seed int64 `yaml:"seed"`
+	// Include are the names of the generators run, project, repository, cluster, application and applicationset,
+	// defaults to all of them. Exclude are the names of the generators skipped, it wins over Include.
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+	// RunID labels the clusters, namespaces and secrets generated by the run, so that clean can be scoped to them with
+	// --run-id. Generate defaults it to a random UUID.
+	RunID string `yaml:"runId"`
+	// DryRun logs the vclusters, clusters and namespaces the run would install, create and delete instead of mutating
+	// them. The other objects are generated in memory.
+	DryRun bool `yaml:"dryRun"`
+	// CleanSelector restricts clean to the generated objects matching the label selector, defaults to all of them
+	CleanSelector string `yaml:"cleanSelector"`
+	// CleanReportPath is the path of the JSON report of a generate run whose clusters, cluster secrets and vcluster
+	// namespaces clean deletes, instead of the generated ones matching the labels
+	CleanReportPath string `yaml:"-"`
+	// CleanWait waits until the namespaces deleted by clean are gone, for at most CleanWaitTimeout, so that the next
+	// run does not collide with namespaces still terminating
+	CleanWait        bool          `yaml:"-"`
+	CleanWaitTimeout time.Duration `yaml:"-"`
+	// ReportPath is the path of the JSON report of the run, not written if empty
+	ReportPath string `yaml:"reportPath"`
+	// Report collects the summary of the run if ReportPath is set
+	Report *Report `yaml:"-"`
+	// Instances are the Argo CD instances the generated clusters are distributed across, round-robin. The clusters are
+	// registered in the Argo CD of Namespace if empty.
+	Instances []ArgoCDInstance `yaml:"instances"`
+}
+
+// ClusterSecretsNamespace returns the namespace the secrets of the generated clusters are created in, see
+// ClusterSecretNamespace
+func (opts *GenerateOpts) ClusterSecretsNamespace() string {
+	if opts.ClusterOpts.ClusterSecretNamespace != "" {
+		return opts.ClusterOpts.ClusterSecretNamespace
+	}
+	return opts.Namespace
+}
+
+// ArgoCDInstance is an Argo CD installation the generated clusters can be registered in
+type ArgoCDInstance struct {
+	// Context is the context of the kubeconfig of the cluster Argo CD runs in, defaults to the current context
+	Context string `yaml:"context"`
+	// Namespace is the namespace Argo CD runs in, defaults to the namespace of the options
+	Namespace string `yaml:"namespace"`
 }
 
 func setDefaults(opts *GenerateOpts) {
 	if opts.ClusterOpts.Concurrency == 0 {
 		opts.ClusterOpts.Concurrency = 2
 	}
+	if opts.ProjectOpts.Concurrency == 0 {
+		opts.ProjectOpts.Concurrency = 2
+	}
+	if opts.RepositoryOpts.RepoOpts.Concurrency == 0 {
+		opts.RepositoryOpts.RepoOpts.Concurrency = 2
+	}
+	if opts.RepositoryOpts.RepoOpts.Type == "" {
+		opts.RepositoryOpts.RepoOpts.Type = "git"
+	}
+	if opts.ApplicationOpts.Concurrency == 0 {
+		opts.ApplicationOpts.Concurrency = 2
+	}
+	if opts.ClusterOpts.ExecTimeout == 0 {
+		opts.ClusterOpts.ExecTimeout = 30 * time.Second
+	}
+	if opts.ClusterOpts.PodReadyTimeout == 0 {
+		opts.ClusterOpts.PodReadyTimeout = 5 * time.Minute
+	}
+	if opts.ClusterOpts.ServicePort == 0 {
+		opts.ClusterOpts.ServicePort = 443
+	}
+	if opts.ClusterOpts.ChartRepo == "" {
+		opts.ClusterOpts.ChartRepo = "https://charts.loft.sh"
+	}
+	if opts.ClusterOpts.ChartName == "" {
+		opts.ClusterOpts.ChartName = "vcluster"
+	}
+	if opts.ClusterOpts.PodPrefix == "" {
+		opts.ClusterOpts.PodPrefix = "vcluster"
+	}
+	if opts.ClusterOpts.Backend == "" {
+		opts.ClusterOpts.Backend = "vcluster"
+	}
+	if opts.ClusterOpts.GKEOpts.NamePrefix == "" {
+		opts.ClusterOpts.GKEOpts.NamePrefix = "argocd-generator"
+	}
+	if opts.ClusterOpts.GKEOpts.MachineType == "" {
+		opts.ClusterOpts.GKEOpts.MachineType = "e2-small"
+	}
+	if opts.ClusterOpts.GKEOpts.NumNodes == 0 {
+		opts.ClusterOpts.GKEOpts.NumNodes = 1
+	}
+	if opts.ClusterOpts.SyncerContainer == "" {
+		opts.ClusterOpts.SyncerContainer = "syncer"
+	}
+	if opts.ApplicationOpts.SourceOpts.MonorepoOpts.PathTemplate == "" {
+		opts.ApplicationOpts.SourceOpts.MonorepoOpts.PathTemplate = "apps/app-{{i}}"
+	}
+	if opts.ApplicationOpts.SourceOpts.MonorepoOpts.TargetRevision == "" {
+		opts.ApplicationOpts.SourceOpts.MonorepoOpts.TargetRevision = "HEAD"
+	}
+	for i := range opts.Instances {
+		if opts.Instances[i].Namespace == "" {
+			opts.Instances[i].Namespace = opts.Namespace
+		}
+	}
+	if opts.ApplicationSetOpts.Generator == "" {
+		opts.ApplicationSetOpts.Generator = "List"
+	}
+	if opts.ApplicationSetOpts.FanOut == 0 {
+		opts.ApplicationSetOpts.FanOut = 10
+	}
+	if opts.ApplicationSetOpts.TargetRevision == "" {
+		opts.ApplicationSetOpts.TargetRevision = "HEAD"
+	}
+	if opts.ApplicationOpts.VerifyOpts.Timeout == 0 {
+		opts.ApplicationOpts.VerifyOpts.Timeout = 600
+	}
+	if opts.ApplicationOpts.VerifyOpts.SyncStatus == "" {
+		opts.ApplicationOpts.VerifyOpts.SyncStatus = "Synced"
+	}
+	if opts.ApplicationOpts.VerifyOpts.HealthStatus == "" {
+		opts.ApplicationOpts.VerifyOpts.HealthStatus = "Healthy"
+	}
+	if opts.ClusterOpts.PipelineOpts.ExtractConcurrency == 0 {
+		opts.ClusterOpts.PipelineOpts.ExtractConcurrency = opts.ClusterOpts.PipelineOpts.InstallConcurrency
+	}
+	if opts.ClusterOpts.PipelineOpts.CreateConcurrency == 0 {
+		opts.ClusterOpts.PipelineOpts.CreateConcurrency = opts.ClusterOpts.PipelineOpts.InstallConcurrency
+	}
+	if opts.ClusterOpts.ConnectionStateOpts.FailedMessage == "" {
+		opts.ClusterOpts.ConnectionStateOpts.FailedMessage = "unable to connect to the cluster"
+	}
+	if opts.ApplicationOpts.DriftOpts.Timeout == 0 {
+		opts.ApplicationOpts.DriftOpts.Timeout = 300
+	}
 }
 
-func Parse(opts *GenerateOpts, file string) error {
+// Parse loads the options from the given YAML file, with the given overrides applied on top of it, and validates them.
+// Each override is a key=value pair whose key is the dotted path of an option in the file, e.g. cluster.samples=10.
+// Unknown keys are reported as errors.
+func Parse(opts *GenerateOpts, file string, overrides ...string) error {
 	fp, err := os.ReadFile(file)
 	if err != nil {
 		return fmt.Errorf("error reading the template file: %s : %w", file, err)
 	}
 
-	if e := yaml.Unmarshal(fp, &opts); e != nil {
-		return e
+	values := map[any]any{}
+	if err := yaml.Unmarshal(fp, &values); err != nil {
+		return fmt.Errorf("error parsing the template file: %s : %w", file, err)
+	}
+	for _, override := range overrides {
+		if err := setOverride(values, override); err != nil {
+			return err
+		}
+	}
+	if fp, err = yaml.Marshal(values); err != nil {
+		return err
+	}
+	if err := yaml.UnmarshalStrict(fp, &opts); err != nil {
+		return fmt.Errorf("invalid options in %s or its overrides: %w", file, err)
 	}
 
 	setDefaults(opts)
 
+	return Validate(opts)
+}
+
+// setOverride sets the value of the given key=value override in the values of the file, the value being parsed as YAML
+func setOverride(values map[any]any, override string) error {
+	key, raw, ok := strings.Cut(override, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("invalid override %q, must be key=value", override)
+	}
+	var value any
+	if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+		return fmt.Errorf("invalid value of override %q: %w", override, err)
+	}
+	path := strings.Split(key, ".")
+	for _, field := range path[:len(path)-1] {
+		next, ok := values[field].(map[any]any)
+		if !ok {
+			if values[field] != nil {
+				return fmt.Errorf("invalid override %q, %s is not a map", override, field)
+			}
+			next = map[any]any{}
+			values[field] = next
+		}
+		values = next
+	}
+	values[path[len(path)-1]] = value
+	return nil
+}
+
+// Validate reports the invalid values of the options
+func Validate(opts *GenerateOpts) error {
+	var errs []error
+	samples := map[string]int{
+		"application.samples":                 opts.ApplicationOpts.Samples,
+		"applicationSet.samples":              opts.ApplicationSetOpts.Samples,
+		"cluster.samples":                     opts.ClusterOpts.Samples,
+		"cluster.targetCount":                 opts.ClusterOpts.TargetCount,
+		"repository.samples":                  opts.RepositoryOpts.Samples,
+		"repository.template.samples":         opts.RepositoryOpts.RepoOpts.Samples,
+		"repository.template.parallel":        opts.RepositoryOpts.RepoOpts.Concurrency,
+		"project.samples":                     opts.ProjectOpts.Samples,
+		"project.parallel":                    opts.ProjectOpts.Concurrency,
+		"application.parallel":                opts.ApplicationOpts.Concurrency,
+		"application.drift.samples":           opts.ApplicationOpts.DriftOpts.Samples,
+		"application.plugin.samples":          opts.ApplicationOpts.PluginOpts.Samples,
+		"applicationSet.fanOut":               opts.ApplicationSetOpts.FanOut,
+		"cluster.parallel":                    opts.ClusterOpts.Concurrency,
+		"cluster.helmConcurrency":             opts.ClusterOpts.HelmConcurrency,
+		"cluster.pipeline.installConcurrency": opts.ClusterOpts.PipelineOpts.InstallConcurrency,
+		"cluster.gke.numNodes":                opts.ClusterOpts.GKEOpts.NumNodes,
+	}
+	for _, key := range slices.Sorted(maps.Keys(samples)) {
+		if samples[key] < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", key))
+		}
+	}
+	percents := map[string]int{
+		"application.verify.targetPercent":      opts.ApplicationOpts.VerifyOpts.TargetPercent,
+		"cluster.clusterScopedPercent":          opts.ClusterOpts.ClusterScopedPercent,
+		"cluster.connectionState.failedPercent": opts.ClusterOpts.ConnectionStateOpts.FailedPercent,
+		"project.denyWindowsPercent":            opts.ProjectOpts.DenyWindowsPercent,
+	}
+	for _, key := range slices.Sorted(maps.Keys(percents)) {
+		if percents[key] < 0 || percents[key] > 100 {
+			errs = append(errs, fmt.Errorf("%s must be between 0 and 100", key))
+		}
+	}
+	enums := []struct {
+		key     string
+		value   string
+		allowed []string
+	}{
+		{"application.source.strategy", opts.ApplicationOpts.SourceOpts.Strategy, []string{"", "Random", "Monorepo"}},
+		{"application.destination.strategy", opts.ApplicationOpts.DestinationOpts.Strategy, []string{"", "Random", "RoundRobin"}},
+		{"applicationSet.generator", opts.ApplicationSetOpts.Generator, []string{"List", "Cluster", "Git"}},
+		{"cluster.serverVersionStrategy", opts.ClusterOpts.ServerVersionStrategy, []string{"", "RoundRobin", "Random"}},
+		{"cluster.inventory.assignment", opts.ClusterOpts.InventoryOpts.Assignment, []string{"", "Ordered", "Weighted"}},
+		{"repository.template.type", opts.RepositoryOpts.RepoOpts.Type, []string{"git", "helm"}},
+		{"cluster.backend", opts.ClusterOpts.Backend, []string{"vcluster", "gke"}},
+	}
+	durations := map[string]time.Duration{
+		"cluster.staggerDelay":    opts.ClusterOpts.StaggerDelay,
+		"cluster.staggerJitter":   opts.ClusterOpts.StaggerJitter,
+		"cluster.generateTimeout": opts.ClusterOpts.GenerateTimeout,
+		"cluster.execTimeout":     opts.ClusterOpts.ExecTimeout,
+		"cluster.podReadyTimeout": opts.ClusterOpts.PodReadyTimeout,
+	}
+	for _, key := range slices.Sorted(maps.Keys(durations)) {
+		if durations[key] < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", key))
+		}
+	}
+	for _, enum := range enums {
+		if !slices.Contains(enum.allowed, enum.value) {
+			errs = append(errs, fmt.Errorf("%s must be one of %s, got %q", enum.key, strings.Join(slices.DeleteFunc(slices.Clone(enum.allowed), func(v string) bool { return v == "" }), ", "), enum.value))
+		}
+	}
+	if opts.ApplicationOpts.PluginOpts.Samples > 0 && opts.ApplicationOpts.PluginOpts.Name == "" {
+		errs = append(errs, errors.New("application.plugin.name is required with application.plugin.samples"))
+	}
+	if repoOpts := opts.RepositoryOpts.RepoOpts; repoOpts.URLTemplate != "" && repoOpts.Samples > 1 && !strings.Contains(repoOpts.URLTemplate, "{{index}}") {
+		errs = append(errs, errors.New("repository.template.urlTemplate must contain the {{index}} placeholder with repository.template.samples greater than 1"))
+	}
+	if err := readableFile("cluster.valuesFilePath", opts.ClusterOpts.ValuesFilePath); err != nil {
+		errs = append(errs, err)
+	}
+	if proxy := opts.ClusterOpts.Proxy; proxy != "" {
+		if u, err := v1alpha1.ParseProxyUrl(proxy); err != nil {
+			errs = append(errs, fmt.Errorf("cluster.proxy %q is invalid: %w", proxy, err))
+		} else if u.Host == "" {
+			errs = append(errs, fmt.Errorf("cluster.proxy %q is invalid, it has no host", proxy))
+		}
+	}
+	if msgs := validation.IsValidLabelValue(opts.RunID); len(msgs) > 0 {
+		errs = append(errs, fmt.Errorf("runId %q is not a valid label value: %s", opts.RunID, strings.Join(msgs, "; ")))
+	}
+	if opts.ClusterOpts.VerifyConnection && opts.ClusterOpts.ConnectionStateOpts.RedisAddress != "" {
+		errs = append(errs, errors.New("cluster.verifyConnection is not supported with the synthetic connection state of cluster.connectionState.redisAddress"))
+	}
+	if opts.ClusterOpts.Backend == "gke" {
+		gkeOpts := opts.ClusterOpts.GKEOpts
+		if gkeOpts.Project == "" || gkeOpts.Location == "" {
+			errs = append(errs, errors.New("cluster.gke.project and cluster.gke.location are required with the gke backend"))
+		}
+		// the names of GKE clusters are at most 40 characters, the random suffix takes 13
+		if len(gkeOpts.NamePrefix) > 27 {
+			errs = append(errs, fmt.Errorf("cluster.gke.namePrefix %q must be at most 27 characters", gkeOpts.NamePrefix))
+		}
+		if opts.ClusterOpts.SkipInstall {
+			errs = append(errs, errors.New("cluster.skipInstall is not supported with the gke backend"))
+		}
+	}
+	if opts.ClusterOpts.SkipInstall && opts.ClusterOpts.TargetCount > 0 {
+		errs = append(errs, errors.New("cluster.targetCount is not supported with cluster.skipInstall"))
+	}
+	if opts.ClusterOpts.SkipInstall && len(opts.ClusterOpts.ExistingVClusters) < opts.ClusterOpts.Samples {
+		errs = append(errs, fmt.Errorf("cluster.existingVClusters lists %d vclusters, cluster.samples requires %d", len(opts.ClusterOpts.ExistingVClusters), opts.ClusterOpts.Samples))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the cluster options a run of the cluster generator needs, which Validate accepts as they are left
+// unset when the clusters are not generated, and the files they reference
+func (clusterOpts *ClusterOpts) Validate() error {
+	var errs []error
+	// the clusters to generate are counted from the target with targetCount
+	if clusterOpts.Samples <= 0 && clusterOpts.TargetCount <= 0 {
+		errs = append(errs, fmt.Errorf("cluster.samples must be greater than 0, got %d", clusterOpts.Samples))
+	}
+	if clusterOpts.Concurrency < 1 {
+		errs = append(errs, fmt.Errorf("cluster.parallel must be at least 1, got %d", clusterOpts.Concurrency))
+	}
+	prefixes := map[string]string{
+		"cluster.gke.namePrefix": clusterOpts.GKEOpts.NamePrefix,
+	}
+	if clusterOpts.Backend != "gke" {
+		// the vclusters are installed in a namespace named after the namespace prefix, as a release named after the pod
+		// prefix
+		prefixes = map[string]string{
+			"cluster.namespacePrefix": clusterOpts.NamespacePrefix,
+			"cluster.podPrefix":       clusterOpts.PodPrefix,
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(prefixes)) {
+		if msgs := validation.IsDNS1123Label(prefixes[key]); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("%s %q is not a valid DNS label: %s", key, prefixes[key], strings.Join(msgs, "; ")))
+		}
+	}
+	files := map[string]string{
+		"cluster.valuesFilePath": clusterOpts.ValuesFilePath,
+		"cluster.inventory.path": clusterOpts.InventoryOpts.Path,
+	}
+	for _, key := range slices.Sorted(maps.Keys(files)) {
+		if err := readableFile(key, files[key]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// readableFile returns an error if the file of the option with the given key is set and cannot be read
+func readableFile(key, path string) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("%s %s cannot be read: %w", key, path, err)
+	}
 	return nil
 }
@@ -0,0 +1,115 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProxy(t *testing.T) {
+	validate := func(proxy string) error {
+		opts := &GenerateOpts{ClusterOpts: ClusterOpts{Proxy: proxy}}
+		setDefaults(opts)
+		return Validate(opts)
+	}
+	for _, proxy := range []string{"", "http://proxy.example.com:3128", "socks5://127.0.0.1:1080"} {
+		assert.NoError(t, validate(proxy), proxy)
+	}
+	require.EqualError(t, validate("ftp://proxy.example.com"), `cluster.proxy "ftp://proxy.example.com" is invalid: failed to parse proxy url, unsupported scheme "ftp", must be http, https, or socks5`)
+	require.EqualError(t, validate("http://"), `cluster.proxy "http://" is invalid, it has no host`)
+}
+
+func TestValidateValuesFilePath(t *testing.T) {
+	validate := func(path string) error {
+		opts := &GenerateOpts{ClusterOpts: ClusterOpts{ValuesFilePath: path}}
+		setDefaults(opts)
+		return Validate(opts)
+	}
+	values := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(values, []byte("{}"), 0o644))
+	require.NoError(t, validate(""))
+	require.NoError(t, validate(values))
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	require.ErrorContains(t, validate(missing), "cluster.valuesFilePath "+missing+" cannot be read")
+}
+
+func TestValidateBackend(t *testing.T) {
+	validate := func(clusterOpts ClusterOpts) error {
+		opts := &GenerateOpts{ClusterOpts: clusterOpts}
+		setDefaults(opts)
+		return Validate(opts)
+	}
+	require.NoError(t, validate(ClusterOpts{}))
+	require.NoError(t, validate(ClusterOpts{Backend: "gke", GKEOpts: GKEOpts{Project: "load-test", Location: "europe-west1-b"}}))
+	require.EqualError(t, validate(ClusterOpts{Backend: "eks"}), `cluster.backend must be one of vcluster, gke, got "eks"`)
+	require.EqualError(t, validate(ClusterOpts{Backend: "gke"}), "cluster.gke.project and cluster.gke.location are required with the gke backend")
+	require.EqualError(t, validate(ClusterOpts{Backend: "gke", SkipInstall: true, GKEOpts: GKEOpts{Project: "load-test", Location: "europe-west1-b", NamePrefix: "a-prefix-longer-than-the-limit"}}),
+		"cluster.gke.namePrefix \"a-prefix-longer-than-the-limit\" must be at most 27 characters\ncluster.skipInstall is not supported with the gke backend")
+}
+
+func TestClusterOptsValidate(t *testing.T) {
+	valid := func() ClusterOpts {
+		return ClusterOpts{Samples: 2, Concurrency: 2, NamespacePrefix: "vcluster", PodPrefix: "vcluster", Backend: "vcluster"}
+	}
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+
+	t.Run("Valid", func(t *testing.T) {
+		clusterOpts := valid()
+		require.NoError(t, clusterOpts.Validate())
+	})
+	t.Run("Samples", func(t *testing.T) {
+		clusterOpts := valid()
+		clusterOpts.Samples = 0
+		require.EqualError(t, clusterOpts.Validate(), "cluster.samples must be greater than 0, got 0")
+		clusterOpts.TargetCount = 5
+		require.NoError(t, clusterOpts.Validate())
+	})
+	t.Run("Concurrency", func(t *testing.T) {
+		clusterOpts := valid()
+		clusterOpts.Concurrency = 0
+		require.EqualError(t, clusterOpts.Validate(), "cluster.parallel must be at least 1, got 0")
+	})
+	t.Run("NamespacePrefix", func(t *testing.T) {
+		clusterOpts := valid()
+		clusterOpts.NamespacePrefix = ""
+		require.ErrorContains(t, clusterOpts.Validate(), `cluster.namespacePrefix "" is not a valid DNS label`)
+		clusterOpts.NamespacePrefix = "VCluster"
+		require.ErrorContains(t, clusterOpts.Validate(), `cluster.namespacePrefix "VCluster" is not a valid DNS label`)
+	})
+	t.Run("PodPrefix", func(t *testing.T) {
+		clusterOpts := valid()
+		clusterOpts.PodPrefix = "vcluster_"
+		require.ErrorContains(t, clusterOpts.Validate(), `cluster.podPrefix "vcluster_" is not a valid DNS label`)
+	})
+	t.Run("GKENamePrefix", func(t *testing.T) {
+		clusterOpts := valid()
+		clusterOpts.Backend = "gke"
+		// the prefixes of the vclusters are not used with the gke backend
+		clusterOpts.NamespacePrefix = ""
+		clusterOpts.GKEOpts.NamePrefix = "argocd-generator"
+		require.NoError(t, clusterOpts.Validate())
+		clusterOpts.GKEOpts.NamePrefix = "argocd.generator"
+		require.ErrorContains(t, clusterOpts.Validate(), `cluster.gke.namePrefix "argocd.generator" is not a valid DNS label`)
+	})
+	t.Run("ValuesFilePath", func(t *testing.T) {
+		clusterOpts := valid()
+		clusterOpts.ValuesFilePath = missing
+		require.ErrorContains(t, clusterOpts.Validate(), "cluster.valuesFilePath "+missing+" cannot be read")
+	})
+	t.Run("InventoryPath", func(t *testing.T) {
+		clusterOpts := valid()
+		clusterOpts.InventoryOpts.Path = missing
+		require.ErrorContains(t, clusterOpts.Validate(), "cluster.inventory.path "+missing+" cannot be read")
+	})
+	t.Run("Aggregated", func(t *testing.T) {
+		clusterOpts := valid()
+		clusterOpts.Samples = 0
+		clusterOpts.Concurrency = 0
+		clusterOpts.PodPrefix = "Vcluster"
+		err := clusterOpts.Validate()
+		require.ErrorContains(t, err, "cluster.samples must be greater than 0, got 0\ncluster.parallel must be at least 1, got 0\ncluster.podPrefix \"Vcluster\" is not a valid DNS label")
+	})
+}
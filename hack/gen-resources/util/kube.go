@@ -58,3 +58,15 @@ func ConnectToK8sClientSet() *kubernetes.Clientset {
 	}
 	return kubernetes.NewForConfigOrDie(config)
 }
+
+// ConnectToK8sConfigForContext returns the config of the given context of the kubeconfig
+func ConnectToK8sConfigForContext(context string) *rest.Config {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: getKubeConfigPath()},
+		&clientcmd.ConfigOverrides{CurrentContext: context},
+	).ClientConfig()
+	if err != nil {
+		log.Panicf("failed to create K8s config of context %s: %v", context, err)
+	}
+	return config
+}
@@ -0,0 +1,144 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	appfake "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned/fake"
+)
+
+// GeneratedBySelector selects the objects created by the generators
+const GeneratedBySelector = "app.kubernetes.io/generated-by=argocd-generator"
+
+// ListClientSets are in-memory clientsets in which the generators create their objects, so that they can be printed as
+// a v1/List and applied with kubectl instead of being created in the cluster
+type ListClientSets struct {
+	ClientSet     *fake.Clientset
+	ArgoClientSet *appfake.Clientset
+}
+
+// NewListClientSets returns in-memory clientsets holding the Argo CD settings of the given namespace
+func NewListClientSets(namespace string) *ListClientSets {
+	partOf := map[string]string{"app.kubernetes.io/part-of": "argocd"}
+	clientSet := fake.NewClientset(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDConfigMapName, Namespace: namespace, Labels: partOf}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDSecretName, Namespace: namespace, Labels: partOf},
+			Data:       map[string][]byte{"server.secretkey": []byte(GetRandomString())},
+		},
+	)
+	argoClientSet := appfake.NewSimpleClientset()
+	// the fake clientsets do not generate names, which kubectl apply requires anyway
+	clientSet.PrependReactor("create", "*", generateName)
+	argoClientSet.PrependReactor("create", "*", generateName)
+	return &ListClientSets{ClientSet: clientSet, ArgoClientSet: argoClientSet}
+}
+
+func generateName(action k8stesting.Action) (bool, runtime.Object, error) {
+	obj := action.(k8stesting.CreateAction).GetObject()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false, nil, err
+	}
+	if accessor.GetName() == "" && accessor.GetGenerateName() != "" {
+		accessor.SetName(accessor.GetGenerateName() + GetRandomString())
+		accessor.SetGenerateName("")
+	}
+	return false, nil, nil
+}
+
+// Print writes the generated objects as a v1/List, in the given format, yaml or json
+func (c *ListClientSets) Print(w io.Writer, namespace string, format string) error {
+	listOpts := metav1.ListOptions{LabelSelector: GeneratedBySelector}
+	list := corev1.List{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"}}
+	add := func(obj runtime.Object, typeMeta metav1.TypeMeta) error {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		accessor.SetResourceVersion("")
+		accessor.SetManagedFields(nil)
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		var item map[string]any
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		item["apiVersion"] = typeMeta.APIVersion
+		item["kind"] = typeMeta.Kind
+		// the status is not applied by kubectl
+		delete(item, "status")
+		if data, err = json.Marshal(item); err != nil {
+			return err
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: data})
+		return nil
+	}
+
+	secrets, err := c.ClientSet.CoreV1().Secrets(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		if err := add(&secrets.Items[i], metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}); err != nil {
+			return err
+		}
+	}
+	projects, err := c.ArgoClientSet.ArgoprojV1alpha1().AppProjects(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return err
+	}
+	for i := range projects.Items {
+		if err := add(&projects.Items[i], metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.AppProjectSchemaGroupVersionKind.Kind}); err != nil {
+			return err
+		}
+	}
+	appSets, err := c.ArgoClientSet.ArgoprojV1alpha1().ApplicationSets(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return err
+	}
+	for i := range appSets.Items {
+		if err := add(&appSets.Items[i], metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.ApplicationSetSchemaGroupVersionKind.Kind}); err != nil {
+			return err
+		}
+	}
+	apps, err := c.ArgoClientSet.ArgoprojV1alpha1().Applications(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return err
+	}
+	for i := range apps.Items {
+		if err := add(&apps.Items[i], metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.ApplicationSchemaGroupVersionKind.Kind}); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "json":
+	case "yaml":
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+	_, err = w.Write(data)
+	return err
+}
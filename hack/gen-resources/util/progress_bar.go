@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"os"
 )
 
 // Bar is a simple progress bar for command line applications.
@@ -39,9 +40,9 @@ func (bar *Bar) Play() {
 	if bar.percent != last && bar.percent%2 == 0 {
 		bar.rate += bar.graph
 	}
-	fmt.Printf("\r[%-50s]%3d%% %8d/%d", bar.rate, bar.percent, bar.cur, bar.total)
+	fmt.Fprintf(os.Stderr, "\r[%-50s]%3d%% %8d/%d", bar.rate, bar.percent, bar.cur, bar.total)
 }
 
 func (bar *Bar) Finish() {
-	fmt.Println()
+	fmt.Fprintln(os.Stderr)
 }
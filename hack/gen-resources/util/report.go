@@ -0,0 +1,230 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Report is the machine-readable summary of a generate or clean run, written to the ReportPath of the options
+type Report struct {
+	Command         string         `json:"command"`
+	StartedAt       time.Time      `json:"startedAt"`
+	FinishedAt      time.Time      `json:"finishedAt"`
+	DurationSeconds float64        `json:"durationSeconds"`
+	Phases          []*PhaseReport `json:"phases"`
+
+	lock sync.Mutex
+}
+
+// PhaseReport summarizes the objects generated or cleaned by one of the generators
+type PhaseReport struct {
+	Name            string  `json:"name"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Created         int     `json:"created"`
+	Deleted         int     `json:"deleted"`
+	// Failed counts the failures by category, the reason of the API error if any
+	Failed map[string]int `json:"failed,omitempty"`
+	// Distributions count the generated objects by value, e.g. by server version
+	Distributions map[string]map[string]int `json:"distributions,omitempty"`
+	// Stages are the durations of the stages of the generation of the objects, e.g. the helm install of the clusters
+	Stages map[string]StageStats `json:"stages,omitempty"`
+	// Clusters are the results of the generation of each cluster
+	Clusters []ClusterResult `json:"clusters,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// StageStats are the durations of a stage of the generation of the objects of a phase
+type StageStats struct {
+	// Count is the number of objects which went through the stage
+	Count        int     `json:"count"`
+	TotalSeconds float64 `json:"totalSeconds"`
+	MaxSeconds   float64 `json:"maxSeconds"`
+}
+
+// ClusterResult is the result of the generation of a cluster
+type ClusterResult struct {
+	Index int    `json:"index"`
+	Name  string `json:"name,omitempty"`
+	// Server is the URL of the cluster, empty if the generation failed before it was known
+	Server string `json:"server,omitempty"`
+	// Namespace is the namespace the vcluster is installed in, empty for the clusters registered from a template
+	Namespace string `json:"namespace,omitempty"`
+	// ReleaseSuffix is the suffix of the helm release of the vcluster, empty for the clusters registered from a template
+	ReleaseSuffix string `json:"releaseSuffix,omitempty"`
+	// Instance is the Argo CD instance the cluster is registered in, empty for the default one
+	Instance string `json:"instance,omitempty"`
+	Success  bool   `json:"success"`
+	// Phase is the last phase of the generation, install, extract or create
+	Phase           string  `json:"phase"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Error           string  `json:"error,omitempty"`
+	// ConnectionStatus is the status of the connection to the created cluster, empty if VerifyConnection is not set
+	ConnectionStatus string `json:"connectionStatus,omitempty"`
+	// ConnectionLatencySeconds is the duration of the request of the version of the cluster
+	ConnectionLatencySeconds float64 `json:"connectionLatencySeconds,omitempty"`
+}
+
+// NewReport returns the report of the given command, started now
+func NewReport(command string) *Report {
+	return &Report{Command: command, StartedAt: time.Now()}
+}
+
+// RunPhase runs the given phase and records its duration and error. The report may be nil.
+func (r *Report) RunPhase(name string, run func() error) error {
+	if r == nil {
+		return run()
+	}
+	phase := r.phase(name)
+	started := time.Now()
+	err := run()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	phase.DurationSeconds += time.Since(started).Seconds()
+	if err != nil {
+		phase.Error = err.Error()
+	}
+	return err
+}
+
+func (r *Report) phase(name string) *PhaseReport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, phase := range r.Phases {
+		if phase.Name == name {
+			return phase
+		}
+	}
+	phase := &PhaseReport{Name: name, Failed: map[string]int{}, Distributions: map[string]map[string]int{}, Stages: map[string]StageStats{}}
+	r.Phases = append(r.Phases, phase)
+	return phase
+}
+
+// Created records the creation of count objects in the given phase. The report may be nil.
+func (r *Report) Created(name string, count int) {
+	if r == nil {
+		return
+	}
+	phase := r.phase(name)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	phase.Created += count
+}
+
+// Deleted records the deletion of count objects in the given phase. The report may be nil.
+func (r *Report) Deleted(name string, count int) {
+	if r == nil {
+		return
+	}
+	phase := r.phase(name)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	phase.Deleted += count
+}
+
+// Failed records a failure in the given phase, categorized by the reason of the error. The report may be nil.
+func (r *Report) Failed(name string, err error) {
+	if r == nil {
+		return
+	}
+	phase := r.phase(name)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	phase.Failed[failureCategory(err)]++
+}
+
+// Distribution records count objects with the given value of the distribution in the given phase. The report may be
+// nil.
+func (r *Report) Distribution(name, distribution, value string, count int) {
+	if r == nil {
+		return
+	}
+	phase := r.phase(name)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if phase.Distributions[distribution] == nil {
+		phase.Distributions[distribution] = map[string]int{}
+	}
+	phase.Distributions[distribution][value] += count
+}
+
+// Stage records the durations of the given stage in the given phase. The report may be nil.
+func (r *Report) Stage(name, stage string, stats StageStats) {
+	if r == nil {
+		return
+	}
+	phase := r.phase(name)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	phase.Stages[stage] = stats
+}
+
+// ClusterResult records the result of the generation of a cluster in the given phase. The report may be nil.
+func (r *Report) ClusterResult(name string, result ClusterResult) {
+	if r == nil {
+		return
+	}
+	phase := r.phase(name)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	phase.Clusters = append(phase.Clusters, result)
+}
+
+// ClusterResults returns the results of the generation of the clusters recorded in the given phase
+func (r *Report) ClusterResults(name string) []ClusterResult {
+	for _, phase := range r.Phases {
+		if phase.Name == name {
+			return phase.Clusters
+		}
+	}
+	return nil
+}
+
+// ReadReport reads the JSON report written by a previous run to the given path
+func ReadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse the report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+func failureCategory(err error) string {
+	if reason := apierrors.ReasonForError(err); reason != "" {
+		return string(reason)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "Timeout"
+	}
+	return "Other"
+}
+
+// Write finishes the report and writes it as JSON to the given path. The report may be nil.
+func (r *Report) Write(path string) error {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, phase := range r.Phases {
+		slices.SortFunc(phase.Clusters, func(a, b ClusterResult) int { return a.Index - b.Index })
+	}
+	r.FinishedAt = time.Now()
+	r.DurationSeconds = r.FinishedAt.Sub(r.StartedAt).Seconds()
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
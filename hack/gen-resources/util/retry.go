@@ -0,0 +1,56 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxRetryBackoff caps the exponential backoff between the attempts of Retry
+const maxRetryBackoff = time.Minute
+
+// permanentError is an error Retry does not retry
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps the error of a function called by Retry so that it is not retried
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Retry calls fn until it succeeds, returns a Permanent error or is called attempts times. It waits for backoff after
+// the first attempt, doubling the wait after each attempt up to a minute, and stops waiting once the context is
+// canceled. The last error of fn is returned wrapped with the number of attempts.
+func Retry(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return fmt.Errorf("failed after %d attempts: %w", attempt, permanent.err)
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("failed after %d attempts: %w", attempt, err)
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("interrupted after %d attempts: %w", attempt, errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
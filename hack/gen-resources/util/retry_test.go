@@ -0,0 +1,62 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	errTransient := errors.New("connection refused")
+
+	t.Run("SucceedsOnNthAttempt", func(t *testing.T) {
+		calls := 0
+		err := Retry(t.Context(), 5, time.Millisecond, func() error {
+			calls++
+			if calls < 3 {
+				return errTransient
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("AlwaysFails", func(t *testing.T) {
+		calls := 0
+		err := Retry(t.Context(), 4, time.Millisecond, func() error {
+			calls++
+			return errTransient
+		})
+		require.ErrorIs(t, err, errTransient)
+		assert.EqualError(t, err, "failed after 4 attempts: connection refused")
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("Permanent", func(t *testing.T) {
+		calls := 0
+		err := Retry(t.Context(), 4, time.Millisecond, func() error {
+			calls++
+			return Permanent(errTransient)
+		})
+		assert.EqualError(t, err, "failed after 1 attempts: connection refused")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		calls := 0
+		err := Retry(ctx, 4, time.Hour, func() error {
+			calls++
+			cancel()
+			return errTransient
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, errTransient)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -34,18 +34,27 @@ package util
 
 import (
 	"context"
+	"errors"
 	"math"
 	"sync"
+	"time"
 )
 
+// ErrCanceled is returned by AddWithContext once the
+// SizedWaitGroup is canceled, see Cancel.
+var ErrCanceled = errors.New("sized wait group canceled")
+
 // SizedWaitGroup has the same role and close to the
 // same API as the Golang sync.WaitGroup but adds a limit of
 // the amount of goroutines started concurrently.
 type SizedWaitGroup struct {
-	Size int
-
-	current chan struct{}
-	wg      sync.WaitGroup
+	lock *sync.Mutex
+	// size is changed by Resize, it is guarded by the lock
+	size     int
+	current  int
+	canceled bool
+	released chan struct{}
+	wg       *sync.WaitGroup
 }
 
 // New creates a SizedWaitGroup.
@@ -57,10 +66,10 @@ func New(limit int) SizedWaitGroup {
 		size = limit
 	}
 	return SizedWaitGroup{
-		Size: size,
-
-		current: make(chan struct{}, size),
-		wg:      sync.WaitGroup{},
+		lock:     &sync.Mutex{},
+		size:     size,
+		released: make(chan struct{}),
+		wg:       &sync.WaitGroup{},
 	}
 }
 
@@ -69,9 +78,40 @@ func New(limit int) SizedWaitGroup {
 // has been reached. It will stop blocking when Done is
 // been called.
 //
+// Add is not stopped by Cancel, the callers which must stop
+// starting goroutines once the group is canceled use
+// AddWithContext.
+//
 // See sync.WaitGroup documentation for more information.
 func (s *SizedWaitGroup) Add() {
-	_ = s.AddWithContext(context.Background())
+	_ = s.add(context.Background(), false)
+}
+
+// add increments the internal WaitGroup counter once a goroutine
+// can be started, or fails if the context is canceled, or if the
+// group is canceled and cancelable is set.
+func (s *SizedWaitGroup) add(ctx context.Context, cancelable bool) error {
+	for {
+		s.lock.Lock()
+		if cancelable && s.canceled {
+			s.lock.Unlock()
+			return ErrCanceled
+		}
+		if s.current < s.size {
+			s.current++
+			s.wg.Add(1)
+			s.lock.Unlock()
+			return nil
+		}
+		released := s.released
+		s.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
 }
 
 // AddWithContext increments the internal WaitGroup counter.
@@ -79,23 +119,38 @@ func (s *SizedWaitGroup) Add() {
 // has been reached. It will stop blocking when Done is
 // been called, or when the context is canceled. Returns nil on
 // success or an error if the context is canceled before the lock
-// is acquired.
+// is acquired, or ErrCanceled once the group is canceled.
 //
 // See sync.WaitGroup documentation for more information.
 func (s *SizedWaitGroup) AddWithContext(ctx context.Context) error {
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case s.current <- struct{}{}:
-	}
-	s.wg.Add(1)
-	return nil
+	return s.add(ctx, true)
+}
+
+// Cancel stops the SizedWaitGroup from starting new goroutines,
+// the pending and later calls to AddWithContext return
+// ErrCanceled. The goroutines already started are not stopped,
+// Wait still waits for them.
+func (s *SizedWaitGroup) Cancel() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.canceled = true
+	s.release()
+}
+
+// Canceled returns whether the SizedWaitGroup is canceled.
+func (s *SizedWaitGroup) Canceled() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.canceled
 }
 
 // Done decrements the SizedWaitGroup counter.
 // See sync.WaitGroup documentation for more information.
 func (s *SizedWaitGroup) Done() {
-	<-s.current
+	s.lock.Lock()
+	s.current--
+	s.release()
+	s.lock.Unlock()
 	s.wg.Done()
 }
 
@@ -104,3 +159,63 @@ func (s *SizedWaitGroup) Done() {
 func (s *SizedWaitGroup) Wait() {
 	s.wg.Wait()
 }
+
+// WaitWithTimeout blocks until the SizedWaitGroup counter is zero
+// or the timeout elapses, and returns the amount of goroutines
+// still in flight, zero if they are all done. It does not wait if
+// the timeout is not positive.
+func (s *SizedWaitGroup) WaitWithTimeout(timeout time.Duration) int {
+	if timeout <= 0 {
+		return s.InFlight()
+	}
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return 0
+	case <-timer.C:
+		return s.InFlight()
+	}
+}
+
+// InFlight returns the amount of goroutines currently started
+// and not done yet.
+func (s *SizedWaitGroup) InFlight() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.current
+}
+
+// Limit returns the current maximum amount of goroutines which
+// can be started concurrently.
+func (s *SizedWaitGroup) Limit() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.size
+}
+
+// Resize changes the maximum amount of goroutines which can be
+// started concurrently. Lowering the limit does not stop the
+// goroutines already started, Add blocks until enough of them
+// are done.
+func (s *SizedWaitGroup) Resize(limit int) {
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.size = limit
+	s.release()
+}
+
+// release wakes up the callers blocked in AddWithContext, it
+// must be called with the lock held.
+func (s *SizedWaitGroup) release() {
+	close(s.released)
+	s.released = make(chan struct{})
+}
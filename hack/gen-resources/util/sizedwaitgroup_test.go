@@ -0,0 +1,53 @@
+package util
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizedWaitGroupCancel(t *testing.T) {
+	wg := New(1)
+	release := make(chan struct{})
+	var completed atomic.Int32
+	require.NoError(t, wg.AddWithContext(t.Context()))
+	go func() {
+		defer wg.Done()
+		<-release
+		completed.Add(1)
+	}()
+
+	// blocked until the started goroutine is done
+	pending := make(chan error)
+	go func() {
+		pending <- wg.AddWithContext(t.Context())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	wg.Cancel()
+	select {
+	case err := <-pending:
+		require.ErrorIs(t, err, ErrCanceled)
+	case <-time.After(time.Second):
+		t.Fatal("pending AddWithContext not released by Cancel")
+	}
+	require.ErrorIs(t, wg.AddWithContext(t.Context()), ErrCanceled)
+	assert.True(t, wg.Canceled())
+	assert.Equal(t, 1, wg.InFlight())
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), completed.Load())
+	assert.Equal(t, 0, wg.InFlight())
+}
+
+func TestSizedWaitGroupAddNotCanceled(t *testing.T) {
+	wg := New(1)
+	wg.Cancel()
+	wg.Add()
+	assert.Equal(t, 1, wg.InFlight())
+	wg.Done()
+	wg.Wait()
+}
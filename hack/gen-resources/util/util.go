@@ -1,21 +1,38 @@
 package util
 
 import (
-	"crypto/rand"
-	"math/big"
+	"math/rand"
+	"sync"
+	"time"
 )
 
 var letters = []rune("abcdefghijklmnopqrstuvwxyz123456789")
 
+var (
+	randomLock sync.Mutex
+	// random draws the random strings, it is not safe for concurrent use
+	random = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// SeedRandom seeds the random strings of GetRandomString, a zero seed being replaced with a time based one, and returns
+// the seed. The same seed draws the same sequence of strings, so that the objects generated one at a time get the same
+// names.
+func SeedRandom(seed int64) int64 {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	randomLock.Lock()
+	defer randomLock.Unlock()
+	random = rand.New(rand.NewSource(seed))
+	return seed
+}
+
 func GetRandomString() string {
+	randomLock.Lock()
+	defer randomLock.Unlock()
 	b := make([]rune, 24)
 	for i := range b {
-		b[i] = letters[cryptoRandSecure(int64(len(letters)))]
+		b[i] = letters[random.Intn(len(letters))]
 	}
 	return string(b)
 }
-
-func cryptoRandSecure(maximum int64) int64 {
-	nBig, _ := rand.Int(rand.Reader, big.NewInt(maximum))
-	return nBig.Int64()
-}
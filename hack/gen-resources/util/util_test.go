@@ -0,0 +1,21 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedRandom(t *testing.T) {
+	names := func(seed int64) []string {
+		assert.Equal(t, seed, SeedRandom(seed))
+		var names []string
+		for i := 0; i < 5; i++ {
+			names = append(names, GetRandomString())
+		}
+		return names
+	}
+	assert.Equal(t, names(42), names(42))
+	assert.NotEqual(t, names(42), names(43))
+	assert.NotZero(t, SeedRandom(0))
+}
@@ -3127,6 +3127,307 @@ func (m *ListAppLinksRequest) GetProject() string {
 	return ""
 }
 
+// ApplicationHistoryQuery is a query for the deployment history of an application
+type ApplicationHistoryQuery struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	AppNamespace         *string  `protobuf:"bytes,2,opt,name=appNamespace" json:"appNamespace,omitempty"`
+	Project              *string  `protobuf:"bytes,3,opt,name=project" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplicationHistoryQuery) Reset()         { *m = ApplicationHistoryQuery{} }
+func (m *ApplicationHistoryQuery) String() string { return proto.CompactTextString(m) }
+func (*ApplicationHistoryQuery) ProtoMessage()    {}
+func (*ApplicationHistoryQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df6e82b174b5eaec, []int{39}
+}
+func (m *ApplicationHistoryQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplicationHistoryQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplicationHistoryQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ApplicationHistoryQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationHistoryQuery.Merge(m, src)
+}
+func (m *ApplicationHistoryQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplicationHistoryQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationHistoryQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationHistoryQuery proto.InternalMessageInfo
+
+func (m *ApplicationHistoryQuery) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ApplicationHistoryQuery) GetAppNamespace() string {
+	if m != nil && m.AppNamespace != nil {
+		return *m.AppNamespace
+	}
+	return ""
+}
+
+func (m *ApplicationHistoryQuery) GetProject() string {
+	if m != nil && m.Project != nil {
+		return *m.Project
+	}
+	return ""
+}
+
+// ApplicationHistoryResponse is the deployment history of an application
+type ApplicationHistoryResponse struct {
+	// items are the entries of the application status and of the revision history archive, ordered by ID
+	Items []*v1alpha1.RevisionHistory `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	// archived are the IDs of the items which are only kept in the revision history archive
+	Archived             []int64  `protobuf:"varint,2,rep,name=archived" json:"archived,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplicationHistoryResponse) Reset()         { *m = ApplicationHistoryResponse{} }
+func (m *ApplicationHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ApplicationHistoryResponse) ProtoMessage()    {}
+func (*ApplicationHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df6e82b174b5eaec, []int{40}
+}
+func (m *ApplicationHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplicationHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplicationHistoryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ApplicationHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationHistoryResponse.Merge(m, src)
+}
+func (m *ApplicationHistoryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplicationHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationHistoryResponse proto.InternalMessageInfo
+
+func (m *ApplicationHistoryResponse) GetItems() []*v1alpha1.RevisionHistory {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *ApplicationHistoryResponse) GetArchived() []int64 {
+	if m != nil {
+		return m.Archived
+	}
+	return nil
+}
+
+// ApplicationGraphQuery is a query for the graph of the Applications managed by an app-of-apps
+type ApplicationGraphQuery struct {
+	Name         *string `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	AppNamespace *string `protobuf:"bytes,2,opt,name=appNamespace" json:"appNamespace,omitempty"`
+	Project      *string `protobuf:"bytes,3,opt,name=project" json:"project,omitempty"`
+	// maxDepth is the maximum number of levels of the graph to walk, 0 for no limit
+	MaxDepth             *int64   `protobuf:"varint,4,opt,name=maxDepth" json:"maxDepth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplicationGraphQuery) Reset()         { *m = ApplicationGraphQuery{} }
+func (m *ApplicationGraphQuery) String() string { return proto.CompactTextString(m) }
+func (*ApplicationGraphQuery) ProtoMessage()    {}
+func (*ApplicationGraphQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df6e82b174b5eaec, []int{41}
+}
+func (m *ApplicationGraphQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplicationGraphQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplicationGraphQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ApplicationGraphQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationGraphQuery.Merge(m, src)
+}
+func (m *ApplicationGraphQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplicationGraphQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationGraphQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationGraphQuery proto.InternalMessageInfo
+
+func (m *ApplicationGraphQuery) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ApplicationGraphQuery) GetAppNamespace() string {
+	if m != nil && m.AppNamespace != nil {
+		return *m.AppNamespace
+	}
+	return ""
+}
+
+func (m *ApplicationGraphQuery) GetProject() string {
+	if m != nil && m.Project != nil {
+		return *m.Project
+	}
+	return ""
+}
+
+func (m *ApplicationGraphQuery) GetMaxDepth() int64 {
+	if m != nil && m.MaxDepth != nil {
+		return *m.MaxDepth
+	}
+	return 0
+}
+
+// ApplicationGraphNode is an Application of an app-of-apps graph, with the Applications it manages as children
+type ApplicationGraphNode struct {
+	Name      *string `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Namespace *string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	Project   *string `protobuf:"bytes,3,opt,name=project" json:"project,omitempty"`
+	Sync      *string `protobuf:"bytes,4,opt,name=sync" json:"sync,omitempty"`
+	Health    *string `protobuf:"bytes,5,opt,name=health" json:"health,omitempty"`
+	// error is set if the Application could not be retrieved, e.g. because the user is not permitted to get it
+	Error *string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+	// cycle is set if the Application is also an ancestor of the node, its children are not walked again
+	Cycle                *bool                   `protobuf:"varint,7,opt,name=cycle" json:"cycle,omitempty"`
+	Children             []*ApplicationGraphNode `protobuf:"bytes,8,rep,name=children" json:"children,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ApplicationGraphNode) Reset()         { *m = ApplicationGraphNode{} }
+func (m *ApplicationGraphNode) String() string { return proto.CompactTextString(m) }
+func (*ApplicationGraphNode) ProtoMessage()    {}
+func (*ApplicationGraphNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df6e82b174b5eaec, []int{42}
+}
+func (m *ApplicationGraphNode) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplicationGraphNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplicationGraphNode.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ApplicationGraphNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationGraphNode.Merge(m, src)
+}
+func (m *ApplicationGraphNode) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplicationGraphNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationGraphNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationGraphNode proto.InternalMessageInfo
+
+func (m *ApplicationGraphNode) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ApplicationGraphNode) GetNamespace() string {
+	if m != nil && m.Namespace != nil {
+		return *m.Namespace
+	}
+	return ""
+}
+
+func (m *ApplicationGraphNode) GetProject() string {
+	if m != nil && m.Project != nil {
+		return *m.Project
+	}
+	return ""
+}
+
+func (m *ApplicationGraphNode) GetSync() string {
+	if m != nil && m.Sync != nil {
+		return *m.Sync
+	}
+	return ""
+}
+
+func (m *ApplicationGraphNode) GetHealth() string {
+	if m != nil && m.Health != nil {
+		return *m.Health
+	}
+	return ""
+}
+
+func (m *ApplicationGraphNode) GetError() string {
+	if m != nil && m.Error != nil {
+		return *m.Error
+	}
+	return ""
+}
+
+func (m *ApplicationGraphNode) GetCycle() bool {
+	if m != nil && m.Cycle != nil {
+		return *m.Cycle
+	}
+	return false
+}
+
+func (m *ApplicationGraphNode) GetChildren() []*ApplicationGraphNode {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ApplicationQuery)(nil), "application.ApplicationQuery")
 	proto.RegisterType((*NodeQuery)(nil), "application.NodeQuery")
@@ -3167,6 +3468,10 @@ func init() {
 	proto.RegisterType((*LinkInfo)(nil), "application.LinkInfo")
 	proto.RegisterType((*LinksResponse)(nil), "application.LinksResponse")
 	proto.RegisterType((*ListAppLinksRequest)(nil), "application.ListAppLinksRequest")
+	proto.RegisterType((*ApplicationHistoryQuery)(nil), "application.ApplicationHistoryQuery")
+	proto.RegisterType((*ApplicationHistoryResponse)(nil), "application.ApplicationHistoryResponse")
+	proto.RegisterType((*ApplicationGraphQuery)(nil), "application.ApplicationGraphQuery")
+	proto.RegisterType((*ApplicationGraphNode)(nil), "application.ApplicationGraphNode")
 }
 
 func init() {
@@ -3174,196 +3479,208 @@ func init() {
 }
 
 var fileDescriptor_df6e82b174b5eaec = []byte{
-	// 3017 bytes of a gzipped FileDescriptorProto
+	// 3206 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5b, 0xcd, 0x8f, 0x1c, 0x47,
-	0x15, 0xa7, 0x66, 0x76, 0x76, 0x67, 0xdf, 0x78, 0xfd, 0x51, 0xb1, 0x4d, 0x67, 0xbc, 0x31, 0x9b,
-	0xb6, 0x1d, 0x6f, 0xd6, 0xde, 0x19, 0x7b, 0x62, 0x20, 0xd9, 0x24, 0x04, 0x67, 0xed, 0x38, 0x0b,
-	0x6b, 0xc7, 0xf4, 0x3a, 0x31, 0x0a, 0x07, 0xa8, 0x74, 0xd7, 0xcc, 0x34, 0xdb, 0xd3, 0xdd, 0xee,
-	0xee, 0x99, 0xb0, 0x0a, 0xb9, 0x04, 0x21, 0x71, 0x88, 0x82, 0x80, 0x1c, 0x38, 0xf0, 0x99, 0x28,
-	0x08, 0x21, 0x10, 0x17, 0x84, 0x90, 0x10, 0x12, 0x1c, 0x82, 0xe0, 0x80, 0x84, 0xe0, 0x1f, 0x40,
-	0x11, 0xe2, 0xc0, 0x21, 0xb9, 0xe4, 0x8c, 0x50, 0x55, 0x57, 0x75, 0x77, 0xcd, 0x47, 0xcf, 0x2c,
-	0x33, 0x10, 0x4b, 0xdc, 0xfa, 0xd5, 0x74, 0xbf, 0xf7, 0x7b, 0xaf, 0xde, 0x7b, 0xf5, 0xea, 0xbd,
-	0x5d, 0x38, 0x1d, 0xd2, 0xa0, 0x47, 0x83, 0x3a, 0xf1, 0x7d, 0xc7, 0x36, 0x49, 0x64, 0x7b, 0x6e,
-	0xf6, 0xb9, 0xe6, 0x07, 0x5e, 0xe4, 0xe1, 0x4a, 0x66, 0xa9, 0xba, 0xdc, 0xf2, 0xbc, 0x96, 0x43,
-	0xeb, 0xc4, 0xb7, 0xeb, 0xc4, 0x75, 0xbd, 0x88, 0x2f, 0x87, 0xf1, 0xab, 0x55, 0x7d, 0xf7, 0xe1,
-	0xb0, 0x66, 0x7b, 0xfc, 0x57, 0xd3, 0x0b, 0x68, 0xbd, 0x77, 0xb1, 0xde, 0xa2, 0x2e, 0x0d, 0x48,
-	0x44, 0x2d, 0xf1, 0xce, 0xa5, 0xf4, 0x9d, 0x0e, 0x31, 0xdb, 0xb6, 0x4b, 0x83, 0xbd, 0xba, 0xbf,
-	0xdb, 0x62, 0x0b, 0x61, 0xbd, 0x43, 0x23, 0x32, 0xec, 0xab, 0xed, 0x96, 0x1d, 0xb5, 0xbb, 0x2f,
-	0xd4, 0x4c, 0xaf, 0x53, 0x27, 0x41, 0xcb, 0xf3, 0x03, 0xef, 0x8b, 0xfc, 0x61, 0xdd, 0xb4, 0xea,
-	0xbd, 0x87, 0x52, 0x06, 0x59, 0x5d, 0x7a, 0x17, 0x89, 0xe3, 0xb7, 0xc9, 0x20, 0xb7, 0xab, 0x63,
-	0xb8, 0x05, 0xd4, 0xf7, 0x84, 0x6d, 0xf8, 0xa3, 0x1d, 0x79, 0xc1, 0x5e, 0xe6, 0x31, 0x66, 0xa3,
-	0xbf, 0x8f, 0xe0, 0xf0, 0xe5, 0x54, 0xde, 0x67, 0xba, 0x34, 0xd8, 0xc3, 0x18, 0xe6, 0x5c, 0xd2,
-	0xa1, 0x1a, 0x5a, 0x41, 0xab, 0x8b, 0x06, 0x7f, 0xc6, 0x1a, 0x2c, 0x04, 0xb4, 0x19, 0xd0, 0xb0,
-	0xad, 0x15, 0xf8, 0xb2, 0x24, 0x71, 0x15, 0xca, 0x4c, 0x38, 0x35, 0xa3, 0x50, 0x2b, 0xae, 0x14,
-	0x57, 0x17, 0x8d, 0x84, 0xc6, 0xab, 0x70, 0x28, 0xa0, 0xa1, 0xd7, 0x0d, 0x4c, 0xfa, 0x1c, 0x0d,
-	0x42, 0xdb, 0x73, 0xb5, 0x39, 0xfe, 0x75, 0xff, 0x32, 0xe3, 0x12, 0x52, 0x87, 0x9a, 0x91, 0x17,
-	0x68, 0x25, 0xfe, 0x4a, 0x42, 0x33, 0x3c, 0x0c, 0xb8, 0x36, 0x1f, 0xe3, 0x61, 0xcf, 0x58, 0x87,
-	0x03, 0xc4, 0xf7, 0x6f, 0x90, 0x0e, 0x0d, 0x7d, 0x62, 0x52, 0x6d, 0x81, 0xff, 0xa6, 0xac, 0x31,
-	0xcc, 0x02, 0x89, 0x56, 0xe6, 0xc0, 0x24, 0xa9, 0x6f, 0xc2, 0xe2, 0x0d, 0xcf, 0xa2, 0xa3, 0xd5,
-	0xed, 0x67, 0x5f, 0x18, 0x64, 0xaf, 0xbf, 0x8d, 0xe0, 0x98, 0x41, 0x7b, 0x36, 0xc3, 0x7f, 0x9d,
-	0x46, 0xc4, 0x22, 0x11, 0xe9, 0xe7, 0x58, 0x48, 0x38, 0x56, 0xa1, 0x1c, 0x88, 0x97, 0xb5, 0x02,
-	0x5f, 0x4f, 0xe8, 0x01, 0x69, 0xc5, 0x7c, 0x65, 0x62, 0x13, 0x4a, 0x12, 0xaf, 0x40, 0x25, 0xb6,
-	0xe5, 0x96, 0x6b, 0xd1, 0x2f, 0x71, 0xeb, 0x95, 0x8c, 0xec, 0x12, 0x5e, 0x86, 0xc5, 0x5e, 0x6c,
-	0xe7, 0x2d, 0x8b, 0x5b, 0xb1, 0x64, 0xa4, 0x0b, 0xfa, 0x3f, 0x10, 0x9c, 0xcc, 0xf8, 0x80, 0x21,
-	0x76, 0xe6, 0x6a, 0x8f, 0xba, 0x51, 0x38, 0x5a, 0xa1, 0xf3, 0x70, 0x44, 0x6e, 0x62, 0xbf, 0x9d,
-	0x06, 0x7f, 0x60, 0x2a, 0x66, 0x17, 0xa5, 0x8a, 0xd9, 0x35, 0xa6, 0x88, 0xa4, 0x9f, 0xdd, 0xba,
-	0x22, 0xd4, 0xcc, 0x2e, 0x0d, 0x18, 0xaa, 0x94, 0x6f, 0xa8, 0x79, 0xc5, 0x50, 0xfa, 0x3f, 0x11,
-	0x68, 0x19, 0x45, 0xaf, 0x13, 0xd7, 0x6e, 0xd2, 0x30, 0x9a, 0x74, 0xcf, 0xd0, 0x0c, 0xf7, 0x6c,
-	0x15, 0x0e, 0xc5, 0x5a, 0xdd, 0x64, 0xf1, 0xc8, 0xf2, 0x8f, 0x56, 0x5a, 0x29, 0xae, 0x16, 0x8d,
-	0xfe, 0x65, 0xb6, 0x77, 0x52, 0x66, 0xa8, 0xcd, 0x73, 0x37, 0x4e, 0x17, 0x98, 0x04, 0xd7, 0xdb,
-	0x24, 0x66, 0x3b, 0x8e, 0x80, 0xb2, 0x21, 0x49, 0xfd, 0x7e, 0x58, 0x7c, 0xca, 0x76, 0xe8, 0x66,
-	0xbb, 0xeb, 0xee, 0xe2, 0xa3, 0x50, 0x32, 0xd9, 0x03, 0xd7, 0xee, 0x80, 0x11, 0x13, 0xfa, 0x37,
-	0x10, 0xdc, 0x3f, 0xca, 0x1e, 0xb7, 0xed, 0xa8, 0xcd, 0xbe, 0x0f, 0x47, 0x19, 0xc6, 0x6c, 0x53,
-	0x73, 0x37, 0xec, 0x76, 0xa4, 0x33, 0x4b, 0x7a, 0x3a, 0xc3, 0xe8, 0x3f, 0x41, 0xb0, 0x3a, 0x16,
-	0xd3, 0xed, 0x80, 0xf8, 0x3e, 0x0d, 0xf0, 0x53, 0x50, 0xba, 0xc3, 0x7e, 0xe0, 0xa1, 0x5b, 0x69,
-	0xd4, 0x6a, 0xd9, 0xd4, 0x3f, 0x96, 0xcb, 0xd3, 0x1f, 0x32, 0xe2, 0xcf, 0x71, 0x4d, 0x9a, 0xa7,
-	0xc0, 0xf9, 0x1c, 0x57, 0xf8, 0x24, 0x56, 0x64, 0xef, 0xf3, 0xd7, 0x9e, 0x9c, 0x87, 0x39, 0x9f,
-	0x04, 0x91, 0x7e, 0x0c, 0xee, 0x51, 0x03, 0xc7, 0xf7, 0xdc, 0x90, 0xea, 0xbf, 0x56, 0xfd, 0x6c,
-	0x33, 0xa0, 0x24, 0xa2, 0x06, 0xbd, 0xd3, 0xa5, 0x61, 0x84, 0x77, 0x21, 0x7b, 0x1a, 0x71, 0xab,
-	0x56, 0x1a, 0x5b, 0xb5, 0x34, 0x9d, 0xd7, 0x64, 0x3a, 0xe7, 0x0f, 0x9f, 0x37, 0xad, 0x5a, 0xef,
-	0xa1, 0x9a, 0xbf, 0xdb, 0xaa, 0xb1, 0xc3, 0x41, 0x41, 0x26, 0x0f, 0x87, 0xac, 0xaa, 0x46, 0x96,
-	0x3b, 0x3e, 0x0e, 0xf3, 0x5d, 0x3f, 0xa4, 0x41, 0xc4, 0x35, 0x2b, 0x1b, 0x82, 0x62, 0xfb, 0xd7,
-	0x23, 0x8e, 0x6d, 0x91, 0x28, 0xde, 0x9f, 0xb2, 0x91, 0xd0, 0xfa, 0x6f, 0x54, 0xf4, 0xcf, 0xfa,
-	0xd6, 0x07, 0x85, 0x3e, 0x8b, 0xb2, 0xa0, 0xa2, 0xcc, 0x7a, 0x50, 0x51, 0xf5, 0xa0, 0x5f, 0xa8,
-	0xf8, 0xaf, 0x50, 0x87, 0xa6, 0xf8, 0x87, 0x39, 0xb3, 0x06, 0x0b, 0x26, 0x09, 0x4d, 0x62, 0x49,
-	0x29, 0x92, 0x64, 0x29, 0xce, 0x0f, 0x3c, 0x9f, 0xb4, 0x38, 0xa7, 0x9b, 0x9e, 0x63, 0x9b, 0x7b,
-	0x42, 0xdc, 0xe0, 0x0f, 0x03, 0x8e, 0x3f, 0x97, 0xef, 0xf8, 0x25, 0x15, 0xf6, 0x29, 0xa8, 0xec,
-	0xec, 0xb9, 0xe6, 0x33, 0x7e, 0x1c, 0xf6, 0x47, 0xa1, 0x64, 0x47, 0xb4, 0x13, 0x6a, 0x88, 0x87,
-	0x7c, 0x4c, 0xe8, 0xff, 0x2a, 0xc1, 0xf1, 0x8c, 0x6e, 0xec, 0x83, 0x3c, 0xcd, 0xf2, 0xf2, 0xd7,
-	0x71, 0x98, 0xb7, 0x82, 0x3d, 0xa3, 0xeb, 0x0a, 0x07, 0x10, 0x14, 0x13, 0xec, 0x07, 0x5d, 0x37,
-	0x86, 0x5f, 0x36, 0x62, 0x02, 0x37, 0xa1, 0x1c, 0x46, 0xac, 0xfe, 0x68, 0xed, 0x71, 0xe0, 0x95,
-	0xc6, 0xa7, 0xa6, 0xdb, 0x74, 0x06, 0x7d, 0x47, 0x70, 0x34, 0x12, 0xde, 0xf8, 0x0e, 0xcb, 0x76,
-	0x71, 0x0a, 0x0c, 0xb5, 0x85, 0x95, 0xe2, 0x6a, 0xa5, 0xb1, 0x33, 0xbd, 0xa0, 0x67, 0x7c, 0x56,
-	0x3b, 0x65, 0xce, 0x36, 0x23, 0x95, 0xc2, 0x12, 0x6c, 0x47, 0xe4, 0x87, 0x50, 0xd4, 0x09, 0xe9,
-	0x02, 0xfe, 0x2c, 0x94, 0x6c, 0xb7, 0xe9, 0x85, 0xda, 0x22, 0x07, 0xf3, 0xe4, 0x74, 0x60, 0xb6,
-	0xdc, 0xa6, 0x67, 0xc4, 0x0c, 0xf1, 0x1d, 0x58, 0x0a, 0x68, 0x14, 0xec, 0x49, 0x2b, 0x68, 0xc0,
-	0xed, 0xfa, 0xe9, 0xe9, 0x24, 0x18, 0x59, 0x96, 0x86, 0x2a, 0x01, 0x6f, 0x40, 0x25, 0x4c, 0x7d,
-	0x4c, 0xab, 0x70, 0x81, 0x9a, 0xc2, 0x28, 0xe3, 0x83, 0x46, 0xf6, 0xe5, 0x01, 0xef, 0x3e, 0x90,
-	0xef, 0xdd, 0x4b, 0x63, 0xcf, 0xbb, 0x83, 0x13, 0x9c, 0x77, 0x87, 0xfa, 0xce, 0x3b, 0xfd, 0x3d,
-	0x04, 0xcb, 0x03, 0xc9, 0x69, 0xc7, 0xa7, 0xb9, 0x61, 0x40, 0x60, 0x2e, 0xf4, 0xa9, 0xc9, 0x4f,
-	0xaa, 0x4a, 0xe3, 0xfa, 0xcc, 0xb2, 0x15, 0x97, 0xcb, 0x59, 0xe7, 0x25, 0xd4, 0x29, 0xf3, 0xc2,
-	0xf7, 0x11, 0x7c, 0x38, 0x23, 0xf3, 0x26, 0x89, 0xcc, 0x76, 0x9e, 0xb2, 0x2c, 0x7e, 0xd9, 0x3b,
-	0xe2, 0x5c, 0x8e, 0x09, 0x66, 0x55, 0xfe, 0x70, 0x6b, 0xcf, 0x67, 0x00, 0xd9, 0x2f, 0xe9, 0xc2,
-	0x94, 0x65, 0xd5, 0x4f, 0x11, 0x54, 0xb3, 0x39, 0xdc, 0x73, 0x9c, 0x17, 0x88, 0xb9, 0x9b, 0x07,
-	0xf2, 0x20, 0x14, 0x6c, 0x8b, 0x23, 0x2c, 0x1a, 0x05, 0xdb, 0xda, 0x67, 0x32, 0xea, 0x87, 0x3b,
-	0x9f, 0x0f, 0x77, 0x41, 0x85, 0xfb, 0x7e, 0x1f, 0x5c, 0x99, 0x12, 0x72, 0xe0, 0x2e, 0xc3, 0xa2,
-	0xdb, 0x57, 0xe2, 0xa6, 0x0b, 0x43, 0x4a, 0xdb, 0xc2, 0x40, 0x69, 0xab, 0xc1, 0x42, 0x2f, 0xb9,
-	0x00, 0xb1, 0x9f, 0x25, 0xc9, 0x54, 0x6c, 0x05, 0x5e, 0xd7, 0x17, 0x46, 0x8f, 0x09, 0x86, 0x62,
-	0xd7, 0x76, 0x59, 0xb1, 0xce, 0x51, 0xb0, 0xe7, 0xfd, 0x5f, 0x79, 0x14, 0xb5, 0x7f, 0x56, 0x80,
-	0x8f, 0x0c, 0x51, 0x7b, 0xac, 0x3f, 0xdd, 0x1d, 0xba, 0x27, 0x5e, 0xbd, 0x30, 0xd2, 0xab, 0xcb,
-	0xe3, 0xbc, 0x7a, 0x31, 0xdf, 0x5e, 0xa0, 0xda, 0xeb, 0xc7, 0x05, 0x58, 0x19, 0x62, 0xaf, 0xf1,
-	0xe5, 0xc4, 0x5d, 0x63, 0xb0, 0xa6, 0x17, 0x98, 0xf2, 0x5a, 0x10, 0x13, 0x2c, 0xce, 0xbc, 0xc0,
-	0x6f, 0x13, 0x97, 0x7b, 0x47, 0xd9, 0x10, 0xd4, 0x94, 0xa6, 0xba, 0x02, 0x9a, 0x34, 0xcf, 0x65,
-	0x33, 0x4e, 0x52, 0x01, 0xe9, 0xd0, 0x88, 0x06, 0xe1, 0xa8, 0x14, 0xd5, 0x23, 0x4e, 0x97, 0xca,
-	0x14, 0xc5, 0x09, 0xfd, 0xb5, 0x42, 0x3f, 0x1b, 0xa3, 0xeb, 0xde, 0xfd, 0x86, 0x3e, 0x0e, 0xf3,
-	0x84, 0xa3, 0x15, 0xae, 0x29, 0xa8, 0x01, 0x93, 0x96, 0xf3, 0x4d, 0xba, 0xa8, 0x98, 0x74, 0xa3,
-	0xa0, 0x21, 0xfd, 0xbd, 0x02, 0x54, 0x47, 0x19, 0xe4, 0xb9, 0xc6, 0xff, 0x9b, 0x49, 0x30, 0x01,
-	0x2d, 0x18, 0xe1, 0x65, 0x1a, 0xf0, 0xe2, 0xec, 0x8c, 0x72, 0x62, 0x8f, 0x72, 0x49, 0x63, 0x24,
-	0x1b, 0xfd, 0xab, 0x08, 0x4e, 0xa8, 0x9f, 0x85, 0xdb, 0x76, 0x18, 0xc9, 0x8b, 0x1d, 0x6e, 0xc2,
-	0x42, 0xac, 0x4a, 0x5c, 0x96, 0x57, 0x1a, 0xdb, 0xd3, 0x16, 0x6b, 0xca, 0xee, 0x4a, 0xe6, 0xfa,
-	0x23, 0x70, 0x62, 0xe8, 0x09, 0x25, 0x60, 0x54, 0xa1, 0x2c, 0x0b, 0x54, 0xb1, 0xfb, 0x09, 0xad,
-	0xbf, 0x39, 0xa7, 0x96, 0x0b, 0x9e, 0xb5, 0xed, 0xb5, 0x72, 0xba, 0x38, 0xf9, 0x1e, 0xc3, 0x76,
-	0xc3, 0xb3, 0x32, 0x0d, 0x1b, 0x49, 0xb2, 0xef, 0x4c, 0xcf, 0x8d, 0x88, 0xed, 0xd2, 0x40, 0x54,
-	0x34, 0xe9, 0x02, 0xdb, 0xe9, 0xd0, 0x76, 0x4d, 0xba, 0x43, 0x4d, 0xcf, 0xb5, 0x42, 0xee, 0x32,
-	0x45, 0x43, 0x59, 0xc3, 0x4f, 0xc3, 0x22, 0xa7, 0x6f, 0xd9, 0x9d, 0xf8, 0x08, 0xaf, 0x34, 0xd6,
-	0x6a, 0x71, 0x67, 0xb5, 0x96, 0xed, 0xac, 0xa6, 0x36, 0xec, 0xd0, 0x88, 0xd4, 0x7a, 0x17, 0x6b,
-	0xec, 0x0b, 0x23, 0xfd, 0x98, 0x61, 0x89, 0x88, 0xed, 0x6c, 0xdb, 0x2e, 0xbf, 0x34, 0x30, 0x51,
-	0xe9, 0x02, 0xf3, 0xc6, 0xa6, 0xe7, 0x38, 0xde, 0x8b, 0x32, 0xe7, 0xc5, 0x14, 0xfb, 0xaa, 0xeb,
-	0x46, 0xb6, 0xc3, 0xe5, 0xc7, 0xbe, 0x96, 0x2e, 0xf0, 0xaf, 0x6c, 0x27, 0xa2, 0x81, 0x48, 0x76,
-	0x82, 0x4a, 0xfc, 0xbd, 0x12, 0x37, 0x0b, 0x65, 0xae, 0x8d, 0x23, 0xe3, 0x40, 0x36, 0x32, 0xfa,
-	0xa3, 0x6d, 0x69, 0x48, 0xc7, 0x8b, 0xf7, 0x4e, 0x69, 0xcf, 0xf6, 0xba, 0xac, 0x1e, 0xe6, 0x65,
-	0xa3, 0xa4, 0x07, 0xa2, 0xe5, 0x50, 0x7e, 0xb4, 0x1c, 0x56, 0xa3, 0x85, 0xdf, 0x6a, 0x22, 0xb3,
-	0xbd, 0x49, 0x42, 0xaa, 0x1d, 0xe1, 0xac, 0xd3, 0x05, 0xfd, 0xb7, 0x08, 0xca, 0xdb, 0x5e, 0xeb,
-	0xaa, 0x1b, 0x05, 0x7b, 0xfc, 0xfe, 0xeb, 0xb9, 0x11, 0x75, 0xa5, 0x37, 0x49, 0x92, 0x6d, 0x51,
-	0x64, 0x77, 0xe8, 0x4e, 0x44, 0x3a, 0xbe, 0xa8, 0x9e, 0xf7, 0xb5, 0x45, 0xc9, 0xc7, 0xcc, 0x6c,
-	0x0e, 0x09, 0x23, 0x9e, 0x72, 0xca, 0x06, 0x7f, 0x66, 0x0a, 0x26, 0x2f, 0xec, 0x44, 0x81, 0xc8,
-	0x37, 0xca, 0x5a, 0xd6, 0x01, 0x4b, 0x31, 0x36, 0x41, 0xea, 0x1d, 0xb8, 0x37, 0xb9, 0xd6, 0xdd,
-	0xa2, 0x41, 0xc7, 0x76, 0x49, 0xfe, 0xb9, 0x3c, 0x41, 0x4b, 0x37, 0xa7, 0xab, 0xe0, 0x29, 0x21,
-	0xc9, 0x6e, 0x49, 0xb7, 0x6d, 0xd7, 0xf2, 0x5e, 0xcc, 0x09, 0xad, 0xe9, 0x04, 0xfe, 0x45, 0xed,
-	0xca, 0x66, 0x24, 0x26, 0x79, 0xe0, 0x69, 0x58, 0x62, 0x19, 0xa3, 0x47, 0xc5, 0x0f, 0x22, 0x29,
-	0xe9, 0xa3, 0xda, 0x60, 0x29, 0x0f, 0x43, 0xfd, 0x10, 0x6f, 0xc3, 0x21, 0x12, 0x86, 0x76, 0xcb,
-	0xa5, 0x96, 0xe4, 0x55, 0x98, 0x98, 0x57, 0xff, 0xa7, 0x71, 0x43, 0x85, 0xbf, 0x21, 0xf6, 0x5b,
-	0x92, 0xfa, 0x57, 0x10, 0x1c, 0x1b, 0xca, 0x24, 0x89, 0x2b, 0x94, 0x39, 0x47, 0xaa, 0x50, 0x0e,
-	0xcd, 0x36, 0xb5, 0xba, 0x8e, 0x2c, 0x15, 0x12, 0x9a, 0xfd, 0x66, 0x75, 0xe3, 0xdd, 0x17, 0xe7,
-	0x58, 0x42, 0xe3, 0x93, 0x00, 0x1d, 0xe2, 0x76, 0x89, 0xc3, 0x21, 0xcc, 0x71, 0x08, 0x99, 0x15,
-	0x7d, 0x19, 0xaa, 0xc3, 0x5c, 0x47, 0x74, 0xef, 0xde, 0x45, 0x70, 0x50, 0xa6, 0x5c, 0xb1, 0xbb,
-	0xab, 0x70, 0x28, 0x63, 0x86, 0x1b, 0xe9, 0x46, 0xf7, 0x2f, 0x8f, 0x49, 0xa7, 0xd2, 0x4b, 0x8a,
-	0xea, 0x60, 0xa5, 0xa7, 0x8c, 0x46, 0x26, 0x3e, 0x70, 0xd1, 0x8c, 0x6e, 0x06, 0x5f, 0x06, 0xed,
-	0x3a, 0x71, 0x49, 0x8b, 0x5a, 0x89, 0xda, 0x89, 0x8b, 0x7d, 0x21, 0xdb, 0x86, 0x9a, 0xba, 0xe9,
-	0x93, 0x14, 0xd1, 0x76, 0xb3, 0x29, 0x5b, 0x5a, 0xaf, 0x17, 0x54, 0x3f, 0xe7, 0x33, 0xab, 0x1d,
-	0xdb, 0xe2, 0x2f, 0xc5, 0xe6, 0xd7, 0x60, 0x41, 0xa8, 0x22, 0x13, 0x94, 0x20, 0xa7, 0x0b, 0x31,
-	0xec, 0xc3, 0x92, 0x63, 0xf7, 0x68, 0xa2, 0xb5, 0x36, 0x37, 0x73, 0x25, 0x55, 0x01, 0xcc, 0x91,
-	0x22, 0x12, 0xb4, 0x68, 0x74, 0x3d, 0xe9, 0x38, 0x95, 0x78, 0x8b, 0xa3, 0x7f, 0x59, 0xff, 0xa1,
-	0xda, 0x9b, 0x57, 0xcd, 0xf2, 0xbf, 0xdb, 0x1e, 0x5e, 0x6b, 0x78, 0x96, 0xdd, 0xb4, 0x69, 0x7c,
-	0x5f, 0x2f, 0x1b, 0x09, 0xad, 0x07, 0x50, 0xde, 0xb6, 0xdd, 0xdd, 0x2d, 0xb7, 0xe9, 0x31, 0x67,
-	0x8d, 0xec, 0xc8, 0x91, 0x3b, 0x14, 0x13, 0xf8, 0x30, 0x14, 0xbb, 0x81, 0x23, 0x82, 0x97, 0x3d,
-	0xe2, 0x15, 0xa8, 0x58, 0x34, 0x34, 0x03, 0xdb, 0x17, 0xa1, 0xcb, 0x67, 0x3c, 0x99, 0x25, 0x16,
-	0x42, 0xb6, 0xe9, 0xb9, 0x9b, 0x0e, 0x09, 0x43, 0x59, 0x59, 0x24, 0x0b, 0xfa, 0x63, 0xb0, 0xc4,
-	0x64, 0xa6, 0x1e, 0x7a, 0x4e, 0x35, 0xc1, 0x31, 0x45, 0x35, 0x09, 0x4f, 0x3a, 0x1b, 0x81, 0x7b,
-	0x58, 0x41, 0x77, 0xd9, 0xf7, 0x05, 0x93, 0x09, 0x6f, 0x17, 0xc5, 0x61, 0x85, 0xd1, 0xd0, 0x01,
-	0x46, 0xe3, 0xdd, 0xb3, 0x80, 0xfb, 0x36, 0xce, 0x36, 0x29, 0xfe, 0x26, 0x82, 0x39, 0x26, 0x1a,
-	0xdf, 0x37, 0x2a, 0xa3, 0x72, 0x5f, 0xaf, 0xce, 0xae, 0x3b, 0xc5, 0xa4, 0xe9, 0xcb, 0xaf, 0xfc,
-	0xf5, 0xef, 0xdf, 0x2a, 0x1c, 0xc7, 0x47, 0xf9, 0x40, 0xbb, 0x77, 0x31, 0x3b, 0x5c, 0x0e, 0xf1,
-	0xab, 0x08, 0xb0, 0x28, 0x70, 0x33, 0x23, 0x3f, 0x7c, 0x6e, 0x14, 0xc4, 0x21, 0xa3, 0xc1, 0xea,
-	0x7d, 0x99, 0x82, 0xa0, 0x66, 0x7a, 0x01, 0x65, 0xc7, 0x3f, 0x7f, 0x81, 0x03, 0x58, 0xe3, 0x00,
-	0x4e, 0x63, 0x7d, 0x18, 0x80, 0xfa, 0x4b, 0xcc, 0xa2, 0x2f, 0xd7, 0x69, 0x2c, 0xf7, 0x0d, 0x04,
-	0xa5, 0xdb, 0xfc, 0x62, 0x3f, 0xc6, 0x48, 0x3b, 0x33, 0x33, 0x12, 0x17, 0xc7, 0xd1, 0xea, 0xa7,
-	0x38, 0xd2, 0xfb, 0xf0, 0x09, 0x89, 0x34, 0x8c, 0x02, 0x4a, 0x3a, 0x0a, 0xe0, 0x0b, 0x08, 0xbf,
-	0x85, 0x60, 0x3e, 0x9e, 0xe8, 0xe0, 0x33, 0xa3, 0x50, 0x2a, 0x13, 0x9f, 0xea, 0xec, 0xc6, 0x23,
-	0xfa, 0x83, 0x1c, 0xe3, 0x29, 0x7d, 0xe8, 0x76, 0x6e, 0x28, 0xc3, 0x93, 0xd7, 0x11, 0x14, 0xaf,
-	0xd1, 0xb1, 0xfe, 0x36, 0x43, 0x70, 0x03, 0x06, 0x1c, 0xb2, 0xd5, 0xf8, 0x4d, 0x04, 0xf7, 0x5e,
-	0xa3, 0xd1, 0xf0, 0xca, 0x06, 0xaf, 0x8e, 0x2f, 0x37, 0x84, 0xdb, 0x9d, 0x9b, 0xe0, 0xcd, 0xe4,
-	0x48, 0xaf, 0x73, 0x64, 0x0f, 0xe2, 0xb3, 0x79, 0x4e, 0x18, 0xee, 0xb9, 0xe6, 0x8b, 0x02, 0xc7,
-	0x1f, 0x11, 0x1c, 0xee, 0x1f, 0xed, 0x63, 0xbd, 0xef, 0x7a, 0x39, 0x64, 0xf2, 0x5f, 0xbd, 0x31,
-	0x6d, 0x06, 0x56, 0x99, 0xea, 0x97, 0x39, 0xf2, 0x47, 0xf1, 0x23, 0x79, 0xc8, 0x93, 0xf6, 0x78,
-	0xfd, 0x25, 0xf9, 0xf8, 0x32, 0xff, 0x33, 0x14, 0x0e, 0xfb, 0x4f, 0x08, 0x8e, 0x4a, 0xbe, 0x9b,
-	0x6d, 0x12, 0x44, 0x57, 0x28, 0xbb, 0x1c, 0x85, 0x13, 0xe9, 0x33, 0xe5, 0x89, 0x92, 0x95, 0xa7,
-	0x5f, 0xe5, 0xba, 0x3c, 0x81, 0x1f, 0xdf, 0xb7, 0x2e, 0x26, 0x63, 0x63, 0x09, 0xd8, 0x6f, 0x23,
-	0x38, 0x78, 0x8d, 0x46, 0xcf, 0x6c, 0x6e, 0xed, 0x6b, 0x67, 0xa6, 0x74, 0xf4, 0x8c, 0x38, 0xfd,
-	0x0a, 0x57, 0xe4, 0x13, 0xf8, 0xb1, 0x7d, 0x2b, 0xe2, 0x99, 0x76, 0xb2, 0x2f, 0xaf, 0x20, 0x38,
-	0x70, 0x2d, 0x73, 0xe4, 0x8f, 0x4e, 0x27, 0xca, 0xf8, 0xba, 0xba, 0x5c, 0xcb, 0xfc, 0x15, 0x8f,
-	0xfc, 0x29, 0x71, 0xf5, 0x75, 0x8e, 0xed, 0x2c, 0x3e, 0x93, 0x87, 0x2d, 0x1d, 0x6f, 0xbd, 0x81,
-	0xe0, 0x58, 0x16, 0x44, 0x3a, 0xf6, 0xff, 0xe8, 0xfe, 0x86, 0xe9, 0x62, 0x24, 0x3f, 0x06, 0x5d,
-	0x83, 0xa3, 0x3b, 0xaf, 0x0f, 0x0f, 0xc4, 0xce, 0x00, 0x8a, 0x0d, 0xb4, 0xb6, 0x8a, 0xf0, 0xef,
-	0x10, 0xcc, 0xc7, 0x93, 0x9e, 0xd1, 0x36, 0x52, 0xc6, 0xd4, 0xb3, 0xcc, 0x6a, 0xc2, 0x6b, 0xab,
-	0x17, 0x86, 0x1b, 0x34, 0xfb, 0xbd, 0xdc, 0xda, 0x1a, 0xb7, 0xb2, 0x9a, 0x8e, 0x7f, 0x89, 0x00,
-	0xd2, 0x69, 0x15, 0x7e, 0x30, 0x5f, 0x8f, 0xcc, 0x44, 0xab, 0x3a, 0xdb, 0x79, 0x95, 0x5e, 0xe3,
-	0xfa, 0xac, 0x56, 0x57, 0x72, 0x73, 0xa1, 0x4f, 0xcd, 0x8d, 0x78, 0xb2, 0xf5, 0x03, 0x04, 0x25,
-	0x3e, 0x24, 0xc0, 0xa7, 0x47, 0x61, 0xce, 0xce, 0x10, 0x66, 0x69, 0xfa, 0x07, 0x38, 0xd4, 0x95,
-	0x46, 0xde, 0x81, 0xb2, 0x81, 0xd6, 0x70, 0x0f, 0xe6, 0xe3, 0xb6, 0xfc, 0x68, 0xf7, 0x50, 0xda,
-	0xf6, 0xd5, 0x95, 0x9c, 0x02, 0x27, 0x76, 0x54, 0x71, 0x96, 0xad, 0x8d, 0x3b, 0xcb, 0xe6, 0xd8,
-	0x71, 0x83, 0x4f, 0xe5, 0x1d, 0x46, 0xff, 0x05, 0xc3, 0x9c, 0xe3, 0xe8, 0xce, 0xe8, 0x2b, 0xe3,
-	0xce, 0x33, 0x66, 0x9d, 0x6f, 0x23, 0x38, 0xdc, 0x7f, 0xbf, 0xc3, 0x27, 0x86, 0xb6, 0x4a, 0xc5,
-	0xd9, 0xaa, 0x5a, 0x71, 0xd4, 0xdd, 0x50, 0xff, 0x24, 0x47, 0xb1, 0x81, 0x1f, 0x1e, 0x1b, 0x19,
-	0x37, 0x64, 0xd6, 0x61, 0x8c, 0xd6, 0xd3, 0xd1, 0xfb, 0x8f, 0x10, 0x1c, 0x54, 0x6f, 0x36, 0xa3,
-	0x6b, 0xcf, 0x21, 0x17, 0xc3, 0x6a, 0x6d, 0xb2, 0x97, 0x13, 0xc4, 0x1f, 0xe7, 0x88, 0x2f, 0xe2,
-	0xfa, 0x48, 0xc4, 0x31, 0xd2, 0xf8, 0x0f, 0x27, 0xd7, 0x43, 0xdb, 0xa2, 0xeb, 0x16, 0x43, 0xf5,
-	0x2b, 0x04, 0x07, 0xa4, 0x01, 0x6e, 0x05, 0x94, 0xe6, 0xdb, 0x6f, 0x76, 0x11, 0xcb, 0x64, 0xe9,
-	0x8f, 0x71, 0xd4, 0x1f, 0xc3, 0x97, 0x26, 0xb4, 0xb3, 0xb4, 0xef, 0x7a, 0xc4, 0x90, 0xfe, 0x1e,
-	0xc1, 0x91, 0xdb, 0x71, 0x80, 0x7e, 0x40, 0xf8, 0x37, 0x39, 0xfe, 0xc7, 0xf1, 0xa3, 0x39, 0x85,
-	0xf5, 0x38, 0x35, 0x2e, 0x20, 0xfc, 0x73, 0x04, 0x65, 0x39, 0x5b, 0xc6, 0x67, 0x47, 0x46, 0xb0,
-	0x3a, 0x7d, 0x9e, 0x65, 0xd4, 0x89, 0x2a, 0x52, 0x3f, 0x9d, 0x7b, 0xec, 0x0b, 0xf9, 0x2c, 0xf2,
-	0x5e, 0x47, 0x80, 0x93, 0xfe, 0x52, 0xd2, 0x71, 0xc2, 0x0f, 0x28, 0xa2, 0x46, 0x36, 0x31, 0xab,
-	0x67, 0xc7, 0xbe, 0xa7, 0x9e, 0xf9, 0x6b, 0xb9, 0x67, 0xbe, 0x97, 0xc8, 0x7f, 0x0d, 0x41, 0xe5,
-	0x1a, 0x4d, 0x2e, 0x7d, 0x39, 0xb6, 0x54, 0x47, 0xe3, 0xd5, 0xd5, 0xf1, 0x2f, 0x0a, 0x44, 0xe7,
-	0x39, 0xa2, 0x07, 0x70, 0xbe, 0xa9, 0x24, 0x80, 0xef, 0x20, 0x58, 0xba, 0x99, 0x75, 0x51, 0x7c,
-	0x7e, 0x9c, 0x24, 0xe5, 0xc8, 0x99, 0x1c, 0xd7, 0x43, 0x1c, 0xd7, 0xba, 0x3e, 0x11, 0xae, 0x0d,
-	0x31, 0x65, 0xfe, 0x1e, 0x8a, 0xbb, 0x06, 0x7d, 0x93, 0xa1, 0xff, 0xd4, 0x6e, 0x39, 0x03, 0x26,
-	0xfd, 0x12, 0xc7, 0x57, 0xc3, 0xe7, 0x27, 0xc1, 0x57, 0x17, 0xe3, 0x22, 0xfc, 0x5d, 0x04, 0x47,
-	0xf8, 0x68, 0x30, 0xcb, 0x18, 0xe7, 0x4d, 0xc3, 0xd2, 0x41, 0xe2, 0x04, 0x67, 0xe1, 0x13, 0x71,
-	0xfe, 0xd1, 0xf7, 0x05, 0x6a, 0x43, 0x0c, 0xfd, 0xbe, 0x56, 0x40, 0x6c, 0x7f, 0xef, 0x19, 0xc0,
-	0xf7, 0x5c, 0xa3, 0xcf, 0x80, 0xa3, 0x47, 0x9d, 0x13, 0x60, 0xdc, 0xe0, 0x18, 0x2f, 0xe9, 0xf5,
-	0xfd, 0x60, 0xac, 0xf7, 0x1a, 0x2c, 0x4c, 0xbf, 0x8e, 0xe0, 0xa0, 0xac, 0x0f, 0x84, 0xff, 0xad,
-	0x8f, 0xdb, 0xda, 0xfd, 0xd6, 0x13, 0x22, 0x20, 0xd6, 0x26, 0x0b, 0x88, 0xb7, 0x10, 0x2c, 0x88,
-	0xc9, 0x5d, 0x4e, 0xd5, 0x95, 0x19, 0xed, 0x55, 0xfb, 0xda, 0x5e, 0x62, 0xb4, 0xa3, 0x7f, 0x8e,
-	0x8b, 0x7d, 0x16, 0xe7, 0x9a, 0xc5, 0xf7, 0xac, 0xb0, 0xfe, 0x92, 0x98, 0xab, 0xbc, 0x5c, 0x77,
-	0xbc, 0x56, 0xf8, 0xbc, 0x8e, 0x73, 0x6b, 0x0b, 0xf6, 0xce, 0x05, 0x84, 0x23, 0x58, 0x64, 0xee,
-	0xcb, 0x7b, 0x69, 0x78, 0xa5, 0xaf, 0xf3, 0x36, 0xd0, 0x66, 0xab, 0x56, 0x07, 0x7a, 0x73, 0x69,
-	0x31, 0x21, 0x3a, 0x1b, 0xf8, 0xfe, 0x5c, 0xb1, 0x5c, 0xd0, 0xab, 0x08, 0x8e, 0x64, 0xe3, 0x31,
-	0x16, 0x3f, 0x71, 0x34, 0xe6, 0xa1, 0x10, 0xf7, 0x13, 0xbc, 0x36, 0x91, 0x1b, 0x71, 0x38, 0x4f,
-	0x3e, 0xf5, 0x87, 0x77, 0x4e, 0xa2, 0x3f, 0xbf, 0x73, 0x12, 0xfd, 0xed, 0x9d, 0x93, 0xe8, 0xf9,
-	0x87, 0x27, 0xfb, 0x2f, 0x0f, 0xd3, 0xb1, 0xa9, 0x1b, 0x65, 0xd9, 0xff, 0x3b, 0x00, 0x00, 0xff,
-	0xff, 0xf3, 0x23, 0xc0, 0x98, 0xcb, 0x32, 0x00, 0x00,
+	0x15, 0xa7, 0x66, 0x76, 0x76, 0x67, 0xdf, 0x78, 0xfd, 0x51, 0xb1, 0x4d, 0x67, 0xbc, 0x31, 0xeb,
+	0xb6, 0x1d, 0xaf, 0xd7, 0xde, 0x19, 0x7b, 0x62, 0x20, 0xd9, 0x24, 0x04, 0x67, 0xed, 0xd8, 0x86,
+	0xb5, 0x63, 0x7a, 0x9d, 0x18, 0x85, 0x03, 0x54, 0xba, 0x6b, 0x67, 0x9a, 0xed, 0xe9, 0x6e, 0x57,
+	0xf7, 0x4c, 0xb2, 0x0a, 0xb9, 0x04, 0x45, 0xe2, 0x10, 0x05, 0x01, 0x39, 0x70, 0x20, 0x7c, 0x24,
+	0x0a, 0x42, 0x08, 0xc4, 0x05, 0x21, 0x24, 0x84, 0x04, 0x87, 0x20, 0x38, 0x20, 0x21, 0xf8, 0x07,
+	0x50, 0x84, 0x38, 0x70, 0x20, 0x42, 0xca, 0x85, 0x0b, 0x42, 0x55, 0x5d, 0xfd, 0x51, 0xf3, 0xd1,
+	0x33, 0x9b, 0x99, 0x10, 0x4b, 0xdc, 0xfa, 0xd5, 0x54, 0xbf, 0xf7, 0xab, 0x57, 0xef, 0xbd, 0x7a,
+	0xf5, 0x5e, 0x0f, 0x9c, 0x08, 0x28, 0xeb, 0x52, 0x56, 0x27, 0xbe, 0xef, 0xd8, 0x26, 0x09, 0x6d,
+	0xcf, 0xcd, 0x3e, 0xd7, 0x7c, 0xe6, 0x85, 0x1e, 0xae, 0x64, 0x86, 0xaa, 0x8b, 0x4d, 0xcf, 0x6b,
+	0x3a, 0xb4, 0x4e, 0x7c, 0xbb, 0x4e, 0x5c, 0xd7, 0x0b, 0xc5, 0x70, 0x10, 0x4d, 0xad, 0xea, 0xdb,
+	0x0f, 0x06, 0x35, 0xdb, 0x13, 0xbf, 0x9a, 0x1e, 0xa3, 0xf5, 0xee, 0xf9, 0x7a, 0x93, 0xba, 0x94,
+	0x91, 0x90, 0x5a, 0x72, 0xce, 0x85, 0x74, 0x4e, 0x9b, 0x98, 0x2d, 0xdb, 0xa5, 0x6c, 0xa7, 0xee,
+	0x6f, 0x37, 0xf9, 0x40, 0x50, 0x6f, 0xd3, 0x90, 0x0c, 0x7a, 0x6b, 0xa3, 0x69, 0x87, 0xad, 0xce,
+	0xb3, 0x35, 0xd3, 0x6b, 0xd7, 0x09, 0x6b, 0x7a, 0x3e, 0xf3, 0xbe, 0x2c, 0x1e, 0x56, 0x4d, 0xab,
+	0xde, 0x7d, 0x20, 0x65, 0x90, 0x5d, 0x4b, 0xf7, 0x3c, 0x71, 0xfc, 0x16, 0xe9, 0xe7, 0x76, 0x79,
+	0x04, 0x37, 0x46, 0x7d, 0x4f, 0xea, 0x46, 0x3c, 0xda, 0xa1, 0xc7, 0x76, 0x32, 0x8f, 0x11, 0x1b,
+	0xfd, 0x3d, 0x04, 0xfb, 0x2f, 0xa6, 0xf2, 0x3e, 0xd7, 0xa1, 0x6c, 0x07, 0x63, 0x98, 0x71, 0x49,
+	0x9b, 0x6a, 0x68, 0x09, 0x2d, 0xcf, 0x1b, 0xe2, 0x19, 0x6b, 0x30, 0xc7, 0xe8, 0x16, 0xa3, 0x41,
+	0x4b, 0x2b, 0x88, 0xe1, 0x98, 0xc4, 0x55, 0x28, 0x73, 0xe1, 0xd4, 0x0c, 0x03, 0xad, 0xb8, 0x54,
+	0x5c, 0x9e, 0x37, 0x12, 0x1a, 0x2f, 0xc3, 0x3e, 0x46, 0x03, 0xaf, 0xc3, 0x4c, 0xfa, 0x34, 0x65,
+	0x81, 0xed, 0xb9, 0xda, 0x8c, 0x78, 0xbb, 0x77, 0x98, 0x73, 0x09, 0xa8, 0x43, 0xcd, 0xd0, 0x63,
+	0x5a, 0x49, 0x4c, 0x49, 0x68, 0x8e, 0x87, 0x03, 0xd7, 0x66, 0x23, 0x3c, 0xfc, 0x19, 0xeb, 0xb0,
+	0x87, 0xf8, 0xfe, 0x0d, 0xd2, 0xa6, 0x81, 0x4f, 0x4c, 0xaa, 0xcd, 0x89, 0xdf, 0x94, 0x31, 0x8e,
+	0x59, 0x22, 0xd1, 0xca, 0x02, 0x58, 0x4c, 0xea, 0xeb, 0x30, 0x7f, 0xc3, 0xb3, 0xe8, 0xf0, 0xe5,
+	0xf6, 0xb2, 0x2f, 0xf4, 0xb3, 0xd7, 0xdf, 0x46, 0x70, 0xc8, 0xa0, 0x5d, 0x9b, 0xe3, 0xbf, 0x4e,
+	0x43, 0x62, 0x91, 0x90, 0xf4, 0x72, 0x2c, 0x24, 0x1c, 0xab, 0x50, 0x66, 0x72, 0xb2, 0x56, 0x10,
+	0xe3, 0x09, 0xdd, 0x27, 0xad, 0x98, 0xbf, 0x98, 0x48, 0x85, 0x31, 0x89, 0x97, 0xa0, 0x12, 0xe9,
+	0xf2, 0x9a, 0x6b, 0xd1, 0xe7, 0x85, 0xf6, 0x4a, 0x46, 0x76, 0x08, 0x2f, 0xc2, 0x7c, 0x37, 0xd2,
+	0xf3, 0x35, 0x4b, 0x68, 0xb1, 0x64, 0xa4, 0x03, 0xfa, 0xdf, 0x11, 0x1c, 0xcd, 0xd8, 0x80, 0x21,
+	0x77, 0xe6, 0x72, 0x97, 0xba, 0x61, 0x30, 0x7c, 0x41, 0x67, 0xe1, 0x40, 0xbc, 0x89, 0xbd, 0x7a,
+	0xea, 0xff, 0x81, 0x2f, 0x31, 0x3b, 0x18, 0x2f, 0x31, 0x3b, 0xc6, 0x17, 0x12, 0xd3, 0x4f, 0x5d,
+	0xbb, 0x24, 0x97, 0x99, 0x1d, 0xea, 0x53, 0x54, 0x29, 0x5f, 0x51, 0xb3, 0x8a, 0xa2, 0xf4, 0x7f,
+	0x20, 0xd0, 0x32, 0x0b, 0xbd, 0x4e, 0x5c, 0x7b, 0x8b, 0x06, 0xe1, 0xb8, 0x7b, 0x86, 0xa6, 0xb8,
+	0x67, 0xcb, 0xb0, 0x2f, 0x5a, 0xd5, 0x4d, 0xee, 0x8f, 0x3c, 0xfe, 0x68, 0xa5, 0xa5, 0xe2, 0x72,
+	0xd1, 0xe8, 0x1d, 0xe6, 0x7b, 0x17, 0xcb, 0x0c, 0xb4, 0x59, 0x61, 0xc6, 0xe9, 0x00, 0x97, 0xe0,
+	0x7a, 0xeb, 0xc4, 0x6c, 0x45, 0x1e, 0x50, 0x36, 0x62, 0x52, 0x3f, 0x06, 0xf3, 0x4f, 0xd8, 0x0e,
+	0x5d, 0x6f, 0x75, 0xdc, 0x6d, 0x7c, 0x10, 0x4a, 0x26, 0x7f, 0x10, 0xab, 0xdb, 0x63, 0x44, 0x84,
+	0xfe, 0x0d, 0x04, 0xc7, 0x86, 0xe9, 0xe3, 0xb6, 0x1d, 0xb6, 0xf8, 0xfb, 0xc1, 0x30, 0xc5, 0x98,
+	0x2d, 0x6a, 0x6e, 0x07, 0x9d, 0x76, 0x6c, 0xcc, 0x31, 0x3d, 0x99, 0x62, 0xf4, 0x1f, 0x23, 0x58,
+	0x1e, 0x89, 0xe9, 0x36, 0x23, 0xbe, 0x4f, 0x19, 0x7e, 0x02, 0x4a, 0x77, 0xf8, 0x0f, 0xc2, 0x75,
+	0x2b, 0x8d, 0x5a, 0x2d, 0x1b, 0xfa, 0x47, 0x72, 0xb9, 0xfa, 0x11, 0x23, 0x7a, 0x1d, 0xd7, 0x62,
+	0xf5, 0x14, 0x04, 0x9f, 0xc3, 0x0a, 0x9f, 0x44, 0x8b, 0x7c, 0xbe, 0x98, 0xf6, 0xf8, 0x2c, 0xcc,
+	0xf8, 0x84, 0x85, 0xfa, 0x21, 0xb8, 0x47, 0x75, 0x1c, 0xdf, 0x73, 0x03, 0xaa, 0xff, 0x4a, 0xb5,
+	0xb3, 0x75, 0x46, 0x49, 0x48, 0x0d, 0x7a, 0xa7, 0x43, 0x83, 0x10, 0x6f, 0x43, 0xf6, 0x34, 0x12,
+	0x5a, 0xad, 0x34, 0xae, 0xd5, 0xd2, 0x70, 0x5e, 0x8b, 0xc3, 0xb9, 0x78, 0xf8, 0xa2, 0x69, 0xd5,
+	0xba, 0x0f, 0xd4, 0xfc, 0xed, 0x66, 0x8d, 0x1f, 0x0e, 0x0a, 0xb2, 0xf8, 0x70, 0xc8, 0x2e, 0xd5,
+	0xc8, 0x72, 0xc7, 0x87, 0x61, 0xb6, 0xe3, 0x07, 0x94, 0x85, 0x62, 0x65, 0x65, 0x43, 0x52, 0x7c,
+	0xff, 0xba, 0xc4, 0xb1, 0x2d, 0x12, 0x46, 0xfb, 0x53, 0x36, 0x12, 0x5a, 0xff, 0xb5, 0x8a, 0xfe,
+	0x29, 0xdf, 0xfa, 0xb0, 0xd0, 0x67, 0x51, 0x16, 0x54, 0x94, 0x59, 0x0b, 0x2a, 0xaa, 0x16, 0xf4,
+	0x73, 0x15, 0xff, 0x25, 0xea, 0xd0, 0x14, 0xff, 0x20, 0x63, 0xd6, 0x60, 0xce, 0x24, 0x81, 0x49,
+	0xac, 0x58, 0x4a, 0x4c, 0xf2, 0x10, 0xe7, 0x33, 0xcf, 0x27, 0x4d, 0xc1, 0xe9, 0xa6, 0xe7, 0xd8,
+	0xe6, 0x8e, 0x14, 0xd7, 0xff, 0x43, 0x9f, 0xe1, 0xcf, 0xe4, 0x1b, 0x7e, 0x49, 0x85, 0x7d, 0x1c,
+	0x2a, 0x9b, 0x3b, 0xae, 0xf9, 0xa4, 0x1f, 0xb9, 0xfd, 0x41, 0x28, 0xd9, 0x21, 0x6d, 0x07, 0x1a,
+	0x12, 0x2e, 0x1f, 0x11, 0xfa, 0x7f, 0x4a, 0x70, 0x38, 0xb3, 0x36, 0xfe, 0x42, 0xde, 0xca, 0xf2,
+	0xe2, 0xd7, 0x61, 0x98, 0xb5, 0xd8, 0x8e, 0xd1, 0x71, 0xa5, 0x01, 0x48, 0x8a, 0x0b, 0xf6, 0x59,
+	0xc7, 0x8d, 0xe0, 0x97, 0x8d, 0x88, 0xc0, 0x5b, 0x50, 0x0e, 0x42, 0x9e, 0x7f, 0x34, 0x77, 0x04,
+	0xf0, 0x4a, 0xe3, 0x33, 0x93, 0x6d, 0x3a, 0x87, 0xbe, 0x29, 0x39, 0x1a, 0x09, 0x6f, 0x7c, 0x87,
+	0x47, 0xbb, 0x28, 0x04, 0x06, 0xda, 0xdc, 0x52, 0x71, 0xb9, 0xd2, 0xd8, 0x9c, 0x5c, 0xd0, 0x93,
+	0x3e, 0x65, 0x91, 0x7d, 0x49, 0xde, 0x46, 0x2a, 0x85, 0x07, 0xd8, 0xb6, 0x8c, 0x0f, 0x81, 0xcc,
+	0x13, 0xd2, 0x01, 0xfc, 0x79, 0x28, 0xd9, 0xee, 0x96, 0x17, 0x68, 0xf3, 0x02, 0xcc, 0xe3, 0x93,
+	0x81, 0xb9, 0xe6, 0x6e, 0x79, 0x46, 0xc4, 0x10, 0xdf, 0x81, 0x05, 0x46, 0x43, 0xb6, 0x13, 0x6b,
+	0x41, 0x03, 0xa1, 0xd7, 0xcf, 0x4e, 0x26, 0xc1, 0xc8, 0xb2, 0x34, 0x54, 0x09, 0x78, 0x0d, 0x2a,
+	0x41, 0x6a, 0x63, 0x5a, 0x45, 0x08, 0xd4, 0x14, 0x46, 0x19, 0x1b, 0x34, 0xb2, 0x93, 0xfb, 0xac,
+	0x7b, 0x4f, 0xbe, 0x75, 0x2f, 0x8c, 0x3c, 0xef, 0xf6, 0x8e, 0x71, 0xde, 0xed, 0xeb, 0x39, 0xef,
+	0xf4, 0x77, 0x11, 0x2c, 0xf6, 0x05, 0xa7, 0x4d, 0x9f, 0xe6, 0xba, 0x01, 0x81, 0x99, 0xc0, 0xa7,
+	0xa6, 0x38, 0xa9, 0x2a, 0x8d, 0xeb, 0x53, 0x8b, 0x56, 0x42, 0xae, 0x60, 0x9d, 0x17, 0x50, 0x27,
+	0x8c, 0x0b, 0xdf, 0x43, 0xf0, 0xd1, 0x8c, 0xcc, 0x9b, 0x24, 0x34, 0x5b, 0x79, 0x8b, 0xe5, 0xfe,
+	0xcb, 0xe7, 0xc8, 0x73, 0x39, 0x22, 0xb8, 0x56, 0xc5, 0xc3, 0xad, 0x1d, 0x9f, 0x03, 0xe4, 0xbf,
+	0xa4, 0x03, 0x13, 0xa6, 0x55, 0x3f, 0x41, 0x50, 0xcd, 0xc6, 0x70, 0xcf, 0x71, 0x9e, 0x25, 0xe6,
+	0x76, 0x1e, 0xc8, 0xbd, 0x50, 0xb0, 0x2d, 0x81, 0xb0, 0x68, 0x14, 0x6c, 0x6b, 0x97, 0xc1, 0xa8,
+	0x17, 0xee, 0x6c, 0x3e, 0xdc, 0x39, 0x15, 0xee, 0x7b, 0x3d, 0x70, 0xe3, 0x90, 0x90, 0x03, 0x77,
+	0x11, 0xe6, 0xdd, 0x9e, 0x14, 0x37, 0x1d, 0x18, 0x90, 0xda, 0x16, 0xfa, 0x52, 0x5b, 0x0d, 0xe6,
+	0xba, 0xc9, 0x05, 0x88, 0xff, 0x1c, 0x93, 0x7c, 0x89, 0x4d, 0xe6, 0x75, 0x7c, 0xa9, 0xf4, 0x88,
+	0xe0, 0x28, 0xb6, 0x6d, 0x97, 0x27, 0xeb, 0x02, 0x05, 0x7f, 0xde, 0xfd, 0x95, 0x47, 0x59, 0xf6,
+	0x4f, 0x0b, 0xf0, 0xb1, 0x01, 0xcb, 0x1e, 0x69, 0x4f, 0x77, 0xc7, 0xda, 0x13, 0xab, 0x9e, 0x1b,
+	0x6a, 0xd5, 0xe5, 0x51, 0x56, 0x3d, 0x9f, 0xaf, 0x2f, 0x50, 0xf5, 0xf5, 0xa3, 0x02, 0x2c, 0x0d,
+	0xd0, 0xd7, 0xe8, 0x74, 0xe2, 0xae, 0x51, 0xd8, 0x96, 0xc7, 0xcc, 0xf8, 0x5a, 0x10, 0x11, 0xdc,
+	0xcf, 0x3c, 0xe6, 0xb7, 0x88, 0x2b, 0xac, 0xa3, 0x6c, 0x48, 0x6a, 0x42, 0x55, 0x5d, 0x02, 0x2d,
+	0x56, 0xcf, 0x45, 0x33, 0x0a, 0x52, 0x8c, 0xb4, 0x69, 0x48, 0x59, 0x30, 0x2c, 0x44, 0x75, 0x89,
+	0xd3, 0xa1, 0x71, 0x88, 0x12, 0x84, 0xfe, 0x6a, 0xa1, 0x97, 0x8d, 0xd1, 0x71, 0xef, 0x7e, 0x45,
+	0x1f, 0x86, 0x59, 0x22, 0xd0, 0x4a, 0xd3, 0x94, 0x54, 0x9f, 0x4a, 0xcb, 0xf9, 0x2a, 0x9d, 0x57,
+	0x54, 0xba, 0x56, 0xd0, 0x90, 0xfe, 0x6e, 0x01, 0xaa, 0xc3, 0x14, 0xf2, 0x74, 0xe3, 0xff, 0x4d,
+	0x25, 0x98, 0x80, 0xc6, 0x86, 0x58, 0x99, 0x06, 0x22, 0x39, 0x3b, 0xa9, 0x9c, 0xd8, 0xc3, 0x4c,
+	0xd2, 0x18, 0xca, 0x46, 0x7f, 0x19, 0xc1, 0x11, 0xf5, 0xb5, 0x60, 0xc3, 0x0e, 0xc2, 0xf8, 0x62,
+	0x87, 0xb7, 0x60, 0x2e, 0x5a, 0x4a, 0x94, 0x96, 0x57, 0x1a, 0x1b, 0x93, 0x26, 0x6b, 0xca, 0xee,
+	0xc6, 0xcc, 0xf5, 0x87, 0xe0, 0xc8, 0xc0, 0x13, 0x4a, 0xc2, 0xa8, 0x42, 0x39, 0x4e, 0x50, 0xe5,
+	0xee, 0x27, 0xb4, 0xfe, 0xe6, 0x8c, 0x9a, 0x2e, 0x78, 0xd6, 0x86, 0xd7, 0xcc, 0xa9, 0xe2, 0xe4,
+	0x5b, 0x0c, 0xdf, 0x0d, 0xcf, 0xca, 0x14, 0x6c, 0x62, 0x92, 0xbf, 0x67, 0x7a, 0x6e, 0x48, 0x6c,
+	0x97, 0x32, 0x99, 0xd1, 0xa4, 0x03, 0x7c, 0xa7, 0x03, 0xdb, 0x35, 0xe9, 0x26, 0x35, 0x3d, 0xd7,
+	0x0a, 0x84, 0xc9, 0x14, 0x0d, 0x65, 0x0c, 0x5f, 0x85, 0x79, 0x41, 0xdf, 0xb2, 0xdb, 0xd1, 0x11,
+	0x5e, 0x69, 0xac, 0xd4, 0xa2, 0xca, 0x6a, 0x2d, 0x5b, 0x59, 0x4d, 0x75, 0xc8, 0x2b, 0xab, 0xb5,
+	0xee, 0xf9, 0x1a, 0x7f, 0xc3, 0x48, 0x5f, 0xe6, 0x58, 0x42, 0x62, 0x3b, 0x1b, 0xb6, 0x2b, 0x2e,
+	0x0d, 0x5c, 0x54, 0x3a, 0xc0, 0xad, 0x71, 0xcb, 0x73, 0x1c, 0xef, 0xb9, 0x38, 0xe6, 0x45, 0x14,
+	0x7f, 0xab, 0xe3, 0x86, 0xb6, 0x23, 0xe4, 0x47, 0xb6, 0x96, 0x0e, 0x88, 0xb7, 0x6c, 0x27, 0xa4,
+	0x4c, 0x06, 0x3b, 0x49, 0x25, 0xf6, 0x5e, 0x11, 0xa3, 0x49, 0xac, 0x8d, 0x3c, 0x63, 0x4f, 0xd6,
+	0x33, 0x7a, 0xbd, 0x6d, 0x61, 0x40, 0xc5, 0x4b, 0xd4, 0x4e, 0x69, 0xd7, 0xf6, 0x3a, 0x3c, 0x1f,
+	0x16, 0x69, 0x63, 0x4c, 0xf7, 0x79, 0xcb, 0xbe, 0x7c, 0x6f, 0xd9, 0xaf, 0x7a, 0x8b, 0xb8, 0xd5,
+	0x84, 0x66, 0x6b, 0x9d, 0x04, 0x54, 0x3b, 0x20, 0x58, 0xa7, 0x03, 0xfa, 0x6f, 0x10, 0x94, 0x37,
+	0xbc, 0xe6, 0x65, 0x37, 0x64, 0x3b, 0x9c, 0x09, 0xdf, 0x39, 0xea, 0xc6, 0xd6, 0x14, 0x93, 0x7c,
+	0x8b, 0x42, 0xbb, 0x4d, 0x37, 0x43, 0xd2, 0xf6, 0x65, 0xf6, 0xbc, 0xab, 0x2d, 0x4a, 0x5e, 0xe6,
+	0x6a, 0x73, 0x48, 0x10, 0x8a, 0x90, 0x53, 0x36, 0xc4, 0x33, 0x5f, 0x60, 0x32, 0x61, 0x33, 0x64,
+	0x32, 0xde, 0x28, 0x63, 0x59, 0x03, 0x2c, 0x45, 0xd8, 0x24, 0xa9, 0xb7, 0xe1, 0xde, 0xe4, 0x5a,
+	0x77, 0x8b, 0xb2, 0xb6, 0xed, 0x92, 0xfc, 0x73, 0x79, 0x8c, 0x92, 0x6e, 0x4e, 0x55, 0xc1, 0x53,
+	0x5c, 0x92, 0xdf, 0x92, 0x6e, 0xdb, 0xae, 0xe5, 0x3d, 0x97, 0xe3, 0x5a, 0x93, 0x09, 0xfc, 0xb3,
+	0x5a, 0x95, 0xcd, 0x48, 0x4c, 0xe2, 0xc0, 0x55, 0x58, 0xe0, 0x11, 0xa3, 0x4b, 0xe5, 0x0f, 0x32,
+	0x28, 0xe9, 0xc3, 0xca, 0x60, 0x29, 0x0f, 0x43, 0x7d, 0x11, 0x6f, 0xc0, 0x3e, 0x12, 0x04, 0x76,
+	0xd3, 0xa5, 0x56, 0xcc, 0xab, 0x30, 0x36, 0xaf, 0xde, 0x57, 0xa3, 0x82, 0x8a, 0x98, 0x21, 0xf7,
+	0x3b, 0x26, 0xf5, 0xaf, 0x22, 0x38, 0x34, 0x90, 0x49, 0xe2, 0x57, 0x28, 0x73, 0x8e, 0xf0, 0x9e,
+	0x80, 0xd9, 0xa2, 0x56, 0xc7, 0x89, 0x53, 0x85, 0x84, 0xe6, 0xbf, 0x59, 0x9d, 0x68, 0xf7, 0xe5,
+	0x39, 0x96, 0xd0, 0xf8, 0x28, 0x40, 0x9b, 0xb8, 0x1d, 0xe2, 0x08, 0x08, 0x33, 0x02, 0x42, 0x66,
+	0x44, 0x5f, 0x84, 0xea, 0x20, 0xd3, 0x91, 0xd5, 0xbb, 0x7f, 0x22, 0xd8, 0x1b, 0x87, 0x5c, 0xb9,
+	0xbb, 0xcb, 0xb0, 0x2f, 0xa3, 0x86, 0x1b, 0xe9, 0x46, 0xf7, 0x0e, 0x8f, 0x08, 0xa7, 0xb1, 0x95,
+	0x14, 0xd5, 0xc6, 0x4a, 0x57, 0x69, 0x8d, 0x8c, 0x7d, 0xe0, 0xa2, 0x29, 0xdd, 0x0c, 0xbe, 0x02,
+	0xda, 0x75, 0xe2, 0x92, 0x26, 0xb5, 0x92, 0x65, 0x27, 0x26, 0xf6, 0xa5, 0x6c, 0x19, 0x6a, 0xe2,
+	0xa2, 0x4f, 0x92, 0x44, 0xdb, 0x5b, 0x5b, 0x71, 0x49, 0xeb, 0xb5, 0x82, 0x6a, 0xe7, 0xa2, 0x67,
+	0xb5, 0x69, 0x5b, 0x62, 0x52, 0xa4, 0x7e, 0x0d, 0xe6, 0xe4, 0x52, 0xe2, 0x00, 0x25, 0xc9, 0xc9,
+	0x5c, 0x0c, 0xfb, 0xb0, 0xe0, 0xd8, 0x5d, 0x9a, 0xac, 0x5a, 0x9b, 0x99, 0xfa, 0x22, 0x55, 0x01,
+	0xdc, 0x90, 0x42, 0xc2, 0x9a, 0x34, 0xbc, 0x9e, 0x54, 0x9c, 0x4a, 0xa2, 0xc4, 0xd1, 0x3b, 0xac,
+	0xff, 0x40, 0xad, 0xcd, 0xab, 0x6a, 0xf9, 0xdf, 0x6d, 0x8f, 0xc8, 0x35, 0x3c, 0xcb, 0xde, 0xb2,
+	0x69, 0x74, 0x5f, 0x2f, 0x1b, 0x09, 0xad, 0x33, 0x28, 0x6f, 0xd8, 0xee, 0x36, 0x2f, 0x6a, 0x71,
+	0x63, 0x0d, 0xed, 0xd0, 0x89, 0x77, 0x28, 0x22, 0xf0, 0x7e, 0x28, 0x76, 0x98, 0x23, 0x9d, 0x97,
+	0x3f, 0xf2, 0x1e, 0x8f, 0x45, 0x03, 0x93, 0xd9, 0xbe, 0x74, 0x5d, 0xd1, 0xe3, 0xc9, 0x0c, 0x71,
+	0x17, 0xb2, 0x4d, 0xcf, 0x5d, 0x77, 0x48, 0x10, 0xc4, 0x99, 0x45, 0x32, 0xa0, 0x3f, 0x02, 0x0b,
+	0x5c, 0x66, 0x6a, 0xa1, 0x67, 0x54, 0x15, 0x1c, 0x52, 0x96, 0x16, 0xc3, 0x8b, 0x8d, 0x8d, 0xc0,
+	0x3d, 0x3c, 0xa1, 0xbb, 0xe8, 0xfb, 0x92, 0xc9, 0x98, 0xb7, 0x8b, 0xe2, 0xa0, 0xc4, 0x68, 0x70,
+	0x03, 0x63, 0x5b, 0xc9, 0xbf, 0xae, 0xda, 0x01, 0xef, 0xb6, 0x7e, 0x50, 0x87, 0xc4, 0xeb, 0x6a,
+	0x2d, 0x43, 0x4a, 0x4b, 0x74, 0x63, 0xaa, 0xba, 0xb9, 0x3e, 0xa9, 0x79, 0x44, 0x55, 0xb8, 0x58,
+	0x4a, 0x6a, 0x21, 0x84, 0x99, 0x2d, 0xbb, 0x2b, 0x2c, 0x84, 0xd7, 0xf4, 0x12, 0x5a, 0x7f, 0x59,
+	0x8d, 0xf7, 0x57, 0x18, 0xf1, 0x5b, 0x1f, 0x90, 0x2e, 0xa2, 0xac, 0xf8, 0xf9, 0x4b, 0xd4, 0x0f,
+	0x5b, 0x62, 0x4f, 0x8a, 0x46, 0x42, 0xeb, 0xff, 0x46, 0x70, 0xb0, 0x17, 0x07, 0x6f, 0x00, 0xbf,
+	0xcf, 0x94, 0x78, 0x30, 0x00, 0x0c, 0x33, 0x41, 0x74, 0xe0, 0x88, 0xc8, 0xcc, 0x9f, 0x79, 0x1a,
+	0xd9, 0xa2, 0xc4, 0x09, 0x5b, 0x32, 0x88, 0x4b, 0x8a, 0xbb, 0x0b, 0x65, 0xcc, 0x63, 0x32, 0x8c,
+	0x47, 0x04, 0x1f, 0x35, 0x77, 0x4c, 0x27, 0xb9, 0xb4, 0x0b, 0x02, 0x3f, 0xca, 0x9b, 0x6d, 0xb6,
+	0x63, 0x31, 0xea, 0x8a, 0xfa, 0x74, 0xa5, 0x71, 0x6c, 0xd8, 0xa9, 0x9c, 0x2c, 0xcc, 0x48, 0x5e,
+	0x69, 0xfc, 0xeb, 0x34, 0xe0, 0x9e, 0x48, 0x62, 0x9b, 0x14, 0x7f, 0x13, 0xc1, 0x0c, 0xf7, 0x05,
+	0x7c, 0xdf, 0x30, 0x66, 0x62, 0xa3, 0xaa, 0xd3, 0x2b, 0x97, 0x72, 0x69, 0xfa, 0xe2, 0x4b, 0x7f,
+	0xf9, 0xdb, 0xb7, 0x0a, 0x87, 0xf1, 0x41, 0xf1, 0x85, 0x45, 0xf7, 0x7c, 0xf6, 0x6b, 0x87, 0x00,
+	0xbf, 0x82, 0x00, 0xcb, 0x1b, 0x57, 0xa6, 0x07, 0x8d, 0xcf, 0x0c, 0x83, 0x38, 0xa0, 0x57, 0x5d,
+	0xbd, 0x2f, 0x93, 0xa1, 0xd6, 0x4c, 0x8f, 0x51, 0x9e, 0x8f, 0x8a, 0x09, 0x02, 0xc0, 0x8a, 0x00,
+	0x70, 0x02, 0xeb, 0x83, 0x00, 0xd4, 0x5f, 0xe0, 0x1b, 0xfd, 0x62, 0x9d, 0x46, 0x72, 0xdf, 0x40,
+	0x50, 0xba, 0x2d, 0x2a, 0x4d, 0x23, 0x94, 0xb4, 0x39, 0x35, 0x25, 0x09, 0x71, 0x02, 0xad, 0x7e,
+	0x5c, 0x20, 0xbd, 0x0f, 0x1f, 0x89, 0x91, 0x06, 0x21, 0xa3, 0xa4, 0xad, 0x00, 0x3e, 0x87, 0xf0,
+	0x5b, 0x08, 0x66, 0xa3, 0x16, 0x23, 0x3e, 0x39, 0x0c, 0xa5, 0xd2, 0x82, 0xac, 0x4e, 0xaf, 0x5f,
+	0xa7, 0x9f, 0x16, 0x18, 0x8f, 0xeb, 0x03, 0xb7, 0x73, 0x4d, 0xe9, 0xe6, 0xbd, 0x86, 0xa0, 0x78,
+	0x85, 0x8e, 0xb4, 0xb7, 0x29, 0x82, 0xeb, 0x53, 0xe0, 0x80, 0xad, 0xc6, 0x6f, 0x22, 0xb8, 0xf7,
+	0x0a, 0x0d, 0x07, 0xa7, 0xda, 0x78, 0x79, 0x74, 0xfe, 0x2b, 0xcd, 0xee, 0xcc, 0x18, 0x33, 0x93,
+	0x1c, 0xb3, 0x2e, 0x90, 0x9d, 0xc6, 0xa7, 0xf2, 0x8c, 0x90, 0x07, 0x90, 0xe7, 0x24, 0x8e, 0x3f,
+	0x20, 0xd8, 0xdf, 0xfb, 0xad, 0x09, 0xd6, 0x7b, 0xea, 0x1d, 0x03, 0x3e, 0x45, 0xa9, 0xde, 0x98,
+	0x4e, 0xcc, 0x8f, 0x99, 0xea, 0x17, 0x05, 0xf2, 0x87, 0xf1, 0x43, 0x79, 0xc8, 0x93, 0x7e, 0x4d,
+	0xfd, 0x85, 0xf8, 0xf1, 0xc5, 0x7a, 0x5b, 0xb2, 0xc0, 0x7f, 0x44, 0x70, 0x30, 0xe6, 0xbb, 0xde,
+	0x22, 0x2c, 0xbc, 0x44, 0xf9, 0x6d, 0x3d, 0x18, 0x6b, 0x3d, 0x13, 0xa6, 0x38, 0x59, 0x79, 0xfa,
+	0x65, 0xb1, 0x96, 0xc7, 0xf0, 0xa3, 0xbb, 0x5e, 0x8b, 0xc9, 0xd9, 0x58, 0x12, 0xf6, 0xdb, 0x08,
+	0xf6, 0x5e, 0xa1, 0xe1, 0x93, 0xeb, 0xd7, 0x76, 0xb5, 0x33, 0x13, 0x1a, 0x7a, 0x46, 0x9c, 0x7e,
+	0x49, 0x2c, 0xe4, 0x53, 0xf8, 0x91, 0x5d, 0x2f, 0xc4, 0x33, 0xed, 0x64, 0x5f, 0x5e, 0x42, 0xb0,
+	0xe7, 0x4a, 0x26, 0x07, 0x1d, 0x1e, 0x4e, 0x94, 0xef, 0x29, 0xaa, 0x8b, 0xb5, 0xcc, 0x67, 0x65,
+	0xf1, 0x4f, 0x89, 0xa9, 0xaf, 0x0a, 0x6c, 0xa7, 0xf0, 0xc9, 0x3c, 0x6c, 0x69, 0xbf, 0xf5, 0x0d,
+	0x04, 0x87, 0xb2, 0x20, 0xd2, 0xef, 0x50, 0x3e, 0xbe, 0xbb, 0xaf, 0x3b, 0xe4, 0x37, 0x22, 0x23,
+	0xd0, 0x35, 0x04, 0xba, 0xb3, 0xfa, 0x60, 0x47, 0x6c, 0xf7, 0xa1, 0x58, 0x43, 0x2b, 0xcb, 0x08,
+	0xff, 0x16, 0xc1, 0x6c, 0xd4, 0x7a, 0x1c, 0xae, 0x23, 0xe5, 0xbb, 0x89, 0x69, 0x46, 0x35, 0x69,
+	0xb5, 0xd5, 0x73, 0x83, 0x15, 0x9a, 0x7d, 0x3f, 0xde, 0xda, 0x9a, 0xd0, 0xb2, 0x1a, 0x8e, 0x7f,
+	0x81, 0x00, 0xd2, 0xf6, 0x29, 0x3e, 0x9d, 0xbf, 0x8e, 0x4c, 0x8b, 0xb5, 0x3a, 0xdd, 0x06, 0xaa,
+	0x5e, 0x13, 0xeb, 0x59, 0xae, 0x2e, 0xe5, 0xc6, 0x42, 0x9f, 0x9a, 0x6b, 0x51, 0xab, 0xf5, 0xfb,
+	0x08, 0x4a, 0xa2, 0x6b, 0x85, 0x4f, 0x0c, 0xc3, 0x9c, 0x6d, 0x6a, 0x4d, 0x53, 0xf5, 0xf7, 0x0b,
+	0xa8, 0x4b, 0x8d, 0xbc, 0x03, 0x65, 0x0d, 0xad, 0xe0, 0x2e, 0xcc, 0x46, 0x7d, 0xa2, 0xe1, 0xe6,
+	0xa1, 0xf4, 0x91, 0xaa, 0x4b, 0x39, 0x09, 0x4e, 0x64, 0xa8, 0xf2, 0x2c, 0x5b, 0x19, 0x75, 0x96,
+	0xcd, 0xf0, 0xe3, 0x06, 0x1f, 0xcf, 0x3b, 0x8c, 0x3e, 0x00, 0xc5, 0x9c, 0x11, 0xe8, 0x4e, 0xea,
+	0x4b, 0xa3, 0xce, 0x33, 0xae, 0x9d, 0x6f, 0x23, 0xd8, 0xdf, 0x5b, 0x70, 0xc0, 0x47, 0x06, 0xd6,
+	0xee, 0xe5, 0xd9, 0xaa, 0x6a, 0x71, 0x58, 0xb1, 0x42, 0xff, 0xb4, 0x40, 0xb1, 0x86, 0x1f, 0x1c,
+	0xe9, 0x19, 0x37, 0xe2, 0xa8, 0xc3, 0x19, 0xad, 0xa6, 0xdf, 0x82, 0xfc, 0x10, 0xc1, 0x5e, 0xf5,
+	0xaa, 0x3d, 0x3c, 0xf7, 0x1c, 0x50, 0xa9, 0xa8, 0xd6, 0xc6, 0x9b, 0x9c, 0x20, 0xfe, 0xa4, 0x40,
+	0x7c, 0x1e, 0xd7, 0x87, 0x22, 0x8e, 0x90, 0x46, 0x5f, 0xf2, 0xae, 0x06, 0xb6, 0x45, 0x57, 0x2d,
+	0x8e, 0xea, 0x97, 0x08, 0xf6, 0xc4, 0x0a, 0xb8, 0xc5, 0x28, 0xcd, 0xd7, 0xdf, 0xf4, 0x3c, 0x96,
+	0xcb, 0xd2, 0x1f, 0x11, 0xa8, 0x3f, 0x81, 0x2f, 0x8c, 0xa9, 0xe7, 0x58, 0xbf, 0xab, 0x21, 0x47,
+	0xfa, 0x3b, 0x04, 0x07, 0x6e, 0x47, 0x0e, 0xfa, 0x21, 0xe1, 0x5f, 0x17, 0xf8, 0x1f, 0xc5, 0x0f,
+	0xe7, 0x24, 0xd6, 0xa3, 0x96, 0x71, 0x0e, 0xe1, 0x9f, 0x21, 0x28, 0xc7, 0x1f, 0x3b, 0xe0, 0x53,
+	0x43, 0x3d, 0x58, 0xfd, 0x1c, 0x62, 0x9a, 0x5e, 0x27, 0xb3, 0x48, 0xfd, 0x44, 0xee, 0xb1, 0x2f,
+	0xe5, 0x73, 0xcf, 0x7b, 0x0d, 0x01, 0x4e, 0x0a, 0x9e, 0x49, 0x09, 0x14, 0xdf, 0xaf, 0x88, 0x1a,
+	0x5a, 0x55, 0xaf, 0x9e, 0x1a, 0x39, 0x4f, 0x3d, 0xf3, 0x57, 0x72, 0xcf, 0x7c, 0x2f, 0x91, 0xff,
+	0x2a, 0x82, 0xca, 0x15, 0x9a, 0x5c, 0xfa, 0x72, 0x74, 0xa9, 0x7e, 0xab, 0x51, 0x5d, 0x1e, 0x3d,
+	0x51, 0x22, 0x3a, 0x2b, 0x10, 0xdd, 0x8f, 0xf3, 0x55, 0x15, 0x03, 0xf8, 0x0e, 0x82, 0x85, 0x9b,
+	0x59, 0x13, 0xc5, 0x67, 0x47, 0x49, 0x52, 0x8e, 0x9c, 0xf1, 0x71, 0x3d, 0x20, 0x70, 0xad, 0xea,
+	0x63, 0xe1, 0x5a, 0x93, 0x9f, 0x3d, 0x7c, 0x17, 0x45, 0x65, 0xac, 0x9e, 0x56, 0xe5, 0xfb, 0xd5,
+	0x5b, 0x4e, 0xc7, 0x53, 0xbf, 0x20, 0xf0, 0xd5, 0xf0, 0xd9, 0x71, 0xf0, 0xd5, 0x65, 0xff, 0x12,
+	0xbf, 0x8e, 0xe0, 0x80, 0xe8, 0x55, 0x67, 0x19, 0xe3, 0xbc, 0xf6, 0x6c, 0xda, 0xd9, 0x1e, 0xe3,
+	0x2c, 0x7c, 0x2c, 0x8a, 0x3f, 0xfa, 0xae, 0x40, 0xad, 0xc9, 0x2e, 0xf4, 0xd7, 0x0a, 0x88, 0xef,
+	0xef, 0x3d, 0x7d, 0xf8, 0x9e, 0x6e, 0xf4, 0x28, 0x70, 0x78, 0xef, 0x7d, 0x0c, 0x8c, 0x6b, 0x02,
+	0xe3, 0x05, 0xbd, 0xbe, 0x1b, 0x8c, 0xf5, 0x6e, 0x83, 0xbb, 0xe9, 0xd7, 0x11, 0xec, 0x8d, 0xf3,
+	0x03, 0x69, 0x7f, 0xab, 0xa3, 0xb6, 0x76, 0xb7, 0xf9, 0x84, 0x74, 0x88, 0x95, 0xf1, 0x1c, 0xe2,
+	0x2d, 0x04, 0x73, 0xb2, 0x95, 0x9c, 0x93, 0x75, 0x65, 0x7a, 0xcd, 0xd5, 0x9e, 0x3a, 0xac, 0xec,
+	0x35, 0xea, 0x5f, 0x10, 0x62, 0x9f, 0xc2, 0xb9, 0x6a, 0xf1, 0x3d, 0x2b, 0xa8, 0xbf, 0x20, 0x1b,
+	0x7d, 0x2f, 0xd6, 0x1d, 0xaf, 0x19, 0x3c, 0xa3, 0xe3, 0xdc, 0xdc, 0x82, 0xcf, 0x39, 0x87, 0x70,
+	0x08, 0xf3, 0xdc, 0x7c, 0x45, 0x71, 0x17, 0xab, 0x4a, 0x18, 0x50, 0xf7, 0xad, 0x56, 0xfb, 0x8a,
+	0xc5, 0x69, 0x32, 0x21, 0x2b, 0x1b, 0xf8, 0x58, 0xae, 0x58, 0x21, 0xe8, 0x15, 0x04, 0x07, 0xb2,
+	0xfe, 0x18, 0x89, 0x1f, 0xdb, 0x1b, 0xf3, 0x50, 0xc8, 0xfb, 0x09, 0x5e, 0x19, 0xcb, 0x8c, 0x22,
+	0x38, 0x2f, 0x23, 0x98, 0x93, 0x35, 0xda, 0xe1, 0x9b, 0x95, 0x2d, 0x4c, 0x57, 0x4f, 0x8d, 0x98,
+	0x95, 0xc0, 0x91, 0x79, 0x1e, 0x3e, 0x9e, 0x07, 0xa7, 0x25, 0x65, 0xef, 0x40, 0x49, 0xd4, 0x23,
+	0xb1, 0x9e, 0x5b, 0xae, 0x8c, 0x20, 0x8c, 0x2e, 0x69, 0x8e, 0xb7, 0x23, 0x4d, 0x3e, 0xfd, 0xf1,
+	0x27, 0x7e, 0xff, 0xce, 0x51, 0xf4, 0xa7, 0x77, 0x8e, 0xa2, 0xbf, 0xbe, 0x73, 0x14, 0x3d, 0xf3,
+	0xe0, 0x78, 0xff, 0xbc, 0x32, 0x1d, 0x9b, 0xba, 0x61, 0x96, 0xeb, 0x7f, 0x07, 0x00, 0x9d, 0xcd,
+	0x42, 0xf8, 0x5f, 0x36, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3443,6 +3760,11 @@ type ApplicationServiceClient interface {
 	ListLinks(ctx context.Context, in *ListAppLinksRequest, opts ...grpc.CallOption) (*LinksResponse, error)
 	// ListResourceLinks returns the list of all resource deep links
 	ListResourceLinks(ctx context.Context, in *ApplicationResourceRequest, opts ...grpc.CallOption) (*LinksResponse, error)
+	// History returns the deployment history of the application, including the entries archived outside of the
+	// application status
+	History(ctx context.Context, in *ApplicationHistoryQuery, opts ...grpc.CallOption) (*ApplicationHistoryResponse, error)
+	// Graph returns the graph of the Applications managed by an app-of-apps, walked recursively
+	Graph(ctx context.Context, in *ApplicationGraphQuery, opts ...grpc.CallOption) (*ApplicationGraphNode, error)
 }
 
 type applicationServiceClient struct {
@@ -3827,6 +4149,24 @@ func (c *applicationServiceClient) ListResourceLinks(ctx context.Context, in *Ap
 	return out, nil
 }
 
+func (c *applicationServiceClient) History(ctx context.Context, in *ApplicationHistoryQuery, opts ...grpc.CallOption) (*ApplicationHistoryResponse, error) {
+	out := new(ApplicationHistoryResponse)
+	err := c.cc.Invoke(ctx, "/application.ApplicationService/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) Graph(ctx context.Context, in *ApplicationGraphQuery, opts ...grpc.CallOption) (*ApplicationGraphNode, error) {
+	out := new(ApplicationGraphNode)
+	err := c.cc.Invoke(ctx, "/application.ApplicationService/Graph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ApplicationServiceServer is the server API for ApplicationService service.
 type ApplicationServiceServer interface {
 	// List returns list of applications
@@ -3894,6 +4234,11 @@ type ApplicationServiceServer interface {
 	ListLinks(context.Context, *ListAppLinksRequest) (*LinksResponse, error)
 	// ListResourceLinks returns the list of all resource deep links
 	ListResourceLinks(context.Context, *ApplicationResourceRequest) (*LinksResponse, error)
+	// History returns the deployment history of the application, including the entries archived outside of the
+	// application status
+	History(context.Context, *ApplicationHistoryQuery) (*ApplicationHistoryResponse, error)
+	// Graph returns the graph of the Applications managed by an app-of-apps, walked recursively
+	Graph(context.Context, *ApplicationGraphQuery) (*ApplicationGraphNode, error)
 }
 
 // UnimplementedApplicationServiceServer can be embedded to have forward compatible implementations.
@@ -3993,6 +4338,12 @@ func (*UnimplementedApplicationServiceServer) ListLinks(ctx context.Context, req
 func (*UnimplementedApplicationServiceServer) ListResourceLinks(ctx context.Context, req *ApplicationResourceRequest) (*LinksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListResourceLinks not implemented")
 }
+func (*UnimplementedApplicationServiceServer) History(ctx context.Context, req *ApplicationHistoryQuery) (*ApplicationHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (*UnimplementedApplicationServiceServer) Graph(ctx context.Context, req *ApplicationGraphQuery) (*ApplicationGraphNode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Graph not implemented")
+}
 
 func RegisterApplicationServiceServer(s *grpc.Server, srv ApplicationServiceServer) {
 	s.RegisterService(&_ApplicationService_serviceDesc, srv)
@@ -4573,6 +4924,42 @@ func _ApplicationService_ListResourceLinks_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationService_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationHistoryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationService/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).History(ctx, req.(*ApplicationHistoryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_Graph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationGraphQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).Graph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationService/Graph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).Graph(ctx, req.(*ApplicationGraphQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ApplicationService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "application.ApplicationService",
 	HandlerType: (*ApplicationServiceServer)(nil),
@@ -4685,6 +5072,14 @@ var _ApplicationService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListResourceLinks",
 			Handler:    _ApplicationService_ListResourceLinks_Handler,
 		},
+		{
+			MethodName: "History",
+			Handler:    _ApplicationService_History_Handler,
+		},
+		{
+			MethodName: "Graph",
+			Handler:    _ApplicationService_Graph_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -7420,26 +7815,274 @@ func (m *ListAppLinksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintApplication(dAtA []byte, offset int, v uint64) int {
-	offset -= sovApplication(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ApplicationHistoryQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ApplicationQuery) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *ApplicationHistoryQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplicationHistoryQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Name != nil {
-		l = len(*m.Name)
-		n += 1 + l + sovApplication(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Project != nil {
+		i -= len(*m.Project)
+		copy(dAtA[i:], *m.Project)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Project)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.AppNamespace != nil {
+		i -= len(*m.AppNamespace)
+		copy(dAtA[i:], *m.AppNamespace)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.AppNamespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Name == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("name")
+	} else {
+		i -= len(*m.Name)
+		copy(dAtA[i:], *m.Name)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApplicationHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplicationHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplicationHistoryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Archived) > 0 {
+		for iNdEx := len(m.Archived) - 1; iNdEx >= 0; iNdEx-- {
+			i = encodeVarintApplication(dAtA, i, uint64(m.Archived[iNdEx]))
+			i--
+			dAtA[i] = 0x10
+		}
+	}
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApplication(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApplicationGraphQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplicationGraphQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplicationGraphQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.MaxDepth != nil {
+		i = encodeVarintApplication(dAtA, i, uint64(*m.MaxDepth))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Project != nil {
+		i -= len(*m.Project)
+		copy(dAtA[i:], *m.Project)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Project)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.AppNamespace != nil {
+		i -= len(*m.AppNamespace)
+		copy(dAtA[i:], *m.AppNamespace)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.AppNamespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Name == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("name")
+	} else {
+		i -= len(*m.Name)
+		copy(dAtA[i:], *m.Name)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApplicationGraphNode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplicationGraphNode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplicationGraphNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Children) > 0 {
+		for iNdEx := len(m.Children) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Children[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApplication(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.Cycle != nil {
+		i--
+		if *m.Cycle {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Error != nil {
+		i -= len(*m.Error)
+		copy(dAtA[i:], *m.Error)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Health != nil {
+		i -= len(*m.Health)
+		copy(dAtA[i:], *m.Health)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Health)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Sync != nil {
+		i -= len(*m.Sync)
+		copy(dAtA[i:], *m.Sync)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Sync)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Project != nil {
+		i -= len(*m.Project)
+		copy(dAtA[i:], *m.Project)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Project)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Namespace != nil {
+		i -= len(*m.Namespace)
+		copy(dAtA[i:], *m.Namespace)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Name == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("name")
+	} else {
+		i -= len(*m.Name)
+		copy(dAtA[i:], *m.Name)
+		i = encodeVarintApplication(dAtA, i, uint64(len(*m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintApplication(dAtA []byte, offset int, v uint64) int {
+	offset -= sovApplication(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ApplicationQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovApplication(uint64(l))
 	}
 	if m.Refresh != nil {
 		l = len(*m.Refresh)
@@ -8682,26 +9325,145 @@ func (m *ListAppLinksRequest) Size() (n int) {
 	return n
 }
 
-func sovApplication(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozApplication(x uint64) (n int) {
-	return sovApplication(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *ApplicationHistoryQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.AppNamespace != nil {
+		l = len(*m.AppNamespace)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Project != nil {
+		l = len(*m.Project)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (m *ApplicationQuery) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowApplication
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
+
+func (m *ApplicationHistoryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovApplication(uint64(l))
+		}
+	}
+	if len(m.Archived) > 0 {
+		for _, e := range m.Archived {
+			n += 1 + sovApplication(uint64(e))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApplicationGraphQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.AppNamespace != nil {
+		l = len(*m.AppNamespace)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Project != nil {
+		l = len(*m.Project)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.MaxDepth != nil {
+		n += 1 + sovApplication(uint64(*m.MaxDepth))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApplicationGraphNode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Namespace != nil {
+		l = len(*m.Namespace)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Project != nil {
+		l = len(*m.Project)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Sync != nil {
+		l = len(*m.Sync)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Health != nil {
+		l = len(*m.Health)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Error != nil {
+		l = len(*m.Error)
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Cycle != nil {
+		n += 2
+	}
+	if len(m.Children) > 0 {
+		for _, e := range m.Children {
+			l = e.Size()
+			n += 1 + l + sovApplication(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovApplication(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozApplication(x uint64) (n int) {
+	return sovApplication(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ApplicationQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
 			iNdEx++
 			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
@@ -16992,6 +17754,806 @@ func (m *ListAppLinksRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ApplicationHistoryQuery) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplicationHistoryQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplicationHistoryQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppNamespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.AppNamespace = &s
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Project = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("name")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplicationHistoryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplicationHistoryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplicationHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &v1alpha1.RevisionHistory{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApplication
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Archived = append(m.Archived, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApplication
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthApplication
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthApplication
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Archived) == 0 {
+					m.Archived = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApplication
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Archived = append(m.Archived, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Archived", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplicationGraphQuery) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplicationGraphQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplicationGraphQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppNamespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.AppNamespace = &s
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Project = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDepth", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MaxDepth = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("name")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplicationGraphNode) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplicationGraphNode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplicationGraphNode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Namespace = &s
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Project = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sync", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Sync = &s
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Health", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Health = &s
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Error = &s
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cycle", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Cycle = &b
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Children", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Children = append(m.Children, &ApplicationGraphNode{})
+			if err := m.Children[len(m.Children)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("name")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipApplication(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1830,6 +1830,8 @@ const (
 	ApplicationConditionDeletionError = "DeletionError"
 	// ApplicationConditionInvalidSpecError indicates that application source is invalid
 	ApplicationConditionInvalidSpecError = "InvalidSpecError"
+	// ApplicationConditionOrphanedProjectError indicates that the project referenced by the application was deleted
+	ApplicationConditionOrphanedProjectError = "OrphanedProjectError"
 	// ApplicationConditionComparisonError indicates controller failed to compare application state
 	ApplicationConditionComparisonError = "ComparisonError"
 	// ApplicationConditionSyncError indicates controller failed to automatically sync the application
@@ -3119,6 +3121,16 @@ func (w SyncWindow) Active() (bool, error) {
 }
 
 func (w SyncWindow) active(currentTime time.Time) (bool, error) {
+	since, err := w.activeSince(currentTime)
+	return since != nil, err
+}
+
+// ActiveSince returns when the current activation of the sync window started, or nil if the sync window is not active
+func (w SyncWindow) ActiveSince() (*time.Time, error) {
+	return w.activeSince(time.Now())
+}
+
+func (w SyncWindow) activeSince(currentTime time.Time) (*time.Time, error) {
 	// If SyncWindow.Active() is called outside of a UTC locale, it should be
 	// first converted to UTC before search
 	currentTime = currentTime.UTC()
@@ -3126,18 +3138,22 @@ func (w SyncWindow) active(currentTime time.Time) (bool, error) {
 	specParser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
 	schedule, sErr := specParser.Parse(w.Schedule)
 	if sErr != nil {
-		return false, fmt.Errorf("cannot parse schedule '%s': %w", w.Schedule, sErr)
+		return nil, fmt.Errorf("cannot parse schedule '%s': %w", w.Schedule, sErr)
 	}
 	duration, dErr := time.ParseDuration(w.Duration)
 	if dErr != nil {
-		return false, fmt.Errorf("cannot parse duration '%s': %w", w.Duration, dErr)
+		return nil, fmt.Errorf("cannot parse duration '%s': %w", w.Duration, dErr)
 	}
 
 	// Offset the nextWindow time to consider the timeZone of the sync window
 	timeZoneOffsetDuration := w.scheduleOffsetByTimeZone()
 	nextWindow := schedule.Next(currentTime.Add(timeZoneOffsetDuration - duration))
 
-	return nextWindow.Before(currentTime.Add(timeZoneOffsetDuration)), nil
+	if !nextWindow.Before(currentTime.Add(timeZoneOffsetDuration)) {
+		return nil, nil
+	}
+	since := nextWindow.Add(-timeZoneOffsetDuration)
+	return &since, nil
 }
 
 // Update updates a sync window's settings with the given parameter
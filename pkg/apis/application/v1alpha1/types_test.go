@@ -3142,6 +3142,28 @@ func TestSyncWindow_Active(t *testing.T) {
 	}
 }
 
+func TestSyncWindow_ActiveSince(t *testing.T) {
+	currentTime := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	t.Run("Active", func(t *testing.T) {
+		window := SyncWindow{Kind: "allow", Schedule: "0 10 * * *", Duration: "1h"}
+		since, err := window.activeSince(currentTime)
+		require.NoError(t, err)
+		require.NotNil(t, since)
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), *since)
+	})
+	t.Run("Inactive", func(t *testing.T) {
+		window := SyncWindow{Kind: "allow", Schedule: "0 12 * * *", Duration: "1h"}
+		since, err := window.activeSince(currentTime)
+		require.NoError(t, err)
+		assert.Nil(t, since)
+	})
+	t.Run("InvalidSchedule", func(t *testing.T) {
+		window := SyncWindow{Kind: "allow", Schedule: "invalid", Duration: "1h"}
+		_, err := window.activeSince(currentTime)
+		require.Error(t, err)
+	})
+}
+
 func TestSyncWindow_Update(t *testing.T) {
 	e := SyncWindow{Kind: "allow", Schedule: "* * * * *", Duration: "1h", Applications: []string{"app1"}}
 	t.Run("AddApplication", func(t *testing.T) {
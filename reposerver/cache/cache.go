@@ -1,7 +1,9 @@
 package cache
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +11,7 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/argoproj/gitops-engine/pkg/utils/text"
@@ -34,6 +37,12 @@ type Cache struct {
 	repoCacheExpiration      time.Duration
 	revisionCacheExpiration  time.Duration
 	revisionCacheLockTimeout time.Duration
+	// manifestDeduplication stores the rendered manifests of the manifest cache once per distinct content
+	manifestDeduplication bool
+	// manifestContentWrites and manifestContentDeduplicated count the manifest cache writes and the ones which reused
+	// the rendered manifests stored by another entry
+	manifestContentWrites       atomic.Int64
+	manifestContentDeduplicated atomic.Int64
 }
 
 // ClusterRuntimeInfo holds cluster runtime information
@@ -49,26 +58,48 @@ type ClusterRuntimeInfo interface {
 type CachedManifestResponse struct {
 	// NOTE: When adding fields to this struct, you MUST also update shallowCopy()
 
-	CacheEntryHash                  string                      `json:"cacheEntryHash"`
-	ManifestResponse                *apiclient.ManifestResponse `json:"manifestResponse"`
-	MostRecentError                 string                      `json:"mostRecentError"`
-	FirstFailureTimestamp           int64                       `json:"firstFailureTimestamp"`
-	NumberOfConsecutiveFailures     int                         `json:"numberOfConsecutiveFailures"`
-	NumberOfCachedResponsesReturned int                         `json:"numberOfCachedResponsesReturned"`
+	CacheEntryHash   string                      `json:"cacheEntryHash"`
+	ManifestResponse *apiclient.ManifestResponse `json:"manifestResponse"`
+	// ManifestContentHash is the hash of the rendered manifests if they are stored in the content-addressable layer of
+	// the cache rather than in the entry itself
+	ManifestContentHash             string `json:"manifestContentHash"`
+	MostRecentError                 string `json:"mostRecentError"`
+	FirstFailureTimestamp           int64  `json:"firstFailureTimestamp"`
+	NumberOfConsecutiveFailures     int    `json:"numberOfConsecutiveFailures"`
+	NumberOfCachedResponsesReturned int    `json:"numberOfCachedResponsesReturned"`
 }
 
 func NewCache(cache *cacheutil.Cache, repoCacheExpiration time.Duration, revisionCacheExpiration time.Duration, revisionCacheLockTimeout time.Duration) *Cache {
-	return &Cache{cache, repoCacheExpiration, revisionCacheExpiration, revisionCacheLockTimeout}
+	return &Cache{cache: cache, repoCacheExpiration: repoCacheExpiration, revisionCacheExpiration: revisionCacheExpiration, revisionCacheLockTimeout: revisionCacheLockTimeout}
+}
+
+// SetManifestDeduplication enables or disables storing the rendered manifests of the manifest cache keyed by their
+// content hash, so that identical manifests rendered for different applications share a single cache entry
+func (c *Cache) SetManifestDeduplication(enabled bool) {
+	c.manifestDeduplication = enabled
+}
+
+// GetManifestDeduplicationRatio returns the ratio of manifest cache writes which reused rendered manifests already
+// stored by another cache entry. It is computed from the writes of this process only, the replicas sharing the cache
+// each report their own ratio.
+func (c *Cache) GetManifestDeduplicationRatio() float64 {
+	writes := c.manifestContentWrites.Load()
+	if writes == 0 {
+		return 0
+	}
+	return float64(c.manifestContentDeduplicated.Load()) / float64(writes)
 }
 
 func AddCacheFlagsToCmd(cmd *cobra.Command, opts ...cacheutil.Options) func() (*Cache, error) {
 	var repoCacheExpiration time.Duration
 	var revisionCacheExpiration time.Duration
 	var revisionCacheLockTimeout time.Duration
+	var manifestDeduplication bool
 
 	cmd.Flags().DurationVar(&repoCacheExpiration, "repo-cache-expiration", env.ParseDurationFromEnv("ARGOCD_REPO_CACHE_EXPIRATION", 24*time.Hour, 0, math.MaxInt64), "Cache expiration for repo state, incl. app lists, app details, manifest generation, revision meta-data")
 	cmd.Flags().DurationVar(&revisionCacheExpiration, "revision-cache-expiration", env.ParseDurationFromEnv("ARGOCD_RECONCILIATION_TIMEOUT", 3*time.Minute, 0, math.MaxInt64), "Cache expiration for cached revision")
 	cmd.Flags().DurationVar(&revisionCacheLockTimeout, "revision-cache-lock-timeout", env.ParseDurationFromEnv("ARGOCD_REVISION_CACHE_LOCK_TIMEOUT", 10*time.Second, 0, math.MaxInt64), "Cache TTL for locks to prevent duplicate requests on revisions, set to 0 to disable")
+	cmd.Flags().BoolVar(&manifestDeduplication, "repo-cache-manifest-deduplication", env.ParseBoolFromEnv("ARGOCD_REPO_CACHE_MANIFEST_DEDUPLICATION", false), "Store the rendered manifests of the manifest cache once per distinct content, so that applications rendering identical manifests share a cache entry")
 
 	repoFactory := cacheutil.AddCacheFlagsToCmd(cmd, opts...)
 
@@ -77,7 +108,9 @@ func AddCacheFlagsToCmd(cmd *cobra.Command, opts ...cacheutil.Options) func() (*
 		if err != nil {
 			return nil, fmt.Errorf("error adding cache flags to cmd: %w", err)
 		}
-		return NewCache(cache, repoCacheExpiration, revisionCacheExpiration, revisionCacheLockTimeout), nil
+		repoCache := NewCache(cache, repoCacheExpiration, revisionCacheExpiration, revisionCacheLockTimeout)
+		repoCache.SetManifestDeduplication(manifestDeduplication)
+		return repoCache, nil
 	}
 }
 
@@ -400,6 +433,26 @@ func (c *Cache) GetManifests(revision string, appSrc *appv1.ApplicationSource, s
 	// The expected hash matches the actual hash, so remove the hash from the returned value
 	res.CacheEntryHash = ""
 
+	if res.ManifestContentHash != "" {
+		var manifests []string
+		err = c.cache.GetItem(manifestContentKey(res.ManifestContentHash), &manifests)
+		if errors.Is(err, ErrCacheMiss) {
+			// The shared manifests expired before the entry referencing them, so the entry is treated as a cache miss too
+			LogDebugManifestCacheKeyFields("deleting manifests cache", "referenced manifest content is missing", revision, appSrc, srcRefs, clusterInfo, namespace, trackingMethod, appLabelKey, appName, refSourceCommitSHAs)
+			if err := c.DeleteManifests(revision, appSrc, srcRefs, clusterInfo, namespace, trackingMethod, appLabelKey, appName, refSourceCommitSHAs, installationID); err != nil {
+				return fmt.Errorf("unable to delete manifest after missing manifest content: %w", err)
+			}
+			return ErrCacheMiss
+		}
+		if err != nil {
+			return fmt.Errorf("unable to get manifest content: %w", err)
+		}
+		if res.ManifestResponse != nil {
+			res.ManifestResponse.Manifests = manifests
+		}
+		res.ManifestContentHash = ""
+	}
+
 	if res.ManifestResponse != nil {
 		// cached manifest response might be reused across different revisions, so we need to assume that the revision is the one we are looking for
 		res.ManifestResponse.Revision = revision
@@ -412,6 +465,21 @@ func (c *Cache) SetManifests(revision string, appSrc *appv1.ApplicationSource, s
 	// Generate and apply the cache entry hash, before writing
 	if res != nil {
 		res = res.shallowCopy()
+		if c.manifestDeduplication && res.ManifestResponse != nil && len(res.ManifestResponse.Manifests) > 0 {
+			contentHash, err := c.setManifestContent(res.ManifestResponse.Manifests)
+			if err != nil {
+				return fmt.Errorf("unable to set manifest content: %w", err)
+			}
+			res.ManifestResponse = &apiclient.ManifestResponse{
+				Namespace:    res.ManifestResponse.Namespace,
+				Server:       res.ManifestResponse.Server,
+				Revision:     res.ManifestResponse.Revision,
+				SourceType:   res.ManifestResponse.SourceType,
+				VerifyResult: res.ManifestResponse.VerifyResult,
+				Commands:     res.ManifestResponse.Commands,
+			}
+			res.ManifestContentHash = contentHash
+		}
 		hash, err := res.generateCacheEntryHash()
 		if err != nil {
 			return fmt.Errorf("unable to generate hash value: %w", err)
@@ -428,6 +496,37 @@ func (c *Cache) SetManifests(revision string, appSrc *appv1.ApplicationSource, s
 		})
 }
 
+func manifestContentKey(contentHash string) string {
+	return "mfst-content|" + contentHash
+}
+
+// setManifestContent stores the rendered manifests keyed by the hash of their content, unless they are already stored,
+// and returns the hash
+func (c *Cache) setManifestContent(manifests []string) (string, error) {
+	h := sha256.New()
+	for _, manifest := range manifests {
+		// the length prefix keeps different splits of the same bytes apart
+		_, _ = fmt.Fprintf(h, "%d:%s", len(manifest), manifest)
+	}
+	contentHash := hex.EncodeToString(h.Sum(nil))
+	c.manifestContentWrites.Add(1)
+
+	var existing []string
+	err := c.cache.GetItem(manifestContentKey(contentHash), &existing)
+	if err == nil {
+		// the expiration of the shared manifests is refreshed, so that they do not expire before the entries which
+		// reference them
+		c.manifestContentDeduplicated.Add(1)
+	} else if !errors.Is(err, ErrCacheMiss) {
+		return "", err
+	}
+	err = c.cache.SetItem(manifestContentKey(contentHash), manifests, &cacheutil.CacheActionOpts{Expiration: c.repoCacheExpiration})
+	if err != nil {
+		return "", err
+	}
+	return contentHash, nil
+}
+
 func (c *Cache) DeleteManifests(revision string, appSrc *appv1.ApplicationSource, srcRefs appv1.RefTargetRevisionMapping, clusterInfo ClusterRuntimeInfo, namespace, trackingMethod, appLabelKey, appName string, refSourceCommitSHAs ResolvedRevisions, installationID string) error {
 	return c.cache.SetItem(
 		manifestCacheKey(revision, appSrc, srcRefs, namespace, trackingMethod, appLabelKey, appName, clusterInfo, refSourceCommitSHAs, installationID),
@@ -531,6 +630,7 @@ func (cmr *CachedManifestResponse) shallowCopy() *CachedManifestResponse {
 		CacheEntryHash:                  cmr.CacheEntryHash,
 		FirstFailureTimestamp:           cmr.FirstFailureTimestamp,
 		ManifestResponse:                cmr.ManifestResponse,
+		ManifestContentHash:             cmr.ManifestContentHash,
 		MostRecentError:                 cmr.MostRecentError,
 		NumberOfCachedResponsesReturned: cmr.NumberOfCachedResponsesReturned,
 		NumberOfConsecutiveFailures:     cmr.NumberOfConsecutiveFailures,
@@ -137,6 +137,49 @@ func TestCache_GetManifests(t *testing.T) {
 	mockCache.AssertCacheCalledTimes(t, &mocks.CacheCallCounts{ExternalSets: 2, ExternalGets: 8})
 }
 
+func TestCache_GetManifestsWithDeduplication(t *testing.T) {
+	fixtures := newFixtures()
+	t.Cleanup(fixtures.mockCache.StopRedisCallback)
+	cache := fixtures.cache
+	mockCache := fixtures.mockCache
+	cache.SetManifestDeduplication(true)
+	q := &apiclient.ManifestRequest{}
+	manifests := []string{`{"kind":"ConfigMap"}`, `{"kind":"Deployment"}`}
+
+	for _, appName := range []string{"app-1", "app-2"} {
+		res := &CachedManifestResponse{ManifestResponse: &apiclient.ManifestResponse{SourceType: "my-source-type", Manifests: manifests}}
+		err := cache.SetManifests("my-revision", &v1alpha1.ApplicationSource{}, q.RefSources, q, "my-namespace", "", "my-app-label-key", appName, res, nil, "")
+		require.NoError(t, err)
+		// the response of the caller is not modified
+		assert.Equal(t, manifests, res.ManifestResponse.Manifests)
+	}
+	assert.InDelta(t, 0.5, cache.GetManifestDeduplicationRatio(), 0.0001)
+	// the shared manifests are set again by the second entry, to refresh their expiration
+	mockCache.AssertCacheCalledTimes(t, &mocks.CacheCallCounts{ExternalSets: 4, ExternalGets: 2})
+
+	for _, appName := range []string{"app-1", "app-2"} {
+		value := &CachedManifestResponse{}
+		err := cache.GetManifests("my-revision", &v1alpha1.ApplicationSource{}, q.RefSources, q, "my-namespace", "", "my-app-label-key", appName, value, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, manifests, value.ManifestResponse.Manifests)
+		assert.Equal(t, "my-source-type", value.ManifestResponse.SourceType)
+		assert.Empty(t, value.ManifestContentHash)
+	}
+
+	t.Run("expect cache miss because of missing content", func(t *testing.T) {
+		stored := &CachedManifestResponse{}
+		key := manifestCacheKey("my-revision", &v1alpha1.ApplicationSource{}, q.RefSources, "my-namespace", "", "my-app-label-key", "app-1", q, nil, "")
+		require.NoError(t, cache.cache.GetItem(key, stored))
+		require.NotEmpty(t, stored.ManifestContentHash)
+		assert.Empty(t, stored.ManifestResponse.Manifests)
+		require.NoError(t, cache.cache.SetItem(manifestContentKey(stored.ManifestContentHash), "", &cacheutil.CacheActionOpts{Delete: true}))
+
+		value := &CachedManifestResponse{}
+		err := cache.GetManifests("my-revision", &v1alpha1.ApplicationSource{}, q.RefSources, q, "my-namespace", "", "my-app-label-key", "app-1", value, nil, "")
+		require.ErrorIs(t, err, ErrCacheMiss)
+	})
+}
+
 func TestCache_GetAppDetails(t *testing.T) {
 	fixtures := newFixtures()
 	t.Cleanup(fixtures.mockCache.StopRedisCallback)
@@ -313,7 +356,7 @@ func TestCachedManifestResponse_ShallowCopyExpectedFields(t *testing.T) {
 	}
 
 	expectedFields := []string{
-		"cacheEntryHash", "manifestResponse", "mostRecentError", "firstFailureTimestamp",
+		"cacheEntryHash", "manifestResponse", "manifestContentHash", "mostRecentError", "firstFailureTimestamp",
 		"numberOfConsecutiveFailures", "numberOfCachedResponsesReturned",
 	}
 
@@ -191,6 +191,18 @@ func NewMetricsServer() *MetricsServer {
 	}
 }
 
+// RegisterManifestCacheDeduplicationRatio registers a gauge reporting the ratio of manifest cache writes which reused
+// rendered manifests already stored by another cache entry, for the writes of this replica only
+func (m *MetricsServer) RegisterManifestCacheDeduplicationRatio(ratio func() float64) {
+	m.PrometheusRegistry.MustRegister(prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: "argocd_repo_manifest_cache_deduplication_ratio",
+			Help: "Ratio of manifest cache writes of this replica which reused rendered manifests stored by another cache entry",
+		},
+		ratio,
+	))
+}
+
 func (m *MetricsServer) GetHandler() http.Handler {
 	return m.handler
 }
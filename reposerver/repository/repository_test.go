@@ -2067,8 +2067,11 @@ func mkTempParameters(ctx context.Context, source string) string {
 func runWithTempTestdata(t *testing.T, path string, runner func(t *testing.T, path string)) {
 	t.Helper()
 	tempDir := mkTempParameters(t.Context(), "./testdata/app-parameters")
+	// removed even if the runner fails the test
+	t.Cleanup(func() {
+		os.RemoveAll(tempDir)
+	})
 	runner(t, filepath.Join(tempDir, "app-parameters", path))
-	os.RemoveAll(tempDir)
 }
 
 func TestGenerateManifestsWithAppParameterFile(t *testing.T) {
@@ -49,6 +49,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	servercache "github.com/argoproj/argo-cd/v3/server/cache"
 	"github.com/argoproj/argo-cd/v3/server/deeplinks"
+	"github.com/argoproj/argo-cd/v3/util/app/history"
 	applog "github.com/argoproj/argo-cd/v3/util/app/log"
 	"github.com/argoproj/argo-cd/v3/util/argo"
 	"github.com/argoproj/argo-cd/v3/util/collections"
@@ -2367,6 +2368,89 @@ func (s *Server) ListResourceLinks(ctx context.Context, req *application.Applica
 	return finalList, nil
 }
 
+// History returns the deployment history of the application, merging the entries of the application status with the
+// entries archived by the configured revision history archive sink
+func (s *Server) History(ctx context.Context, q *application.ApplicationHistoryQuery) (*application.ApplicationHistoryResponse, error) {
+	a, _, err := s.getApplicationEnforceRBACInformer(ctx, rbac.ActionGet, q.GetProject(), q.GetAppNamespace(), q.GetName())
+	if err != nil {
+		return nil, err
+	}
+	sinkName, limit, err := s.settingsMgr.GetHistoryArchiveSink()
+	if err != nil {
+		return nil, fmt.Errorf("error getting revision history archive settings: %w", err)
+	}
+	sink, err := history.NewSink(sinkName, s.kubeclientset, limit)
+	if err != nil {
+		return nil, err
+	}
+	var archived v1alpha1.RevisionHistories
+	if sink != nil {
+		if archived, err = sink.List(ctx, a); err != nil {
+			return nil, err
+		}
+	}
+	inStatus := make(map[int64]bool, len(a.Status.History))
+	for _, entry := range a.Status.History {
+		inStatus[entry.ID] = true
+	}
+	res := &application.ApplicationHistoryResponse{}
+	for _, entry := range history.Merge(archived, a.Status.History) {
+		res.Items = append(res.Items, &entry)
+		if !inStatus[entry.ID] {
+			res.Archived = append(res.Archived, entry.ID)
+		}
+	}
+	return res, nil
+}
+
+// Graph returns the graph of the Applications managed by the application, walked recursively. Every Application of
+// the graph is retrieved with the permissions of the user, the Applications which cannot be retrieved are part of
+// the graph with their error only. Cycles are broken at the first repeated Application.
+func (s *Server) Graph(ctx context.Context, q *application.ApplicationGraphQuery) (*application.ApplicationGraphNode, error) {
+	a, _, err := s.getApplicationEnforceRBACInformer(ctx, rbac.ActionGet, q.GetProject(), q.GetAppNamespace(), q.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return s.walkGraph(ctx, a, int(q.GetMaxDepth()), 0, map[string]bool{}), nil
+}
+
+func (s *Server) walkGraph(ctx context.Context, a *v1alpha1.Application, maxDepth, depth int, ancestors map[string]bool) *application.ApplicationGraphNode {
+	node := &application.ApplicationGraphNode{
+		Name:      ptr.To(a.Name),
+		Namespace: ptr.To(a.Namespace),
+		Project:   ptr.To(a.Spec.GetProject()),
+		Sync:      ptr.To(string(a.Status.Sync.Status)),
+		Health:    ptr.To(string(a.Status.Health.Status)),
+	}
+	if maxDepth > 0 && depth >= maxDepth {
+		return node
+	}
+
+	key := a.Namespace + "/" + a.Name
+	ancestors[key] = true
+	defer delete(ancestors, key)
+	for _, res := range a.Status.Resources {
+		if res.Group != v1alpha1.ApplicationSchemaGroupVersionKind.Group || res.Kind != v1alpha1.ApplicationSchemaGroupVersionKind.Kind {
+			continue
+		}
+		childNamespace := res.Namespace
+		if childNamespace == "" {
+			childNamespace = a.Namespace
+		}
+		if ancestors[childNamespace+"/"+res.Name] {
+			node.Children = append(node.Children, &application.ApplicationGraphNode{Name: ptr.To(res.Name), Namespace: ptr.To(childNamespace), Cycle: ptr.To(true)})
+			continue
+		}
+		child, _, err := s.getApplicationEnforceRBACInformer(ctx, rbac.ActionGet, "", childNamespace, res.Name)
+		if err != nil {
+			node.Children = append(node.Children, &application.ApplicationGraphNode{Name: ptr.To(res.Name), Namespace: ptr.To(childNamespace), Error: ptr.To(err.Error())})
+			continue
+		}
+		node.Children = append(node.Children, s.walkGraph(ctx, child, maxDepth, depth+1, ancestors))
+	}
+	return node
+}
+
 func getAmbiguousRevision(app *v1alpha1.Application, syncReq *application.ApplicationSyncRequest, sourceIndex int) string {
 	ambiguousRevision := ""
 	if app.Spec.HasMultipleSources() {
@@ -2596,11 +2680,6 @@ func (s *Server) RunResourceActionV2(ctx context.Context, q *application.Resourc
 		return nil, err
 	}
 
-	liveObjBytes, err := json.Marshal(liveObj)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling live object: %w", err)
-	}
-
 	resourceOverrides, err := s.settingsMgr.GetResourceOverrides()
 	if err != nil {
 		return nil, fmt.Errorf("error getting resource overrides: %w", err)
@@ -2639,51 +2718,8 @@ func (s *Server) RunResourceActionV2(ctx context.Context, q *application.Resourc
 		return nil, err
 	}
 
-	// First, make sure all the returned resources are permitted, for each operation.
-	// Also perform create with dry-runs for all create-operation resources.
-	// This is performed separately to reduce the risk of only some of the resources being successfully created later.
-	// TODO: when apply/delete operations would be supported for custom actions,
-	// the dry-run for relevant apply/delete operation would have to be invoked as well.
-	for _, impactedResource := range newObjects {
-		newObj := impactedResource.UnstructuredObj
-		err := s.verifyResourcePermitted(destCluster, proj, newObj)
-		if err != nil {
-			return nil, err
-		}
-		if impactedResource.K8SOperation == lua.CreateOperation {
-			createOptions := metav1.CreateOptions{DryRun: []string{"All"}}
-			_, err := s.kubectl.CreateResource(ctx, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), newObj, createOptions)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	// Now, perform the actual operations.
-	// The creation itself is not transactional.
-	// TODO: maybe create a k8s list representation of the resources,
-	// and invoke create on this list resource to make it semi-transactional (there is still patch operation that is separate,
-	// thus can fail separately from create).
-	for _, impactedResource := range newObjects {
-		newObj := impactedResource.UnstructuredObj
-		newObjBytes, err := json.Marshal(newObj)
-		if err != nil {
-			return nil, fmt.Errorf("error marshaling new object: %w", err)
-		}
-
-		switch impactedResource.K8SOperation {
-		// No default case since a not supported operation would have failed upon unmarshaling earlier
-		case lua.PatchOperation:
-			_, err := s.patchResource(ctx, config, liveObjBytes, newObjBytes, newObj)
-			if err != nil {
-				return nil, err
-			}
-		case lua.CreateOperation:
-			_, err := s.createResource(ctx, config, newObj)
-			if err != nil {
-				return nil, err
-			}
-		}
+	if err := argo.ApplyResourceActionResult(ctx, s.kubectl, config, s.db, destCluster, proj, liveObj, newObjects); err != nil {
+		return nil, err
 	}
 
 	if res == nil {
@@ -2695,108 +2731,6 @@ func (s *Server) RunResourceActionV2(ctx context.Context, q *application.Resourc
 	return &application.ApplicationResponse{}, nil
 }
 
-func (s *Server) patchResource(ctx context.Context, config *rest.Config, liveObjBytes, newObjBytes []byte, newObj *unstructured.Unstructured) (*application.ApplicationResponse, error) {
-	diffBytes, err := jsonpatch.CreateMergePatch(liveObjBytes, newObjBytes)
-	if err != nil {
-		return nil, fmt.Errorf("error calculating merge patch: %w", err)
-	}
-	if string(diffBytes) == "{}" {
-		return &application.ApplicationResponse{}, nil
-	}
-
-	// The following logic detects if the resource action makes a modification to status and/or spec.
-	// If status was modified, we attempt to patch the status using status subresource, in case the
-	// CRD is configured using the status subresource feature. See:
-	// https://kubernetes.io/docs/tasks/extend-kubernetes/custom-resources/custom-resource-definitions/#status-subresource
-	// If status subresource is in use, the patch has to be split into two:
-	// * one to update spec (and other non-status fields)
-	// * the other to update only status.
-	nonStatusPatch, statusPatch, err := splitStatusPatch(diffBytes)
-	if err != nil {
-		return nil, fmt.Errorf("error splitting status patch: %w", err)
-	}
-	if statusPatch != nil {
-		_, err = s.kubectl.PatchResource(ctx, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), types.MergePatchType, diffBytes, "status")
-		if err != nil {
-			if !apierrors.IsNotFound(err) {
-				return nil, fmt.Errorf("error patching resource: %w", err)
-			}
-			// K8s API server returns 404 NotFound when the CRD does not support the status subresource
-			// if we get here, the CRD does not use the status subresource. We will fall back to a normal patch
-		} else {
-			// If we get here, the CRD does use the status subresource, so we must patch status and
-			// spec separately. update the diffBytes to the spec-only patch and fall through.
-			diffBytes = nonStatusPatch
-		}
-	}
-	if diffBytes != nil {
-		_, err = s.kubectl.PatchResource(ctx, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), types.MergePatchType, diffBytes)
-		if err != nil {
-			return nil, fmt.Errorf("error patching resource: %w", err)
-		}
-	}
-	return &application.ApplicationResponse{}, nil
-}
-
-func (s *Server) verifyResourcePermitted(destCluster *v1alpha1.Cluster, proj *v1alpha1.AppProject, obj *unstructured.Unstructured) error {
-	permitted, err := proj.IsResourcePermitted(schema.GroupKind{Group: obj.GroupVersionKind().Group, Kind: obj.GroupVersionKind().Kind}, obj.GetName(), obj.GetNamespace(), destCluster, func(project string) ([]*v1alpha1.Cluster, error) {
-		clusters, err := s.db.GetProjectClusters(context.TODO(), project)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get project clusters: %w", err)
-		}
-		return clusters, nil
-	})
-	if err != nil {
-		return fmt.Errorf("error checking resource permissions: %w", err)
-	}
-	if !permitted {
-		return fmt.Errorf("application is not permitted to manage %s/%s/%s in %s", obj.GroupVersionKind().Group, obj.GroupVersionKind().Kind, obj.GetName(), obj.GetNamespace())
-	}
-
-	return nil
-}
-
-func (s *Server) createResource(ctx context.Context, config *rest.Config, newObj *unstructured.Unstructured) (*application.ApplicationResponse, error) {
-	_, err := s.kubectl.CreateResource(ctx, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), newObj, metav1.CreateOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error creating resource: %w", err)
-	}
-	return &application.ApplicationResponse{}, nil
-}
-
-// splitStatusPatch splits a patch into two: one for a non-status patch, and the status-only patch.
-// Returns nil for either if the patch doesn't have modifications to non-status, or status, respectively.
-func splitStatusPatch(patch []byte) ([]byte, []byte, error) {
-	var obj map[string]any
-	err := json.Unmarshal(patch, &obj)
-	if err != nil {
-		return nil, nil, err
-	}
-	var nonStatusPatch, statusPatch []byte
-	if statusVal, ok := obj["status"]; ok {
-		// calculate the status-only patch
-		statusObj := map[string]any{
-			"status": statusVal,
-		}
-		statusPatch, err = json.Marshal(statusObj)
-		if err != nil {
-			return nil, nil, err
-		}
-		// remove status, and calculate the non-status patch
-		delete(obj, "status")
-		if len(obj) > 0 {
-			nonStatusPatch, err = json.Marshal(obj)
-			if err != nil {
-				return nil, nil, err
-			}
-		}
-	} else {
-		// status was not modified in patch
-		nonStatusPatch = patch
-	}
-	return nonStatusPatch, statusPatch, nil
-}
-
 func (s *Server) GetApplicationSyncWindows(ctx context.Context, q *application.ApplicationSyncWindowsQuery) (*application.ApplicationSyncWindowsResponse, error) {
 	a, proj, err := s.getApplicationEnforceRBACClient(ctx, rbac.ActionGet, q.GetProject(), q.GetAppNamespace(), q.GetName(), "")
 	if err != nil {
@@ -52,6 +52,7 @@ import (
 	servercache "github.com/argoproj/argo-cd/v3/server/cache"
 	"github.com/argoproj/argo-cd/v3/server/rbacpolicy"
 	"github.com/argoproj/argo-cd/v3/test"
+	"github.com/argoproj/argo-cd/v3/util/app/history"
 	"github.com/argoproj/argo-cd/v3/util/argo"
 	"github.com/argoproj/argo-cd/v3/util/assets"
 	"github.com/argoproj/argo-cd/v3/util/cache"
@@ -2982,37 +2983,6 @@ func TestGetCachedAppState(t *testing.T) {
 	})
 }
 
-func TestSplitStatusPatch(t *testing.T) {
-	specPatch := `{"spec":{"aaa":"bbb"}}`
-	statusPatch := `{"status":{"ccc":"ddd"}}`
-	{
-		nonStatus, status, err := splitStatusPatch([]byte(specPatch))
-		require.NoError(t, err)
-		assert.Equal(t, specPatch, string(nonStatus))
-		assert.Nil(t, status)
-	}
-	{
-		nonStatus, status, err := splitStatusPatch([]byte(statusPatch))
-		require.NoError(t, err)
-		assert.Nil(t, nonStatus)
-		assert.Equal(t, statusPatch, string(status))
-	}
-	{
-		bothPatch := `{"spec":{"aaa":"bbb"},"status":{"ccc":"ddd"}}`
-		nonStatus, status, err := splitStatusPatch([]byte(bothPatch))
-		require.NoError(t, err)
-		assert.Equal(t, specPatch, string(nonStatus))
-		assert.Equal(t, statusPatch, string(status))
-	}
-	{
-		otherFields := `{"operation":{"eee":"fff"},"spec":{"aaa":"bbb"},"status":{"ccc":"ddd"}}`
-		nonStatus, status, err := splitStatusPatch([]byte(otherFields))
-		require.NoError(t, err)
-		assert.JSONEq(t, `{"operation":{"eee":"fff"},"spec":{"aaa":"bbb"}}`, string(nonStatus))
-		assert.Equal(t, statusPatch, string(status))
-	}
-}
-
 func TestLogsGetSelectedPod(t *testing.T) {
 	deployment := v1alpha1.ResourceRef{Group: "", Version: "v1", Kind: "Deployment", Name: "deployment", UID: "1"}
 	rs := v1alpha1.ResourceRef{Group: "", Version: "v1", Kind: "ReplicaSet", Name: "rs", UID: "2"}
@@ -4655,3 +4625,112 @@ func TestTerminateOperationWithConflicts(t *testing.T) {
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, updateCallCount, 2, "Update should be called at least twice (once with conflict, once with success)")
 }
+
+func TestGraph(t *testing.T) {
+	newGraphApp := func(name, project string, children ...string) *v1alpha1.Application {
+		return newTestApp(func(app *v1alpha1.Application) {
+			app.Name = name
+			app.Spec.Project = project
+			app.Status.Sync.Status = v1alpha1.SyncStatusCodeSynced
+			app.Status.Health.Status = health.HealthStatusHealthy
+			for _, child := range children {
+				app.Status.Resources = append(app.Status.Resources, v1alpha1.ResourceStatus{Group: "argoproj.io", Kind: "Application", Name: child})
+			}
+			// resources other than Applications are not part of the graph
+			app.Status.Resources = append(app.Status.Resources, v1alpha1.ResourceStatus{Group: "apps", Kind: "Deployment", Namespace: "default", Name: name})
+		})
+	}
+	newServer := func(t *testing.T, apps ...runtime.Object) *Server {
+		t.Helper()
+		return newTestAppServerWithEnforcerConfigure(t, func(enf *rbac.Enforcer) {
+			_ = enf.SetBuiltinPolicy(`p, test-user, applications, get, default/*, allow`)
+			enf.SetDefaultRole("")
+		}, map[string]string{}, apps...)
+	}
+	//nolint:staticcheck
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "test-user"})
+
+	t.Run("Nested", func(t *testing.T) {
+		appServer := newServer(t, newGraphApp("root", "default", "a", "b"), newGraphApp("a", "default", "c"), newGraphApp("b", "default"), newGraphApp("c", "default"))
+		root, err := appServer.Graph(ctx, &application.ApplicationGraphQuery{Name: ptr.To("root")})
+		require.NoError(t, err)
+		assert.Equal(t, testNamespace, root.GetNamespace())
+		assert.Equal(t, "default", root.GetProject())
+		require.Len(t, root.Children, 2)
+		assert.Equal(t, "a", root.Children[0].GetName())
+		require.Len(t, root.Children[0].Children, 1)
+		assert.Equal(t, "c", root.Children[0].Children[0].GetName())
+		assert.Equal(t, string(health.HealthStatusHealthy), root.Children[0].Children[0].GetHealth())
+		assert.Empty(t, root.Children[1].Children)
+	})
+
+	t.Run("MaxDepth", func(t *testing.T) {
+		appServer := newServer(t, newGraphApp("root", "default", "a"), newGraphApp("a", "default", "c"), newGraphApp("c", "default"))
+		root, err := appServer.Graph(ctx, &application.ApplicationGraphQuery{Name: ptr.To("root"), MaxDepth: ptr.To(int64(1))})
+		require.NoError(t, err)
+		require.Len(t, root.Children, 1)
+		assert.Empty(t, root.Children[0].Children)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		appServer := newServer(t, newGraphApp("root", "default", "a"), newGraphApp("a", "default", "root", "a"))
+		root, err := appServer.Graph(ctx, &application.ApplicationGraphQuery{Name: ptr.To("root")})
+		require.NoError(t, err)
+		require.Len(t, root.Children, 1)
+		a := root.Children[0]
+		require.Len(t, a.Children, 2)
+		assert.True(t, a.Children[0].GetCycle())
+		assert.True(t, a.Children[1].GetCycle())
+		assert.Empty(t, a.Children[0].Children)
+	})
+
+	t.Run("PermissionDenied", func(t *testing.T) {
+		appServer := newServer(t, newGraphApp("root", "default", "a", "secret", "missing"), newGraphApp("a", "default"), newGraphApp("secret", "my-proj", "a"))
+		root, err := appServer.Graph(ctx, &application.ApplicationGraphQuery{Name: ptr.To("root")})
+		require.NoError(t, err)
+		require.Len(t, root.Children, 3)
+		assert.Empty(t, root.Children[0].GetError())
+		// the Applications of other projects and the missing Applications are indistinguishable
+		for _, denied := range root.Children[1:] {
+			assert.Contains(t, denied.GetError(), "permission denied")
+			assert.Empty(t, denied.GetHealth())
+			assert.Empty(t, denied.Children)
+		}
+	})
+
+	t.Run("RootPermissionDenied", func(t *testing.T) {
+		appServer := newServer(t, newGraphApp("secret", "my-proj", "a"), newGraphApp("a", "default"))
+		_, err := appServer.Graph(ctx, &application.ApplicationGraphQuery{Name: ptr.To("secret")})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestHistory(t *testing.T) {
+	testApp := newTestApp(func(app *v1alpha1.Application) {
+		app.Status.History = v1alpha1.RevisionHistories{{ID: 2, Revision: "bbb"}, {ID: 3, Revision: "ccc"}}
+	})
+
+	t.Run("NoSink", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp)
+		res, err := appServer.History(t.Context(), &application.ApplicationHistoryQuery{Name: ptr.To(testApp.Name)})
+		require.NoError(t, err)
+		require.Len(t, res.Items, 2)
+		assert.Empty(t, res.Archived)
+	})
+
+	t.Run("ConfigMapSink", func(t *testing.T) {
+		appServer := newTestAppServerWithEnforcerConfigure(t, func(enf *rbac.Enforcer) {
+			_ = enf.SetBuiltinPolicy(assets.BuiltinPolicyCSV)
+			enf.SetDefaultRole("role:admin")
+		}, map[string]string{"application.history.archive.sink": "configmap"}, testApp)
+		sink, err := history.NewSink(history.SinkConfigMap, appServer.kubeclientset, 0)
+		require.NoError(t, err)
+		require.NoError(t, sink.Archive(t.Context(), testApp, v1alpha1.RevisionHistories{{ID: 1, Revision: "aaa"}, {ID: 2, Revision: "bbb"}}))
+
+		res, err := appServer.History(t.Context(), &application.ApplicationHistoryQuery{Name: ptr.To(testApp.Name)})
+		require.NoError(t, err)
+		require.Len(t, res.Items, 3)
+		assert.Equal(t, []int64{1, 2, 3}, []int64{res.Items[0].ID, res.Items[1].ID, res.Items[2].ID})
+		assert.Equal(t, []int64{1}, res.Archived)
+	})
+}
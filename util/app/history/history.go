@@ -0,0 +1,186 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/hash"
+)
+
+const (
+	// SinkConfigMap archives revision history entries in a ConfigMap next to the Application
+	SinkConfigMap = "configmap"
+
+	// LabelKeyHistoryArchive is the label set on ConfigMaps holding archived revision history
+	LabelKeyHistoryArchive = "argocd.argoproj.io/history-archive"
+	// AnnotationKeyApplication is the annotation holding the qualified name of the archived Application
+	AnnotationKeyApplication = "argocd.argoproj.io/application"
+
+	configMapPrefix = "argocd-history-"
+	configMapKey    = "history.json"
+	// maxNameLength is the maximum length of a ConfigMap name
+	maxNameLength = 253
+)
+
+// Sink stores revision history entries trimmed from the status of an Application, so that the in-CR history can be
+// kept small while preserving the long-term deployment history.
+type Sink interface {
+	// Archive adds the given entries to the archived history of the Application
+	Archive(ctx context.Context, app *v1alpha1.Application, entries v1alpha1.RevisionHistories) error
+	// List returns the archived history of the Application, ordered by ID
+	List(ctx context.Context, app *v1alpha1.Application) (v1alpha1.RevisionHistories, error)
+}
+
+// NewSink returns the Sink with the given name, or nil if name is empty. Limit is the maximum number of archived
+// entries retained per Application; zero or a negative value means no limit.
+func NewSink(name string, kubeClientset kubernetes.Interface, limit int) (Sink, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case SinkConfigMap:
+		return &configMapSink{kubeClientset: kubeClientset, limit: limit}, nil
+	default:
+		return nil, fmt.Errorf("unknown revision history archive sink %q", name)
+	}
+}
+
+type configMapSink struct {
+	kubeClientset kubernetes.Interface
+	limit         int
+}
+
+// configMapName returns the name of the ConfigMap holding the archived history of the Application
+func configMapName(app *v1alpha1.Application) string {
+	name := configMapPrefix + app.Name
+	if len(name) > maxNameLength {
+		suffix := fmt.Sprintf("-%d", hash.FNVa(app.Name))
+		name = name[:maxNameLength-len(suffix)] + suffix
+	}
+	return name
+}
+
+func (s *configMapSink) Archive(ctx context.Context, app *v1alpha1.Application, entries v1alpha1.RevisionHistories) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	configMaps := s.kubeClientset.CoreV1().ConfigMaps(app.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := configMaps.Get(ctx, configMapName(app), metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting revision history archive: %w", err)
+		}
+		exists := err == nil
+		if !exists {
+			cm = s.newConfigMap(app)
+		}
+		archived, err := decode(cm)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(Merge(archived, entries).Trunc(s.retained()))
+		if err != nil {
+			return fmt.Errorf("error marshaling revision history archive: %w", err)
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[configMapKey] = string(data)
+		if exists {
+			_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+		} else {
+			_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// created concurrently, retry as a conflict to merge with the existing entries
+				return apierrors.NewConflict(corev1.Resource("configmaps"), cm.Name, err)
+			}
+		}
+		return err
+	})
+}
+
+func (s *configMapSink) List(ctx context.Context, app *v1alpha1.Application) (v1alpha1.RevisionHistories, error) {
+	cm, err := s.kubeClientset.CoreV1().ConfigMaps(app.Namespace).Get(ctx, configMapName(app), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting revision history archive: %w", err)
+	}
+	return decode(cm)
+}
+
+func (s *configMapSink) retained() int {
+	if s.limit <= 0 {
+		return math.MaxInt
+	}
+	return s.limit
+}
+
+func (s *configMapSink) newConfigMap(app *v1alpha1.Application) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName(app),
+			Namespace: app.Namespace,
+			Labels: map[string]string{
+				LabelKeyHistoryArchive:      "true",
+				"app.kubernetes.io/part-of": "argocd",
+			},
+			Annotations: map[string]string{
+				AnnotationKeyApplication:      app.QualifiedName(),
+				common.AnnotationKeyManagedBy: common.AnnotationValueManagedByArgoCD,
+			},
+		},
+	}
+	if app.UID != "" {
+		// the archive is garbage collected together with the Application
+		cm.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: application.Group + "/v1alpha1",
+			Kind:       application.ApplicationKind,
+			Name:       app.Name,
+			UID:        app.UID,
+		}}
+	}
+	return cm
+}
+
+func decode(cm *corev1.ConfigMap) (v1alpha1.RevisionHistories, error) {
+	data, ok := cm.Data[configMapKey]
+	if !ok || data == "" {
+		return nil, nil
+	}
+	var entries v1alpha1.RevisionHistories
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, fmt.Errorf("error unmarshaling revision history archive %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	return entries, nil
+}
+
+// Merge returns the union of both histories ordered by ID. Entries of next override entries of prev with the same ID.
+func Merge(prev, next v1alpha1.RevisionHistories) v1alpha1.RevisionHistories {
+	byID := make(map[int64]v1alpha1.RevisionHistory, len(prev)+len(next))
+	for _, entry := range prev {
+		byID[entry.ID] = entry
+	}
+	for _, entry := range next {
+		byID[entry.ID] = entry
+	}
+	merged := make(v1alpha1.RevisionHistories, 0, len(byID))
+	for _, entry := range byID {
+		merged = append(merged, entry)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].ID < merged[j].ID
+	})
+	return merged
+}
@@ -0,0 +1,104 @@
+package history
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func newApp(name string) *v1alpha1.Application {
+	return &v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argocd", UID: "uid"},
+	}
+}
+
+func entries(ids ...int64) v1alpha1.RevisionHistories {
+	var res v1alpha1.RevisionHistories
+	for _, id := range ids {
+		res = append(res, v1alpha1.RevisionHistory{ID: id, Revision: "rev"})
+	}
+	return res
+}
+
+func ids(entries v1alpha1.RevisionHistories) []int64 {
+	var res []int64
+	for _, entry := range entries {
+		res = append(res, entry.ID)
+	}
+	return res
+}
+
+func TestNewSink(t *testing.T) {
+	sink, err := NewSink("", fake.NewClientset(), 0)
+	require.NoError(t, err)
+	assert.Nil(t, sink)
+
+	sink, err = NewSink(SinkConfigMap, fake.NewClientset(), 0)
+	require.NoError(t, err)
+	assert.NotNil(t, sink)
+
+	_, err = NewSink("s3", fake.NewClientset(), 0)
+	require.ErrorContains(t, err, `unknown revision history archive sink "s3"`)
+}
+
+func TestConfigMapSink(t *testing.T) {
+	kubeClientset := fake.NewClientset()
+	sink, err := NewSink(SinkConfigMap, kubeClientset, 4)
+	require.NoError(t, err)
+	app := newApp("guestbook")
+
+	archived, err := sink.List(t.Context(), app)
+	require.NoError(t, err)
+	assert.Empty(t, archived)
+
+	require.NoError(t, sink.Archive(t.Context(), app, entries(0, 1)))
+	require.NoError(t, sink.Archive(t.Context(), app, entries(2)))
+	archived, err = sink.List(t.Context(), app)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2}, ids(archived))
+
+	// entries archived twice are not duplicated and the oldest entries are dropped past the limit
+	require.NoError(t, sink.Archive(t.Context(), app, entries(2, 3, 4)))
+	archived, err = sink.List(t.Context(), app)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3, 4}, ids(archived))
+
+	cm, err := kubeClientset.CoreV1().ConfigMaps("argocd").Get(t.Context(), "argocd-history-guestbook", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "true", cm.Labels[LabelKeyHistoryArchive])
+	assert.Equal(t, "argocd/guestbook", cm.Annotations[AnnotationKeyApplication])
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, "guestbook", cm.OwnerReferences[0].Name)
+}
+
+func TestConfigMapSink_InvalidData(t *testing.T) {
+	kubeClientset := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-history-guestbook", Namespace: "argocd"},
+		Data:       map[string]string{configMapKey: "not-json"},
+	})
+	sink, err := NewSink(SinkConfigMap, kubeClientset, 0)
+	require.NoError(t, err)
+
+	_, err = sink.List(t.Context(), newApp("guestbook"))
+	require.ErrorContains(t, err, "error unmarshaling revision history archive argocd/argocd-history-guestbook")
+}
+
+func TestConfigMapName(t *testing.T) {
+	assert.Equal(t, "argocd-history-guestbook", configMapName(newApp("guestbook")))
+
+	name := configMapName(newApp(strings.Repeat("a", 253)))
+	assert.Len(t, name, maxNameLength)
+	assert.NotEqual(t, name, configMapName(newApp(strings.Repeat("a", 252)+"b")))
+}
+
+func TestMerge(t *testing.T) {
+	assert.Equal(t, []int64{0, 1, 2, 3}, ids(Merge(entries(2, 0), entries(3, 1, 2))))
+	assert.Empty(t, Merge(nil, nil))
+}
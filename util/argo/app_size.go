@@ -0,0 +1,54 @@
+package argo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// ApplicationObjectSize is the size, in bytes, of the serialized Application and of its main parts. Applications are
+// stored as JSON, so the total size reflects the storage cost of the Application in etcd.
+type ApplicationObjectSize struct {
+	Total int `json:"total"`
+	Spec  int `json:"spec"`
+	// Status is the size of the status, without the history
+	Status  int `json:"status"`
+	History int `json:"history"`
+}
+
+// GetApplicationObjectSize returns the size of the serialized Application, broken down by spec, status and history
+func GetApplicationObjectSize(app *argoappv1.Application) (ApplicationObjectSize, error) {
+	var size ApplicationObjectSize
+	var err error
+	if size.Total, err = serializedSize(app); err != nil {
+		return size, fmt.Errorf("error serializing application %s: %w", app.QualifiedName(), err)
+	}
+	if size.Spec, err = serializedSize(app.Spec); err != nil {
+		return size, fmt.Errorf("error serializing spec of application %s: %w", app.QualifiedName(), err)
+	}
+	status := app.Status.DeepCopy()
+	status.History = nil
+	if size.Status, err = serializedSize(status); err != nil {
+		return size, fmt.Errorf("error serializing status of application %s: %w", app.QualifiedName(), err)
+	}
+	if len(app.Status.History) > 0 {
+		if size.History, err = serializedSize(app.Status.History); err != nil {
+			return size, fmt.Errorf("error serializing history of application %s: %w", app.QualifiedName(), err)
+		}
+	}
+	return size, nil
+}
+
+// GetApplicationSerializedSize returns the size of the serialized Application
+func GetApplicationSerializedSize(app *argoappv1.Application) (int, error) {
+	return serializedSize(app)
+}
+
+func serializedSize(obj any) (int, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
@@ -0,0 +1,55 @@
+package argo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestGetApplicationObjectSize(t *testing.T) {
+	app := &argoappv1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "argocd"},
+		Spec: argoappv1.ApplicationSpec{
+			Project: "default",
+			Source:  &argoappv1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps", Path: "guestbook"},
+		},
+		Status: argoappv1.ApplicationStatus{
+			Sync: argoappv1.SyncStatus{Status: argoappv1.SyncStatusCodeSynced},
+		},
+	}
+
+	t.Run("WithoutHistory", func(t *testing.T) {
+		size, err := GetApplicationObjectSize(app)
+		require.NoError(t, err)
+		data, err := json.Marshal(app)
+		require.NoError(t, err)
+		assert.Equal(t, len(data), size.Total)
+		spec, err := json.Marshal(app.Spec)
+		require.NoError(t, err)
+		assert.Equal(t, len(spec), size.Spec)
+		assert.Positive(t, size.Status)
+		assert.Zero(t, size.History)
+	})
+
+	t.Run("WithHistory", func(t *testing.T) {
+		withHistory := app.DeepCopy()
+		withHistory.Status.History = argoappv1.RevisionHistories{{ID: 1, Revision: strings.Repeat("a", 1000)}}
+		without, err := GetApplicationObjectSize(app)
+		require.NoError(t, err)
+		size, err := GetApplicationObjectSize(withHistory)
+		require.NoError(t, err)
+		assert.Greater(t, size.History, 1000)
+		// the history is not part of the size of the status
+		assert.Equal(t, without.Status, size.Status)
+		assert.Greater(t, size.Total, without.Total+1000)
+		total, err := GetApplicationSerializedSize(withHistory)
+		require.NoError(t, err)
+		assert.Equal(t, size.Total, total)
+	})
+}
@@ -0,0 +1,170 @@
+package argo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	jsonpatch "github.com/evanphx/json-patch"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/lua"
+)
+
+// ApplyResourceActionResult applies the resources returned by a Lua resource action run on the live object, in the
+// destination cluster of the given REST config.
+func ApplyResourceActionResult(ctx context.Context, kubectl kube.Kubectl, config *rest.Config, argoDB db.ArgoDB, destCluster *argoappv1.Cluster, proj *argoappv1.AppProject, liveObj *unstructured.Unstructured, newObjects []lua.ImpactedResource) error {
+	// First, make sure all the returned resources are permitted, for each operation.
+	// Also perform create with dry-runs for all create-operation resources.
+	// This is performed separately to reduce the risk of only some of the resources being successfully created later.
+	// TODO: when apply/delete operations would be supported for custom actions,
+	// the dry-run for relevant apply/delete operation would have to be invoked as well.
+	for _, impactedResource := range newObjects {
+		newObj := impactedResource.UnstructuredObj
+		if err := VerifyResourcePermitted(ctx, argoDB, destCluster, proj, newObj); err != nil {
+			return err
+		}
+		if impactedResource.K8SOperation == lua.CreateOperation {
+			createOptions := metav1.CreateOptions{DryRun: []string{"All"}}
+			_, err := kubectl.CreateResource(ctx, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), newObj, createOptions)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	liveObjBytes, err := json.Marshal(liveObj)
+	if err != nil {
+		return fmt.Errorf("error marshaling live object: %w", err)
+	}
+	// Now, perform the actual operations.
+	// The creation itself is not transactional.
+	// TODO: maybe create a k8s list representation of the resources,
+	// and invoke create on this list resource to make it semi-transactional (there is still patch operation that is separate,
+	// thus can fail separately from create).
+	for _, impactedResource := range newObjects {
+		newObj := impactedResource.UnstructuredObj
+		newObjBytes, err := json.Marshal(newObj)
+		if err != nil {
+			return fmt.Errorf("error marshaling new object: %w", err)
+		}
+
+		switch impactedResource.K8SOperation {
+		// No default case since a not supported operation would have failed upon unmarshaling earlier
+		case lua.PatchOperation:
+			if err := patchResource(ctx, kubectl, config, liveObjBytes, newObjBytes, newObj); err != nil {
+				return err
+			}
+		case lua.CreateOperation:
+			_, err := kubectl.CreateResource(ctx, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), newObj, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("error creating resource: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func patchResource(ctx context.Context, kubectl kube.Kubectl, config *rest.Config, liveObjBytes, newObjBytes []byte, newObj *unstructured.Unstructured) error {
+	diffBytes, err := jsonpatch.CreateMergePatch(liveObjBytes, newObjBytes)
+	if err != nil {
+		return fmt.Errorf("error calculating merge patch: %w", err)
+	}
+	if string(diffBytes) == "{}" {
+		return nil
+	}
+
+	// The following logic detects if the resource action makes a modification to status and/or spec.
+	// If status was modified, we attempt to patch the status using status subresource, in case the
+	// CRD is configured using the status subresource feature. See:
+	// https://kubernetes.io/docs/tasks/extend-kubernetes/custom-resources/custom-resource-definitions/#status-subresource
+	// If status subresource is in use, the patch has to be split into two:
+	// * one to update spec (and other non-status fields)
+	// * the other to update only status.
+	nonStatusPatch, statusPatch, err := splitStatusPatch(diffBytes)
+	if err != nil {
+		return fmt.Errorf("error splitting status patch: %w", err)
+	}
+	if statusPatch != nil {
+		_, err = kubectl.PatchResource(ctx, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), types.MergePatchType, diffBytes, "status")
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("error patching resource: %w", err)
+			}
+			// K8s API server returns 404 NotFound when the CRD does not support the status subresource
+			// if we get here, the CRD does not use the status subresource. We will fall back to a normal patch
+		} else {
+			// If we get here, the CRD does use the status subresource, so we must patch status and
+			// spec separately. update the diffBytes to the spec-only patch and fall through.
+			diffBytes = nonStatusPatch
+		}
+	}
+	if diffBytes != nil {
+		_, err = kubectl.PatchResource(ctx, config, newObj.GroupVersionKind(), newObj.GetName(), newObj.GetNamespace(), types.MergePatchType, diffBytes)
+		if err != nil {
+			return fmt.Errorf("error patching resource: %w", err)
+		}
+	}
+	return nil
+}
+
+// VerifyResourcePermitted returns an error if the project does not permit its applications to manage the object in
+// the destination cluster
+func VerifyResourcePermitted(ctx context.Context, argoDB db.ArgoDB, destCluster *argoappv1.Cluster, proj *argoappv1.AppProject, obj *unstructured.Unstructured) error {
+	permitted, err := proj.IsResourcePermitted(schema.GroupKind{Group: obj.GroupVersionKind().Group, Kind: obj.GroupVersionKind().Kind}, obj.GetName(), obj.GetNamespace(), destCluster, func(project string) ([]*argoappv1.Cluster, error) {
+		clusters, err := argoDB.GetProjectClusters(ctx, project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project clusters: %w", err)
+		}
+		return clusters, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error checking resource permissions: %w", err)
+	}
+	if !permitted {
+		return fmt.Errorf("application is not permitted to manage %s/%s/%s in %s", obj.GroupVersionKind().Group, obj.GroupVersionKind().Kind, obj.GetName(), obj.GetNamespace())
+	}
+
+	return nil
+}
+
+// splitStatusPatch splits a patch into two: one for a non-status patch, and the status-only patch.
+// Returns nil for either if the patch doesn't have modifications to non-status, or status, respectively.
+func splitStatusPatch(patch []byte) ([]byte, []byte, error) {
+	var obj map[string]any
+	err := json.Unmarshal(patch, &obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	var nonStatusPatch, statusPatch []byte
+	if statusVal, ok := obj["status"]; ok {
+		// calculate the status-only patch
+		statusObj := map[string]any{
+			"status": statusVal,
+		}
+		statusPatch, err = json.Marshal(statusObj)
+		if err != nil {
+			return nil, nil, err
+		}
+		// remove status, and calculate the non-status patch
+		delete(obj, "status")
+		if len(obj) > 0 {
+			nonStatusPatch, err = json.Marshal(obj)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	} else {
+		// status was not modified in patch
+		nonStatusPatch = patch
+	}
+	return nonStatusPatch, statusPatch, nil
+}
@@ -0,0 +1,119 @@
+package argo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube/kubetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/lua"
+)
+
+// recordingKubectl records the creates and patches of the resources, and fails the creates of the names in createErrs
+type recordingKubectl struct {
+	kubetest.MockKubectlCmd
+	calls      []string
+	createErrs map[string]error
+}
+
+func (k *recordingKubectl) CreateResource(_ context.Context, _ *rest.Config, _ schema.GroupVersionKind, name string, _ string, _ *unstructured.Unstructured, createOptions metav1.CreateOptions, _ ...string) (*unstructured.Unstructured, error) {
+	if len(createOptions.DryRun) > 0 {
+		k.calls = append(k.calls, "dry-run create "+name)
+	} else {
+		k.calls = append(k.calls, "create "+name)
+	}
+	return nil, k.createErrs[name]
+}
+
+func (k *recordingKubectl) PatchResource(_ context.Context, _ *rest.Config, _ schema.GroupVersionKind, name string, _ string, _ types.PatchType, patch []byte, _ ...string) (*unstructured.Unstructured, error) {
+	k.calls = append(k.calls, "patch "+name+" "+string(patch))
+	return nil, nil
+}
+
+func TestApplyResourceActionResult(t *testing.T) {
+	destCluster := &argoappv1.Cluster{Server: "https://kubernetes.default.svc"}
+	proj := &argoappv1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "argocd"},
+		Spec: argoappv1.AppProjectSpec{
+			Destinations: []argoappv1.ApplicationDestination{{Server: "*", Namespace: "*"}},
+		},
+	}
+	object := func(name string, data string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+			"data":       map[string]any{"key": data},
+		}}
+	}
+	liveObj := object("live", "old")
+
+	t.Run("DryRunsCreatesFirst", func(t *testing.T) {
+		kubectl := &recordingKubectl{}
+		err := ApplyResourceActionResult(t.Context(), kubectl, &rest.Config{}, nil, destCluster, proj, liveObj, []lua.ImpactedResource{
+			{UnstructuredObj: object("live", "new"), K8SOperation: lua.PatchOperation},
+			{UnstructuredObj: object("created", "new"), K8SOperation: lua.CreateOperation},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dry-run create created", `patch live {"data":{"key":"new"}}`, "create created"}, kubectl.calls)
+	})
+	t.Run("FailedDryRunCreatesNothing", func(t *testing.T) {
+		kubectl := &recordingKubectl{createErrs: map[string]error{"second": errors.New("admission denied")}}
+		err := ApplyResourceActionResult(t.Context(), kubectl, &rest.Config{}, nil, destCluster, proj, liveObj, []lua.ImpactedResource{
+			{UnstructuredObj: object("first", "new"), K8SOperation: lua.CreateOperation},
+			{UnstructuredObj: object("second", "new"), K8SOperation: lua.CreateOperation},
+		})
+		require.EqualError(t, err, "admission denied")
+		assert.Equal(t, []string{"dry-run create first", "dry-run create second"}, kubectl.calls)
+	})
+	t.Run("NotPermitted", func(t *testing.T) {
+		kubectl := &recordingKubectl{}
+		denied := proj.DeepCopy()
+		denied.Spec.NamespaceResourceBlacklist = []metav1.GroupKind{{Group: "", Kind: "ConfigMap"}}
+		err := ApplyResourceActionResult(t.Context(), kubectl, &rest.Config{}, nil, destCluster, denied, liveObj, []lua.ImpactedResource{
+			{UnstructuredObj: object("created", "new"), K8SOperation: lua.CreateOperation},
+		})
+		require.EqualError(t, err, "application is not permitted to manage /ConfigMap/created in default")
+		assert.Empty(t, kubectl.calls)
+	})
+}
+
+func TestSplitStatusPatch(t *testing.T) {
+	specPatch := `{"spec":{"aaa":"bbb"}}`
+	statusPatch := `{"status":{"ccc":"ddd"}}`
+	{
+		nonStatus, status, err := splitStatusPatch([]byte(specPatch))
+		require.NoError(t, err)
+		assert.Equal(t, specPatch, string(nonStatus))
+		assert.Nil(t, status)
+	}
+	{
+		nonStatus, status, err := splitStatusPatch([]byte(statusPatch))
+		require.NoError(t, err)
+		assert.Nil(t, nonStatus)
+		assert.Equal(t, statusPatch, string(status))
+	}
+	{
+		bothPatch := `{"spec":{"aaa":"bbb"},"status":{"ccc":"ddd"}}`
+		nonStatus, status, err := splitStatusPatch([]byte(bothPatch))
+		require.NoError(t, err)
+		assert.Equal(t, specPatch, string(nonStatus))
+		assert.Equal(t, statusPatch, string(status))
+	}
+	{
+		otherFields := `{"operation":{"eee":"fff"},"spec":{"aaa":"bbb"},"status":{"ccc":"ddd"}}`
+		nonStatus, status, err := splitStatusPatch([]byte(otherFields))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"operation":{"eee":"fff"},"spec":{"aaa":"bbb"}}`, string(nonStatus))
+		assert.Equal(t, statusPatch, string(status))
+	}
+}
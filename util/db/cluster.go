@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -301,9 +302,29 @@ func (db *db) GetClusterServersByName(_ context.Context, name string) ([]string,
 				filtered = append(filtered, s)
 			}
 		}
-		return filtered, nil
+		servers = filtered
 	}
 
+	if len(servers) > 1 {
+		return db.resolveDuplicateClusterName(name, servers)
+	}
+	return servers, nil
+}
+
+// resolveDuplicateClusterName returns the servers of the clusters sharing the given name, all of them in strict mode so
+// that callers expecting a single cluster fail, and only the one with the lowest server URL in lenient mode
+func (db *db) resolveDuplicateClusterName(name string, servers []string) ([]string, error) {
+	resolution, err := db.settingsMgr.GetClusterNameResolution()
+	if err != nil {
+		return nil, err
+	}
+	servers = slices.Clone(servers)
+	slices.Sort(servers)
+	if resolution == settings.ClusterNameResolutionLenient {
+		log.Warnf("%d clusters are named %q, resolving the name to %s: [%s]", len(servers), name, servers[0], strings.Join(servers, " "))
+		return servers[:1], nil
+	}
+	log.Warnf("%d clusters are named %q: [%s]", len(servers), name, strings.Join(servers, " "))
 	return servers, nil
 }
 
@@ -659,6 +659,52 @@ func TestGetClusterServersByName(t *testing.T) {
 		require.NoError(t, err)
 		assert.Empty(t, servers)
 	})
+
+	newClusterSecret := func(secretName, server string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: fakeNamespace,
+				Labels: map[string]string{
+					common.LabelKeySecretType: common.LabelValueSecretTypeCluster,
+				},
+			},
+			Data: map[string][]byte{
+				"name":   []byte("duplicate"),
+				"server": []byte(server),
+				"config": []byte("{}"),
+			},
+		}
+	}
+	newConfigMap := func(resolution string) *corev1.ConfigMap {
+		cm := emptyArgoCDConfigMap.DeepCopy()
+		if resolution != "" {
+			cm.Data = map[string]string{"cluster.nameResolution": resolution}
+		}
+		return cm
+	}
+	t.Run("returns all duplicates in strict mode", func(t *testing.T) {
+		for _, resolution := range []string{"", "strict"} {
+			kubeclientset := fake.NewClientset(newConfigMap(resolution), argoCDSecret, newClusterSecret("b", "https://b"), newClusterSecret("a", "https://a"))
+			db := NewDB(fakeNamespace, settings.NewSettingsManager(t.Context(), kubeclientset, fakeNamespace), kubeclientset)
+			servers, err := db.GetClusterServersByName(t.Context(), "duplicate")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"https://a", "https://b"}, servers)
+		}
+	})
+	t.Run("returns the lowest server of duplicates in lenient mode", func(t *testing.T) {
+		kubeclientset := fake.NewClientset(newConfigMap("lenient"), argoCDSecret, newClusterSecret("b", "https://b"), newClusterSecret("a", "https://a"))
+		db := NewDB(fakeNamespace, settings.NewSettingsManager(t.Context(), kubeclientset, fakeNamespace), kubeclientset)
+		servers, err := db.GetClusterServersByName(t.Context(), "duplicate")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://a"}, servers)
+	})
+	t.Run("fails on an invalid resolution", func(t *testing.T) {
+		kubeclientset := fake.NewClientset(newConfigMap("random"), argoCDSecret, newClusterSecret("b", "https://b"), newClusterSecret("a", "https://a"))
+		db := NewDB(fakeNamespace, settings.NewSettingsManager(t.Context(), kubeclientset, fakeNamespace), kubeclientset)
+		_, err := db.GetClusterServersByName(t.Context(), "duplicate")
+		require.ErrorContains(t, err, "invalid cluster.nameResolution")
+	})
 }
 
 // TestClusterRaceConditionClusterSecrets reproduces a race condition
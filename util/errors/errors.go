@@ -11,6 +11,12 @@ import (
 const (
 	// ErrorGeneric is returned for generic error
 	ErrorGeneric = 20
+	// ErrorWaitTimeout is returned when the conditions waited for were not met before the timeout
+	ErrorWaitTimeout = 21
+	// ErrorWaitDegraded is returned when waiting ended because the application became degraded
+	ErrorWaitDegraded = 22
+	// ErrorWaitOperationFailed is returned when waiting ended because the operation of the application failed
+	ErrorWaitOperationFailed = 23
 )
 
 type Handler struct {
@@ -0,0 +1,82 @@
+package lua
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// HealthScriptGuard validates the health scripts of the resource overrides as they are reloaded, so that a script
+// which fails to compile does not replace the last script of the same override which compiled.
+type HealthScriptGuard struct {
+	lock sync.Mutex
+	// valid are the last health scripts which compiled, by resource override key
+	valid map[string]string
+	// rejected are the last health scripts which failed to compile, by resource override key
+	rejected map[string]string
+}
+
+func NewHealthScriptGuard() *HealthScriptGuard {
+	return &HealthScriptGuard{valid: map[string]string{}, rejected: map[string]string{}}
+}
+
+// ValidateHealthScript returns the error of the given health script if it fails to compile
+func ValidateHealthScript(script string) error {
+	l := lua.NewState(lua.Options{SkipOpenLibs: true})
+	defer l.Close()
+	if _, err := l.LoadString(script); err != nil {
+		var apiErr *lua.ApiError
+		if errors.As(err, &apiErr) {
+			return errors.New(apiErr.Object.String())
+		}
+		return err
+	}
+	return nil
+}
+
+// Apply returns the given overrides, the health scripts which fail to compile being replaced by the last scripts of
+// the same overrides which compiled, or removed if none did. It also returns the errors of the scripts which fail to
+// compile and were not rejected by a previous call, by key. A nil guard returns the overrides as is.
+func (g *HealthScriptGuard) Apply(overrides map[string]appv1.ResourceOverride) (map[string]appv1.ResourceOverride, map[string]error) {
+	if g == nil {
+		return overrides, nil
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	result := maps.Clone(overrides)
+	errs := map[string]error{}
+	for key, override := range overrides {
+		if override.HealthLua == "" || override.HealthLua == g.valid[key] {
+			delete(g.rejected, key)
+			continue
+		}
+		if override.HealthLua != g.rejected[key] {
+			err := ValidateHealthScript(override.HealthLua)
+			if err == nil {
+				g.valid[key] = override.HealthLua
+				delete(g.rejected, key)
+				continue
+			}
+			g.rejected[key] = override.HealthLua
+			errs[key] = fmt.Errorf("health script of %s fails to compile: %w", key, err)
+		}
+		override.HealthLua = g.valid[key]
+		result[key] = override
+	}
+	for key := range g.valid {
+		if overrides[key].HealthLua == "" {
+			delete(g.valid, key)
+		}
+	}
+	for key := range g.rejected {
+		if _, ok := overrides[key]; !ok {
+			delete(g.rejected, key)
+		}
+	}
+	return result, errs
+}
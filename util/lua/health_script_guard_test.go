@@ -0,0 +1,57 @@
+package lua
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+const (
+	validHealthScript   = `return {status = "Healthy"}`
+	validHealthScript2  = `return {status = "Progressing"}`
+	invalidHealthScript = `return {status = `
+)
+
+func TestValidateHealthScript(t *testing.T) {
+	require.NoError(t, ValidateHealthScript(validHealthScript))
+	require.Error(t, ValidateHealthScript(invalidHealthScript))
+}
+
+func TestHealthScriptGuard(t *testing.T) {
+	guard := NewHealthScriptGuard()
+
+	overrides, errs := guard.Apply(map[string]appv1.ResourceOverride{"apps/Deployment": {HealthLua: validHealthScript}})
+	assert.Empty(t, errs)
+	assert.Equal(t, validHealthScript, overrides["apps/Deployment"].HealthLua)
+
+	// a broken script keeps the previous one, and is only reported once
+	broken := map[string]appv1.ResourceOverride{"apps/Deployment": {HealthLua: invalidHealthScript}}
+	overrides, errs = guard.Apply(broken)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs["apps/Deployment"], "health script of apps/Deployment fails to compile")
+	assert.Equal(t, validHealthScript, overrides["apps/Deployment"].HealthLua)
+	assert.Equal(t, invalidHealthScript, broken["apps/Deployment"].HealthLua)
+	overrides, errs = guard.Apply(broken)
+	assert.Empty(t, errs)
+	assert.Equal(t, validHealthScript, overrides["apps/Deployment"].HealthLua)
+
+	overrides, errs = guard.Apply(map[string]appv1.ResourceOverride{"apps/Deployment": {HealthLua: validHealthScript2}})
+	assert.Empty(t, errs)
+	assert.Equal(t, validHealthScript2, overrides["apps/Deployment"].HealthLua)
+
+	// a broken script without a previous one is not applied
+	overrides, errs = guard.Apply(map[string]appv1.ResourceOverride{"Service": {HealthLua: invalidHealthScript, UseOpenLibs: true}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, appv1.ResourceOverride{UseOpenLibs: true}, overrides["Service"])
+}
+
+func TestHealthScriptGuardNil(t *testing.T) {
+	var guard *HealthScriptGuard
+	overrides := map[string]appv1.ResourceOverride{"Service": {HealthLua: invalidHealthScript}}
+	result, errs := guard.Apply(overrides)
+	assert.Empty(t, errs)
+	assert.Equal(t, overrides, result)
+}
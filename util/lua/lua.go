@@ -26,6 +26,7 @@ import (
 	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/resource_customizations"
 	argoglob "github.com/argoproj/argo-cd/v3/util/glob"
+	"github.com/argoproj/argo-cd/v3/util/settings"
 )
 
 const (
@@ -61,6 +62,66 @@ func (overrides ResourceHealthOverrides) GetResourceHealth(obj *unstructured.Uns
 	return result, nil
 }
 
+// AgeAwareHealthOverride assesses the health of resources with the wrapped health override, or with the built-in health
+// checks if the override has none for the resource, and marks the resources which are still Progressing after the
+// maximum age configured for their group and kind as Degraded.
+type AgeAwareHealthOverride struct {
+	HealthOverride health.HealthOverride
+	Thresholds     []settings.ResourceHealthAgeThreshold
+}
+
+// NewResourceHealthOverride returns the health override of the given resource overrides, which also considers the age
+// of resources if any age threshold is given
+func NewResourceHealthOverride(overrides map[string]appv1.ResourceOverride, thresholds []settings.ResourceHealthAgeThreshold) health.HealthOverride {
+	if len(thresholds) == 0 {
+		return ResourceHealthOverrides(overrides)
+	}
+	return AgeAwareHealthOverride{HealthOverride: ResourceHealthOverrides(overrides), Thresholds: thresholds}
+}
+
+func (o AgeAwareHealthOverride) GetResourceHealth(obj *unstructured.Unstructured) (*health.HealthStatus, error) {
+	healthStatus, err := o.HealthOverride.GetResourceHealth(obj)
+	if err != nil {
+		return nil, err
+	}
+	if healthStatus == nil {
+		healthCheck := health.GetHealthCheckFunc(obj.GroupVersionKind())
+		if healthCheck == nil {
+			return nil, nil
+		}
+		if healthStatus, err = healthCheck(obj); err != nil || healthStatus == nil {
+			return healthStatus, err
+		}
+	}
+	if healthStatus.Status != health.HealthStatusProgressing || obj.GetDeletionTimestamp() != nil {
+		return healthStatus, nil
+	}
+	age, ok := resourceAge(obj)
+	if !ok {
+		return healthStatus, nil
+	}
+	gvk := obj.GroupVersionKind()
+	for _, threshold := range o.Thresholds {
+		if threshold.Match(gvk.Group, gvk.Kind) && age > threshold.MaxProgressingAge.Duration {
+			message := fmt.Sprintf("Resource has been progressing for more than %s since its creation", threshold.MaxProgressingAge.Duration)
+			if healthStatus.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, healthStatus.Message)
+			}
+			return &health.HealthStatus{Status: health.HealthStatusDegraded, Message: message}, nil
+		}
+	}
+	return healthStatus, nil
+}
+
+// resourceAge returns the time since the creation of the resource, if it has a creation timestamp
+func resourceAge(obj *unstructured.Unstructured) (time.Duration, bool) {
+	created := obj.GetCreationTimestamp()
+	if created.IsZero() {
+		return 0, false
+	}
+	return time.Since(created.Time), true
+}
+
 // VM Defines a struct that implements the luaVM
 type VM struct {
 	ResourceOverrides map[string]appv1.ResourceOverride
@@ -113,6 +174,10 @@ func (vm VM) runLuaWithResourceActionParameters(obj *unstructured.Unstructured,
 
 	objectValue := decodeValue(l, obj.Object)
 	l.SetGlobal("obj", objectValue)
+	// the age of the resource in seconds, e.g. to degrade resources which have been progressing for too long
+	if age, ok := resourceAge(obj); ok {
+		l.SetGlobal("objAge", lua.LNumber(age.Seconds()))
+	}
 	err := l.DoString(script)
 
 	// Remove the default lua stack trace from execution errors since these
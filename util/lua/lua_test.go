@@ -4,17 +4,20 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/argoproj/gitops-engine/pkg/health"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	lua "github.com/yuin/gopher-lua"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 
 	applicationpkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
 	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/util/grpc"
+	"github.com/argoproj/argo-cd/v3/util/settings"
 )
 
 const objJSON = `
@@ -1082,3 +1085,59 @@ func Test_getHealthScriptPaths(t *testing.T) {
 		"grafana-org-operator.kubitus-project.gitlab.io/_",
 	}, paths)
 }
+
+func TestAgeAwareHealthOverride(t *testing.T) {
+	newJob := func(age time.Duration) *unstructured.Unstructured {
+		job := StrToUnstructured(`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: test
+  namespace: default
+status:
+  active: 1`)
+		job.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-age)))
+		return job
+	}
+	thresholds := []settings.ResourceHealthAgeThreshold{{Group: "batch", Kind: "Job", MaxProgressingAge: metav1.Duration{Duration: time.Hour}}}
+
+	t.Run("BuiltIn", func(t *testing.T) {
+		override := NewResourceHealthOverride(nil, thresholds)
+		status, err := health.GetResourceHealth(newJob(10*time.Minute), override)
+		require.NoError(t, err)
+		assert.Equal(t, health.HealthStatusProgressing, status.Status)
+
+		status, err = health.GetResourceHealth(newJob(6*time.Hour), override)
+		require.NoError(t, err)
+		assert.Equal(t, health.HealthStatusDegraded, status.Status)
+		assert.Contains(t, status.Message, "progressing for more than 1h0m0s")
+	})
+
+	t.Run("NoThreshold", func(t *testing.T) {
+		status, err := health.GetResourceHealth(newJob(6*time.Hour), NewResourceHealthOverride(nil, nil))
+		require.NoError(t, err)
+		assert.Equal(t, health.HealthStatusProgressing, status.Status)
+	})
+
+	t.Run("CustomScript", func(t *testing.T) {
+		overrides := map[string]appv1.ResourceOverride{
+			"batch/Job": {HealthLua: `
+hs = {status = "Progressing"}
+if objAge ~= nil and objAge > 1800 then
+  hs.message = "Running for more than 30 minutes"
+end
+return hs`},
+		}
+		status, err := health.GetResourceHealth(newJob(10*time.Minute), NewResourceHealthOverride(overrides, nil))
+		require.NoError(t, err)
+		assert.Equal(t, health.HealthStatus{Status: health.HealthStatusProgressing}, *status)
+
+		status, err = health.GetResourceHealth(newJob(time.Hour), NewResourceHealthOverride(overrides, nil))
+		require.NoError(t, err)
+		assert.Equal(t, "Running for more than 30 minutes", status.Message)
+
+		status, err = health.GetResourceHealth(newJob(6*time.Hour), NewResourceHealthOverride(overrides, thresholds))
+		require.NoError(t, err)
+		assert.Equal(t, health.HealthStatusDegraded, status.Status)
+	})
+}
@@ -0,0 +1,61 @@
+package settings
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultAutoResourceActionMinInterval is the minimum time between two runs of an auto resource action on the same
+// resource when no interval is configured
+const defaultAutoResourceActionMinInterval = time.Hour
+
+// AutoResourceAction binds a resource action to a health status. The application controller runs the action on
+// resources of the given group and kind which have reported the health status for at least After.
+type AutoResourceAction struct {
+	// Group is the API group of the resource, empty for the core group
+	Group string `json:"group,omitempty"`
+	// Kind is the kind of the resource
+	Kind string `json:"kind"`
+	// Health is the health status which triggers the action
+	Health health.HealthStatusCode `json:"health"`
+	// After is how long the resource must have reported the health status before the action is run
+	After metav1.Duration `json:"after,omitempty"`
+	// Action is the name of the resource action to run
+	Action string `json:"action"`
+	// MinInterval is the minimum time between two runs of the action on the same resource
+	MinInterval *metav1.Duration `json:"minInterval,omitempty"`
+}
+
+// Match returns whether the binding applies to resources with the given group and kind
+func (a AutoResourceAction) Match(group, kind string) bool {
+	return a.Group == group && a.Kind == kind
+}
+
+// GetMinInterval returns the minimum time between two runs of the action on the same resource
+func (a AutoResourceAction) GetMinInterval() time.Duration {
+	if a.MinInterval == nil {
+		return defaultAutoResourceActionMinInterval
+	}
+	return a.MinInterval.Duration
+}
+
+func (a AutoResourceAction) validate() error {
+	if a.Kind == "" {
+		return fmt.Errorf("kind is required")
+	}
+	if a.Action == "" {
+		return fmt.Errorf("action is required for %s/%s", a.Group, a.Kind)
+	}
+	switch a.Health {
+	case health.HealthStatusUnknown, health.HealthStatusProgressing, health.HealthStatusHealthy, health.HealthStatusSuspended, health.HealthStatusDegraded, health.HealthStatusMissing:
+	default:
+		return fmt.Errorf("invalid health status %q for %s/%s", a.Health, a.Group, a.Kind)
+	}
+	if a.After.Duration < 0 || a.GetMinInterval() < 0 {
+		return fmt.Errorf("durations must not be negative for %s/%s", a.Group, a.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package settings
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceHealthAgeThreshold bounds how long resources of the given group and kind may report the Progressing health
+// status. Resources older than MaxProgressingAge which are still Progressing are Degraded.
+type ResourceHealthAgeThreshold struct {
+	// Group is the API group of the resource, empty for the core group
+	Group string `json:"group,omitempty"`
+	// Kind is the kind of the resource
+	Kind string `json:"kind"`
+	// MaxProgressingAge is the age, since the creation of the resource, after which a Progressing resource is Degraded
+	MaxProgressingAge metav1.Duration `json:"maxProgressingAge"`
+}
+
+// Match returns whether the threshold applies to resources with the given group and kind
+func (t ResourceHealthAgeThreshold) Match(group, kind string) bool {
+	return t.Group == group && t.Kind == kind
+}
+
+func (t ResourceHealthAgeThreshold) validate() error {
+	if t.Kind == "" {
+		return fmt.Errorf("kind is required")
+	}
+	if t.MaxProgressingAge.Duration <= 0 {
+		return fmt.Errorf("maxProgressingAge must be positive for %s/%s", t.Group, t.Kind)
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -473,6 +474,12 @@ const (
 	resourceExclusionsKey = "resource.exclusions"
 	// resourceInclusions is the key to the list of explicitly watched resources
 	resourceInclusionsKey = "resource.inclusions"
+	// clusterNameResolutionKey is the key to the resolution of cluster names shared by several clusters
+	clusterNameResolutionKey = "cluster.nameResolution"
+	// resourceAutoActionsKey is the key to the list of resource actions run automatically on health transitions
+	resourceAutoActionsKey = "resource.autoActions"
+	// resourceHealthAgeThresholdsKey is the key to the maximum ages of Progressing resources
+	resourceHealthAgeThresholdsKey = "resource.health.ageThresholds"
 	// resourceIgnoreResourceUpdatesEnabledKey is the key to a boolean determining whether the resourceIgnoreUpdates feature is enabled
 	resourceIgnoreResourceUpdatesEnabledKey = "resource.ignoreResourceUpdatesEnabled"
 	// resourceSensitiveAnnotationsKey is the key to list of annotations to mask in secret resource
@@ -556,6 +563,45 @@ const (
 	impersonationEnabledKey = "application.sync.impersonation.enabled"
 	// requireOverridePrivilegeForRevisionSyncKey is the key to configure whether giving an external revision during sync is considered an override
 	requireOverridePrivilegeForRevisionSyncKey = "application.sync.requireOverridePrivilegeForRevisionSync"
+	// historyArchiveSinkKey is the key to configure the sink that archives revision history entries trimmed from Applications
+	historyArchiveSinkKey = "application.history.archive.sink"
+	// historyArchiveLimitKey is the key to configure the maximum number of archived revision history entries per Application
+	historyArchiveLimitKey = "application.history.archive.limit"
+	// projectDeletionPolicyKey is the key to configure how Applications referencing a deleted AppProject are handled
+	projectDeletionPolicyKey = "application.projectDeletionPolicy"
+	// projectDeletionFallbackProjectKey is the key to configure the AppProject which orphaned Applications are reassigned to
+	projectDeletionFallbackProjectKey = "application.projectDeletionPolicy.fallbackProject"
+	// syncWindowsExclusivityGroupsKey is the key to configure groups of AppProjects whose allow sync windows are mutually exclusive
+	syncWindowsExclusivityGroupsKey = "syncWindows.exclusivityGroups"
+)
+
+// SyncWindowExclusivityGroup is a group of AppProjects whose allow sync windows must not be active at the same time.
+// While an allow window of one of the projects is active, the overlapping allow windows of the other projects of the
+// group are suppressed.
+type SyncWindowExclusivityGroup struct {
+	// Name is the name of the group
+	Name string `json:"name"`
+	// Projects are the names of the AppProjects of the group
+	Projects []string `json:"projects"`
+}
+
+// Contains returns whether the AppProject with the given name is part of the group
+func (g SyncWindowExclusivityGroup) Contains(project string) bool {
+	return slices.Contains(g.Projects, project)
+}
+
+// ProjectDeletionPolicy defines how Applications referencing a deleted AppProject are handled
+type ProjectDeletionPolicy string
+
+const (
+	// ProjectDeletionPolicyNone reports Applications referencing a missing AppProject with an invalid spec condition
+	ProjectDeletionPolicyNone ProjectDeletionPolicy = ""
+	// ProjectDeletionPolicyCondition reports Applications referencing a missing AppProject with an orphaned project condition
+	ProjectDeletionPolicyCondition ProjectDeletionPolicy = "condition"
+	// ProjectDeletionPolicyBlock blocks the deletion of AppProjects which are referenced by Applications, using a finalizer
+	ProjectDeletionPolicyBlock ProjectDeletionPolicy = "block"
+	// ProjectDeletionPolicyReassign reassigns Applications referencing a missing AppProject to a fallback AppProject
+	ProjectDeletionPolicyReassign ProjectDeletionPolicy = "reassign"
 )
 
 const (
@@ -564,6 +610,9 @@ const (
 
 	// application sync with impersonation feature is disabled by default.
 	defaultImpersonationEnabledFlag = false
+
+	// default max number of archived revision history entries per application
+	defaultHistoryArchiveLimit = 100
 )
 
 var sourceTypeToEnableGenerationKey = map[v1alpha1.ApplicationSourceType]string{
@@ -2405,6 +2454,145 @@ func (mgr *SettingsManager) IsImpersonationEnabled() (bool, error) {
 	return cm.Data[impersonationEnabledKey] == "true", nil
 }
 
+// GetHistoryArchiveSink returns the name of the sink used to archive revision history entries trimmed from
+// Applications, and the maximum number of archived entries retained per Application. An empty name means archival is
+// disabled.
+func (mgr *SettingsManager) GetHistoryArchiveSink() (string, int, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return "", defaultHistoryArchiveLimit, fmt.Errorf("error retrieving argocd-cm: %w", err)
+	}
+	sink := argoCDCM.Data[historyArchiveSinkKey]
+	limitStr := argoCDCM.Data[historyArchiveLimitKey]
+	if limitStr == "" {
+		return sink, defaultHistoryArchiveLimit, nil
+	}
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		return sink, defaultHistoryArchiveLimit, fmt.Errorf("error parsing %s: %w", historyArchiveLimitKey, err)
+	}
+	return sink, limit, nil
+}
+
+// ClusterNameResolution is how a cluster name shared by several clusters is resolved
+type ClusterNameResolution string
+
+const (
+	// ClusterNameResolutionStrict fails to resolve a cluster name shared by several clusters
+	ClusterNameResolutionStrict ClusterNameResolution = "strict"
+	// ClusterNameResolutionLenient resolves a cluster name shared by several clusters to the one with the lowest
+	// server URL
+	ClusterNameResolutionLenient ClusterNameResolution = "lenient"
+)
+
+// GetClusterNameResolution returns how a cluster name shared by several clusters is resolved, strict by default
+func (mgr *SettingsManager) GetClusterNameResolution() (ClusterNameResolution, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return ClusterNameResolutionStrict, fmt.Errorf("error retrieving argocd-cm: %w", err)
+	}
+	switch resolution := ClusterNameResolution(argoCDCM.Data[clusterNameResolutionKey]); resolution {
+	case "":
+		return ClusterNameResolutionStrict, nil
+	case ClusterNameResolutionStrict, ClusterNameResolutionLenient:
+		return resolution, nil
+	default:
+		return ClusterNameResolutionStrict, fmt.Errorf("invalid %s %q, must be %s or %s", clusterNameResolutionKey, resolution, ClusterNameResolutionStrict, ClusterNameResolutionLenient)
+	}
+}
+
+// GetAutoResourceActions returns the resource actions which the application controller runs automatically on
+// resources which report a given health status for long enough
+func (mgr *SettingsManager) GetAutoResourceActions() ([]AutoResourceAction, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving argocd-cm: %w", err)
+	}
+	value, ok := argoCDCM.Data[resourceAutoActionsKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	actions := make([]AutoResourceAction, 0)
+	if err := yaml.Unmarshal([]byte(value), &actions); err != nil {
+		return nil, fmt.Errorf("error unmarshalling %s: %w", resourceAutoActionsKey, err)
+	}
+	for _, action := range actions {
+		if err := action.validate(); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", resourceAutoActionsKey, err)
+		}
+	}
+	return actions, nil
+}
+
+// GetResourceHealthAgeThresholds returns the maximum ages of Progressing resources, after which they are Degraded
+func (mgr *SettingsManager) GetResourceHealthAgeThresholds() ([]ResourceHealthAgeThreshold, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving argocd-cm: %w", err)
+	}
+	value, ok := argoCDCM.Data[resourceHealthAgeThresholdsKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	thresholds := make([]ResourceHealthAgeThreshold, 0)
+	if err := yaml.Unmarshal([]byte(value), &thresholds); err != nil {
+		return nil, fmt.Errorf("error unmarshalling %s: %w", resourceHealthAgeThresholdsKey, err)
+	}
+	for _, threshold := range thresholds {
+		if err := threshold.validate(); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", resourceHealthAgeThresholdsKey, err)
+		}
+	}
+	return thresholds, nil
+}
+
+// GetSyncWindowExclusivityGroups returns the groups of AppProjects whose allow sync windows are mutually exclusive
+func (mgr *SettingsManager) GetSyncWindowExclusivityGroups() ([]SyncWindowExclusivityGroup, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving argocd-cm: %w", err)
+	}
+	value, ok := argoCDCM.Data[syncWindowsExclusivityGroupsKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	groups := make([]SyncWindowExclusivityGroup, 0)
+	if err := yaml.Unmarshal([]byte(value), &groups); err != nil {
+		return nil, fmt.Errorf("error unmarshalling %s: %w", syncWindowsExclusivityGroupsKey, err)
+	}
+	for _, group := range groups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("invalid %s: name is required", syncWindowsExclusivityGroupsKey)
+		}
+		if len(group.Projects) < 2 {
+			return nil, fmt.Errorf("invalid %s: group %s must have at least two projects", syncWindowsExclusivityGroupsKey, group.Name)
+		}
+	}
+	return groups, nil
+}
+
+// GetProjectDeletionPolicy returns how Applications referencing a deleted AppProject are handled, and the AppProject
+// orphaned Applications are reassigned to if the policy is ProjectDeletionPolicyReassign
+func (mgr *SettingsManager) GetProjectDeletionPolicy() (ProjectDeletionPolicy, string, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return ProjectDeletionPolicyNone, "", fmt.Errorf("error retrieving argocd-cm: %w", err)
+	}
+	policy := ProjectDeletionPolicy(argoCDCM.Data[projectDeletionPolicyKey])
+	switch policy {
+	case ProjectDeletionPolicyNone, ProjectDeletionPolicyCondition, ProjectDeletionPolicyBlock:
+		return policy, "", nil
+	case ProjectDeletionPolicyReassign:
+		fallback := argoCDCM.Data[projectDeletionFallbackProjectKey]
+		if fallback == "" {
+			return ProjectDeletionPolicyNone, "", fmt.Errorf("%s is required when %s is %s", projectDeletionFallbackProjectKey, projectDeletionPolicyKey, policy)
+		}
+		return policy, fallback, nil
+	default:
+		return ProjectDeletionPolicyNone, "", fmt.Errorf("invalid %s %q, must be one of %s, %s or %s", projectDeletionPolicyKey, policy, ProjectDeletionPolicyCondition, ProjectDeletionPolicyBlock, ProjectDeletionPolicyReassign)
+	}
+}
+
 func (mgr *SettingsManager) GetAllowedNodeLabels() []string {
 	labelKeys := []string{}
 	argoCDCM, err := mgr.getConfigMap()
@@ -2302,3 +2302,179 @@ func TestSettingsManager_GetAllowedNodeLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestSettingsManager_GetAutoResourceActions(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{})
+		actions, err := settingsManager.GetAutoResourceActions()
+		require.NoError(t, err)
+		assert.Empty(t, actions)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"resource.autoActions": `
+- group: apps
+  kind: Deployment
+  health: Degraded
+  after: 10m
+  action: restart
+- kind: Pod
+  health: Unknown
+  action: delete
+  minInterval: 5m`,
+		})
+		actions, err := settingsManager.GetAutoResourceActions()
+		require.NoError(t, err)
+		require.Len(t, actions, 2)
+		assert.True(t, actions[0].Match("apps", "Deployment"))
+		assert.False(t, actions[0].Match("", "Deployment"))
+		assert.Equal(t, 10*time.Minute, actions[0].After.Duration)
+		assert.Equal(t, time.Hour, actions[0].GetMinInterval())
+		assert.True(t, actions[1].Match("", "Pod"))
+		assert.Equal(t, 5*time.Minute, actions[1].GetMinInterval())
+	})
+	t.Run("InvalidHealth", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"resource.autoActions": `[{kind: Pod, health: Broken, action: delete}]`,
+		})
+		_, err := settingsManager.GetAutoResourceActions()
+		require.ErrorContains(t, err, `invalid health status "Broken"`)
+	})
+	t.Run("MissingAction", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"resource.autoActions": `[{kind: Pod, health: Degraded}]`,
+		})
+		_, err := settingsManager.GetAutoResourceActions()
+		require.ErrorContains(t, err, "action is required")
+	})
+}
+
+func TestSettingsManager_GetResourceHealthAgeThresholds(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{})
+		thresholds, err := settingsManager.GetResourceHealthAgeThresholds()
+		require.NoError(t, err)
+		assert.Empty(t, thresholds)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"resource.health.ageThresholds": `
+- group: batch
+  kind: Job
+  maxProgressingAge: 6h
+- kind: Pod
+  maxProgressingAge: 30m`,
+		})
+		thresholds, err := settingsManager.GetResourceHealthAgeThresholds()
+		require.NoError(t, err)
+		require.Len(t, thresholds, 2)
+		assert.True(t, thresholds[0].Match("batch", "Job"))
+		assert.Equal(t, 6*time.Hour, thresholds[0].MaxProgressingAge.Duration)
+		assert.True(t, thresholds[1].Match("", "Pod"))
+	})
+	t.Run("MissingAge", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"resource.health.ageThresholds": `[{group: batch, kind: Job}]`,
+		})
+		_, err := settingsManager.GetResourceHealthAgeThresholds()
+		require.ErrorContains(t, err, "maxProgressingAge must be positive")
+	})
+}
+
+func TestSettingsManager_GetProjectDeletionPolicy(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{})
+		policy, fallback, err := settingsManager.GetProjectDeletionPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, ProjectDeletionPolicyNone, policy)
+		assert.Empty(t, fallback)
+	})
+	t.Run("Block", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"application.projectDeletionPolicy": "block",
+		})
+		policy, _, err := settingsManager.GetProjectDeletionPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, ProjectDeletionPolicyBlock, policy)
+	})
+	t.Run("Reassign", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"application.projectDeletionPolicy":                 "reassign",
+			"application.projectDeletionPolicy.fallbackProject": "orphans",
+		})
+		policy, fallback, err := settingsManager.GetProjectDeletionPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, ProjectDeletionPolicyReassign, policy)
+		assert.Equal(t, "orphans", fallback)
+	})
+	t.Run("ReassignWithoutFallback", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"application.projectDeletionPolicy": "reassign",
+		})
+		_, _, err := settingsManager.GetProjectDeletionPolicy()
+		require.ErrorContains(t, err, "application.projectDeletionPolicy.fallbackProject is required")
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"application.projectDeletionPolicy": "ignore",
+		})
+		_, _, err := settingsManager.GetProjectDeletionPolicy()
+		require.ErrorContains(t, err, `invalid application.projectDeletionPolicy "ignore"`)
+	})
+}
+
+func TestSettingsManager_GetSyncWindowExclusivityGroups(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{})
+		groups, err := settingsManager.GetSyncWindowExclusivityGroups()
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"syncWindows.exclusivityGroups": `
+- name: shared-infra
+  projects: [team-a, team-b]`,
+		})
+		groups, err := settingsManager.GetSyncWindowExclusivityGroups()
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "shared-infra", groups[0].Name)
+		assert.True(t, groups[0].Contains("team-b"))
+		assert.False(t, groups[0].Contains("team-c"))
+	})
+	t.Run("SingleProject", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"syncWindows.exclusivityGroups": `[{name: shared-infra, projects: [team-a]}]`,
+		})
+		_, err := settingsManager.GetSyncWindowExclusivityGroups()
+		require.ErrorContains(t, err, "group shared-infra must have at least two projects")
+	})
+	t.Run("MissingName", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{
+			"syncWindows.exclusivityGroups": `[{projects: [team-a, team-b]}]`,
+		})
+		_, err := settingsManager.GetSyncWindowExclusivityGroups()
+		require.ErrorContains(t, err, "name is required")
+	})
+}
+
+func TestGetClusterNameResolution(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), nil)
+		resolution, err := settingsManager.GetClusterNameResolution()
+		require.NoError(t, err)
+		assert.Equal(t, ClusterNameResolutionStrict, resolution)
+	})
+	t.Run("Lenient", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{"cluster.nameResolution": "lenient"})
+		resolution, err := settingsManager.GetClusterNameResolution()
+		require.NoError(t, err)
+		assert.Equal(t, ClusterNameResolutionLenient, resolution)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, settingsManager := fixtures(t.Context(), map[string]string{"cluster.nameResolution": "random"})
+		_, err := settingsManager.GetClusterNameResolution()
+		require.ErrorContains(t, err, `invalid cluster.nameResolution "random"`)
+	})
+}